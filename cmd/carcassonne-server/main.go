@@ -0,0 +1,82 @@
+// Command carcassonne-server runs a single long-lived game engine
+// and exposes it over HTTP with JSON bodies, see the server package for the endpoints.
+//
+// Usage:
+//
+//	carcassonne-server [flags]
+//
+// The server listens on a TCP address or on a Unix socket (e.g. -listen unix:/tmp/engine.sock)
+// and shuts down gracefully on SIGINT or SIGTERM.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/server"
+)
+
+func main() {
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	address := flags.String(
+		"listen", "127.0.0.1:8080",
+		"TCP address or a Unix socket path prefixed with \""+server.UnixAddressPrefix+"\" to listen on",
+	)
+	workers := flags.Int("workers", runtime.NumCPU(), "number of the engine's workers")
+	logDir := flags.String("log-dir", "", "directory for the game logs, empty to disable logging")
+	orderedLocking := flags.Bool(
+		"ordered-locking", false,
+		"queue the requests for the same game in submission order instead of failing them",
+	)
+	//nolint:errcheck// flag.ExitOnError makes Parse() exit instead of returning an error
+	flags.Parse(os.Args[1:])
+
+	if err := run(*address, *workers, *logDir, *orderedLocking); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(address string, workers int, logDir string, orderedLocking bool) error {
+	eng, err := engine.StartGameEngine(workers, logDir)
+	if err != nil {
+		return err
+	}
+	defer eng.Close()
+	eng.SetOrderedLocking(orderedLocking)
+
+	listener, err := server.Listen(address)
+	if err != nil {
+		return err
+	}
+	httpServer := &http.Server{Handler: server.New(eng)} //nolint:gosec// only meant to listen locally
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+	log.Printf("listening on %v", listener.Addr())
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	log.Print("shutting down")
+	if err := httpServer.Shutdown(context.Background()); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
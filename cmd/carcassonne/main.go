@@ -0,0 +1,81 @@
+// Command carcassonne plays a tournament of seeded games between the given agents
+// and prints the final scores of each game.
+//
+// Usage:
+//
+//	carcassonne [flags] <agent> <agent> [<agent>...]
+//
+// Each agent plays as one of the players (in the given order) and can be one of:
+//   - random - chooses uniformly at random from all legal moves
+//   - greedy - chooses the move with the best immediate outcome
+//   - mcts - chooses the move using Monte Carlo Tree Search
+//   - exec:<command> - external agent running as a separate process, which receives
+//     a JSON line with the serialized game and the legal moves in each of its turns
+//     and replies with a JSON line containing the index of the chosen move: {"move": 0}
+//
+// The engine writes the JSONL log of each game to the log directory,
+// the games can be reproduced with the same seed and agents.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func main() {
+	config := tournamentConfig{}
+	mctsConfig := agents.DefaultMCTSConfig()
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags] <agent> <agent> [<agent>...]\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Agents: random, greedy, mcts, exec:<command>")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(
+		&config.tileSet, "tileset", "standard",
+		"tile set of the games, one of: "+strings.Join(tilesets.Names(), ", "),
+	)
+	flags.StringVar(
+		&config.ruleset, "rules", "standard",
+		"ruleset of the games, one of: "+strings.Join(rules.Names(), ", "),
+	)
+	flags.IntVar(&config.games, "games", 1, "number of games to play")
+	flags.Int64Var(&config.seed, "seed", 1, "seed of the first game, every next game uses the next seed")
+	flags.IntVar(&config.workers, "workers", runtime.NumCPU(), "number of the engine's workers")
+	flags.StringVar(&config.logDir, "log-dir", "logs", "directory for the game logs, empty to disable logging")
+	flags.IntVar(&mctsConfig.Iterations, "mcts-iterations", mctsConfig.Iterations, "iterations of the mcts agent per move")
+	flags.IntVar(&mctsConfig.Workers, "mcts-workers", mctsConfig.Workers, "goroutines used by the mcts agent")
+	flags.IntVar(
+		&mctsConfig.RolloutDepth, "mcts-rollout-depth", mctsConfig.RolloutDepth,
+		"maximum number of random turns after leaving the mcts tree, 0 for no limit",
+	)
+	//nolint:errcheck// flag.ExitOnError makes Parse() exit instead of returning an error
+	flags.Parse(os.Args[1:])
+
+	config.agents = flags.Args()
+	if len(config.agents) < engine.MinPlayerCount || len(config.agents) > engine.MaxPlayerCount {
+		fmt.Fprintf(
+			os.Stderr, "expected %v-%v agents, got %v\n",
+			engine.MinPlayerCount, engine.MaxPlayerCount, len(config.agents),
+		)
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	newConfiguredPlayer := func(spec string, seed int64) (player, error) {
+		return newPlayer(spec, seed, mctsConfig)
+	}
+	if _, err := runTournament(config, newConfiguredPlayer, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+)
+
+const externalAgentPrefix = "exec:"
+
+var (
+	errUnknownAgent         = errors.New("unknown agent")
+	errInvalidExternalReply = errors.New("invalid reply from the external agent")
+)
+
+// Plays the turns of a single seat in all of the games of the tournament.
+type player interface {
+	chooseMove(eng *engine.GameEngine, gameID int, serialized game.SerializedGame) (elements.PlacedTile, error)
+	close() error
+}
+
+// Creates the player from the agent specification:
+//   - "random", "greedy" or "mcts" for one of the built-in agents
+//   - "exec:<command>" for an external agent, see externalPlayer
+func newPlayer(spec string, seed int64, mctsConfig agents.MCTSConfig) (player, error) {
+	switch spec {
+	case "random":
+		return &builtinPlayer{agent: agents.NewRandomAgent(seed)}, nil
+	case "greedy":
+		return &builtinPlayer{agent: agents.NewGreedyAgent(seed)}, nil
+	case "mcts":
+		return &builtinPlayer{agent: agents.NewMCTSAgent(mctsConfig, seed)}, nil
+	}
+	if command, ok := strings.CutPrefix(spec, externalAgentPrefix); ok {
+		return newExternalPlayer(command)
+	}
+	return nil, fmt.Errorf("%w: %q", errUnknownAgent, spec)
+}
+
+// Player using one of the agents from the agents package,
+// the agent chooses the move inside the engine.
+type builtinPlayer struct {
+	agent agents.Agent
+}
+
+func (player *builtinPlayer) chooseMove(
+	eng *engine.GameEngine, gameID int, _ game.SerializedGame,
+) (elements.PlacedTile, error) {
+	req := &engine.ChooseMoveRequest{GameID: gameID, Agent: player.agent}
+	resp := eng.SendChooseMoveBatch([]*engine.ChooseMoveRequest{req})[0]
+	return resp.Move, resp.Err()
+}
+
+func (player *builtinPlayer) close() error {
+	return nil
+}
+
+// What the external agent receives in each of its turns (as a single line of JSON).
+type externalAgentQuery struct {
+	Game  game.SerializedGame   `json:"game"`
+	Moves []elements.PlacedTile `json:"moves"`
+}
+
+// What the external agent replies with (as a single line of JSON).
+type externalAgentReply struct {
+	// index in the moves of the query
+	Move int `json:"move"`
+}
+
+// Player using an agent running in a separate process.
+//
+// The process is started once for the whole tournament. In each of the player's turns,
+// it receives an externalAgentQuery on its standard input and is expected
+// to write an externalAgentReply to its standard output.
+// The standard error of the process is passed through.
+type externalPlayer struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	stdout  *bufio.Scanner
+	encoder *json.Encoder
+}
+
+func newExternalPlayer(command string) (*externalPlayer, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: empty command", errUnknownAgent)
+	}
+	cmd := exec.Command(args[0], args[1:]...) //nolint:gosec// running the user-provided agent is the point
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	// the serialized game can get quite long
+	scanner.Buffer(nil, 16*1024*1024)
+	return &externalPlayer{
+		cmd:     cmd,
+		stdin:   stdin,
+		stdout:  scanner,
+		encoder: json.NewEncoder(stdin),
+	}, nil
+}
+
+func (player *externalPlayer) chooseMove(
+	eng *engine.GameEngine, gameID int, serialized game.SerializedGame,
+) (elements.PlacedTile, error) {
+	req := &engine.GetLegalMovesRequest{BaseGameID: gameID, TileToPlace: serialized.CurrentTile}
+	resp := eng.SendGetLegalMovesBatch([]*engine.GetLegalMovesRequest{req})[0]
+	if resp.Err() != nil {
+		return elements.PlacedTile{}, resp.Err()
+	}
+	query := externalAgentQuery{Game: serialized, Moves: make([]elements.PlacedTile, len(resp.Moves))}
+	for i, move := range resp.Moves {
+		query.Moves[i] = move.Move
+	}
+
+	if err := player.encoder.Encode(query); err != nil {
+		return elements.PlacedTile{}, err
+	}
+	if !player.stdout.Scan() {
+		if err := player.stdout.Err(); err != nil {
+			return elements.PlacedTile{}, err
+		}
+		return elements.PlacedTile{}, fmt.Errorf("%w: %w", errInvalidExternalReply, io.ErrUnexpectedEOF)
+	}
+	var reply externalAgentReply
+	if err := json.Unmarshal(player.stdout.Bytes(), &reply); err != nil {
+		return elements.PlacedTile{}, fmt.Errorf("%w: %w", errInvalidExternalReply, err)
+	}
+	if reply.Move < 0 || reply.Move >= len(query.Moves) {
+		return elements.PlacedTile{}, fmt.Errorf(
+			"%w: move index %v out of range (%v moves)", errInvalidExternalReply, reply.Move, len(query.Moves),
+		)
+	}
+	return query.Moves[reply.Move], nil
+}
+
+// Closes the standard input of the process and waits for it to exit.
+func (player *externalPlayer) close() error {
+	if err := player.stdin.Close(); err != nil {
+		return err
+	}
+	return player.cmd.Wait()
+}
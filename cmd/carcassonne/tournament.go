@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+type tournamentConfig struct {
+	tileSet string
+	ruleset string
+	// agent specifications (see newPlayer()), one for each of the players
+	agents []string
+	games  int
+	// the seed of the first game, every next game uses the next seed
+	seed    int64
+	workers int
+	logDir  string
+}
+
+// Results of a single game of the tournament.
+type gameResult struct {
+	gameID int
+	seed   int64
+	// final scores of the players, indexed by player ID - 1
+	scores []uint32
+	// report of the final scoring, with the meeples returned at the end of the game
+	scoreReport elements.ScoreReport
+	// trade goods of the players at the end of the game, indexed by player ID - 1
+	tradeGoods [][]uint8
+}
+
+var meepleTypeNames = map[elements.MeepleType]string{
+	elements.NormalMeeple:  "normal",
+	elements.BigMeeple:     "big",
+	elements.BuilderMeeple: "builder",
+	elements.PigMeeple:     "pig",
+	elements.AbbotMeeple:   "abbot",
+}
+
+var tradeGoodNames = map[elements.TradeGood]string{
+	elements.Wine:  "wine",
+	elements.Grain: "grain",
+	elements.Cloth: "cloth",
+}
+
+// Plays the games of the tournament one after another,
+// printing the final score report of each game and the summary to the given writer.
+func runTournament(
+	config tournamentConfig, newPlayer func(spec string, seed int64) (player, error), out io.Writer,
+) (results []gameResult, err error) {
+	// validate the names before starting any of the players
+	if _, err := tilesets.ByName(config.tileSet); err != nil {
+		return nil, err
+	}
+	ruleset, err := rules.ByName(config.ruleset)
+	if err != nil {
+		return nil, err
+	}
+
+	players := []player{}
+	defer func() {
+		for _, player := range players {
+			if closeErr := player.close(); err == nil {
+				err = closeErr
+			}
+		}
+	}()
+	for i, spec := range config.agents {
+		player, err := newPlayer(spec, config.seed+int64(i))
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, player)
+	}
+
+	eng, err := engine.StartGameEngine(config.workers, config.logDir)
+	if err != nil {
+		return nil, err
+	}
+	defer eng.Close()
+
+	results = []gameResult{}
+	for i := range config.games {
+		seed := config.seed + int64(i)
+		// each game gets its own copy of the tile set
+		tileSet, err := tilesets.ByName(config.tileSet)
+		if err != nil {
+			return results, err
+		}
+		result, err := playGame(eng, tileSet, ruleset, seed, players)
+		if err != nil {
+			return results, fmt.Errorf("game with seed %v: %w", seed, err)
+		}
+		results = append(results, result)
+		printGameResult(config, result, out)
+	}
+
+	printSummary(config, results, out)
+	return results, nil
+}
+
+func playGame(
+	eng *engine.GameEngine, tileSet tilesets.TileSet, ruleset rules.Ruleset, seed int64, players []player,
+) (gameResult, error) {
+	gameWithID, err := eng.GenerateSeededGame(tileSet, seed, len(players), ruleset)
+	if err != nil {
+		return gameResult{}, err
+	}
+	defer eng.DeleteGames([]int{gameWithID.ID})
+
+	serialized := gameWithID.Game
+	for {
+		player := players[serialized.CurrentPlayerID-1]
+		move, err := player.chooseMove(eng, gameWithID.ID, serialized)
+		if err != nil {
+			return gameResult{}, err
+		}
+
+		req := &engine.PlayTurnRequest{GameID: gameWithID.ID, Move: move}
+		resp := eng.SendPlayTurnBatch([]*engine.PlayTurnRequest{req})[0]
+		if resp.Err() != nil {
+			return gameResult{}, resp.Err()
+		}
+		if resp.FinalScores != nil {
+			result := gameResult{
+				gameID:      gameWithID.ID,
+				seed:        seed,
+				scores:      make([]uint32, len(players)),
+				scoreReport: *resp.FinalScoreReport,
+				tradeGoods:  make([][]uint8, len(players)),
+			}
+			for i := range players {
+				result.scores[i] = resp.FinalScores[elements.ID(i+1)]
+				result.tradeGoods[i] = resp.Game.Players[i].TradeGoods
+			}
+			return result, nil
+		}
+		serialized = resp.Game
+	}
+}
+
+// Prints the final scores of the game, followed by the meeples returned
+// in the final scoring and the trade goods of each player.
+func printGameResult(config tournamentConfig, result gameResult, out io.Writer) {
+	fmt.Fprintf(out, "game %v (seed %v):", result.gameID, result.seed)
+	for playerIndex, score := range result.scores {
+		fmt.Fprintf(out, " %v=%v", config.agents[playerIndex], score)
+	}
+	fmt.Fprintln(out)
+
+	for playerIndex, score := range result.scores {
+		playerID := elements.ID(playerIndex + 1)
+		meepleCounts := make([]int, elements.MeepleTypeCount)
+		for _, meeple := range result.scoreReport.ReturnedMeeples[playerID] {
+			meepleCounts[meeple.Type]++
+		}
+		returnedMeeples := []string{}
+		for meepleType, count := range meepleCounts {
+			if count != 0 {
+				returnedMeeples = append(
+					returnedMeeples, fmt.Sprintf("%v %v", count, meepleTypeNames[elements.MeepleType(meepleType)]),
+				)
+			}
+		}
+		if len(returnedMeeples) == 0 {
+			returnedMeeples = append(returnedMeeples, "none")
+		}
+		tradeGoods := []string{}
+		for tradeGood, count := range result.tradeGoods[playerIndex] {
+			tradeGoods = append(tradeGoods, fmt.Sprintf("%v %v", count, tradeGoodNames[elements.TradeGood(tradeGood)]))
+		}
+
+		fmt.Fprintf(
+			out, "  player %v (%v): %v points, returned meeples: %v, trade goods: %v\n",
+			playerID, config.agents[playerIndex], score,
+			strings.Join(returnedMeeples, ", "), strings.Join(tradeGoods, ", "),
+		)
+	}
+}
+
+// Prints the number of wins (split in case of a tie) and the average score of each player.
+func printSummary(config tournamentConfig, results []gameResult, out io.Writer) {
+	wins := make([]float64, len(config.agents))
+	totalScores := make([]uint64, len(config.agents))
+	for _, result := range results {
+		var bestScore uint32
+		for _, score := range result.scores {
+			bestScore = max(bestScore, score)
+		}
+		winners := 0
+		for _, score := range result.scores {
+			if score == bestScore {
+				winners++
+			}
+		}
+		for i, score := range result.scores {
+			totalScores[i] += uint64(score)
+			if score == bestScore {
+				wins[i] += 1 / float64(winners)
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "summary of %v games:\n", len(results))
+	for i, spec := range config.agents {
+		averageScore := 0.0
+		if len(results) != 0 {
+			averageScore = float64(totalScores[i]) / float64(len(results))
+		}
+		fmt.Fprintf(out, "  player %v (%v): %.1f wins, %.1f average score\n", i+1, spec, wins[i], averageScore)
+	}
+}
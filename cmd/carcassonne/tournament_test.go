@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+const externalAgentHelperEnv = "CARCASSONNE_TEST_EXTERNAL_AGENT"
+
+// Lets the test binary act as an external agent that always chooses the first move.
+func TestMain(m *testing.M) {
+	if os.Getenv(externalAgentHelperEnv) == "1" {
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(nil, 16*1024*1024)
+		for scanner.Scan() {
+			fmt.Println(`{"move": 0}`)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func newTestPlayer(spec string, seed int64) (player, error) {
+	return newPlayer(spec, seed, agents.MCTSConfig{Iterations: 10, Workers: 1, Exploration: 1})
+}
+
+func TestRunTournamentPlaysAllGamesAndWritesLogs(t *testing.T) {
+	logDir := t.TempDir()
+	config := tournamentConfig{
+		tileSet: "standard",
+		ruleset: "standard",
+		agents:  []string{"random", "greedy"},
+		games:   2,
+		seed:    7,
+		workers: 2,
+		logDir:  logDir,
+	}
+	out := &bytes.Buffer{}
+
+	results, err := runTournament(config, newTestPlayer, out)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if len(results) != config.games {
+		t.Fatalf("expected %#v, got %#v instead", config.games, len(results))
+	}
+	for i, result := range results {
+		expectedSeed := config.seed + int64(i)
+		if result.seed != expectedSeed {
+			t.Fatalf("expected %#v, got %#v instead", expectedSeed, result.seed)
+		}
+		if len(result.scores) != len(config.agents) {
+			t.Fatalf("expected %#v, got %#v instead", len(config.agents), len(result.scores))
+		}
+		for playerIndex, score := range result.scores {
+			if result.scoreReport.ReceivedPoints[elements.ID(playerIndex+1)] != score {
+				t.Fatalf("expected %#v, got %#v instead", result.scores, result.scoreReport.ReceivedPoints)
+			}
+		}
+		logFile := path.Join(logDir, fmt.Sprintf("%v.jsonl", result.gameID))
+		if _, err := os.Stat(logFile); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if !bytes.Contains(out.Bytes(), []byte("returned meeples:")) {
+		t.Fatalf("expected score reports in the output, got %#v instead", out.String())
+	}
+	if !bytes.Contains(out.Bytes(), []byte("summary of 2 games")) {
+		t.Fatalf("expected summary in the output, got %#v instead", out.String())
+	}
+}
+
+func TestRunTournamentIsReproducibleWithTheSameSeed(t *testing.T) {
+	config := tournamentConfig{
+		tileSet: "standard",
+		ruleset: "first-edition",
+		agents:  []string{"random", "random", "greedy"},
+		games:   1,
+		seed:    3,
+		workers: 1,
+	}
+
+	first, err := runTournament(config, newTestPlayer, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	second, err := runTournament(config, newTestPlayer, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for i := range first[0].scores {
+		if first[0].scores[i] != second[0].scores[i] {
+			t.Fatalf("expected %#v, got %#v instead", first[0].scores, second[0].scores)
+		}
+	}
+}
+
+func TestRunTournamentWithExternalAgent(t *testing.T) {
+	t.Setenv(externalAgentHelperEnv, "1")
+	config := tournamentConfig{
+		tileSet: "standard",
+		ruleset: "standard",
+		agents:  []string{externalAgentPrefix + os.Args[0], "random"},
+		games:   1,
+		seed:    1,
+		workers: 1,
+	}
+
+	results, err := runTournament(config, newTestPlayer, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected %#v, got %#v instead", 1, len(results))
+	}
+}
+
+func TestRunTournamentReturnsErrorForUnknownTileSet(t *testing.T) {
+	config := tournamentConfig{tileSet: "unknown", ruleset: "standard", agents: []string{"random", "random"}}
+
+	_, err := runTournament(config, newTestPlayer, &bytes.Buffer{})
+
+	if !errors.Is(err, tilesets.ErrUnknownTileSet) {
+		t.Fatalf("expected %#v, got %#v instead", tilesets.ErrUnknownTileSet, err)
+	}
+}
+
+func TestRunTournamentReturnsErrorForUnknownRuleset(t *testing.T) {
+	config := tournamentConfig{tileSet: "standard", ruleset: "unknown", agents: []string{"random", "random"}}
+
+	_, err := runTournament(config, newTestPlayer, &bytes.Buffer{})
+
+	if !errors.Is(err, rules.ErrUnknownRuleset) {
+		t.Fatalf("expected %#v, got %#v instead", rules.ErrUnknownRuleset, err)
+	}
+}
+
+func TestRunTournamentReturnsErrorForUnknownAgent(t *testing.T) {
+	config := tournamentConfig{tileSet: "standard", ruleset: "standard", agents: []string{"random", "unknown"}}
+
+	_, err := runTournament(config, newTestPlayer, &bytes.Buffer{})
+
+	if !errors.Is(err, errUnknownAgent) {
+		t.Fatalf("expected %#v, got %#v instead", errUnknownAgent, err)
+	}
+}
@@ -0,0 +1,98 @@
+// Command selfplay plays seeded games of the given policy against itself
+// and writes the training data of their turns to the shards in the output directory,
+// see the selfplay package for the schema of the records.
+//
+// Usage:
+//
+//	selfplay [flags]
+//
+// The policy can be one of:
+//   - random - chooses uniformly at random from all legal moves
+//   - greedy - chooses the move with the best immediate outcome
+//   - mcts - chooses the most visited move of Monte Carlo Tree Search,
+//     the only policy that records the visits
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/selfplay"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func main() {
+	config := selfplay.DefaultConfig()
+	mctsConfig := agents.DefaultMCTSConfig()
+	var policy string
+	var workers int
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags.StringVar(
+		&config.TileSet, "tileset", config.TileSet,
+		"tile set of the games, one of: "+strings.Join(tilesets.Names(), ", "),
+	)
+	flags.StringVar(
+		&config.Ruleset, "rules", config.Ruleset,
+		"ruleset of the games, one of: "+strings.Join(rules.Names(), ", "),
+	)
+	flags.IntVar(&config.PlayerCount, "players", config.PlayerCount, "number of players of each game")
+	flags.IntVar(&config.Games, "games", config.Games, "number of games to play")
+	flags.Int64Var(&config.Seed, "seed", config.Seed, "seed of the first game, every next game uses the next seed")
+	flags.IntVar(&config.ParallelGames, "parallel-games", config.ParallelGames, "number of games played at the same time")
+	flags.IntVar(&config.GamesPerShard, "games-per-shard", config.GamesPerShard, "number of games written to each shard")
+	flags.StringVar(&config.OutputDir, "out-dir", config.OutputDir, "directory of the shards")
+	flags.IntVar(&config.EncodingOptions.Width, "board-width", config.EncodingOptions.Width, "width of the encoded board, in tiles")
+	flags.IntVar(
+		&config.EncodingOptions.Height, "board-height", config.EncodingOptions.Height,
+		"height of the encoded board, in tiles",
+	)
+	flags.StringVar(&policy, "policy", "random", "policy playing the games, one of: random, greedy, mcts")
+	flags.IntVar(&workers, "workers", runtime.NumCPU(), "number of the engine's workers")
+	flags.IntVar(&mctsConfig.Iterations, "mcts-iterations", mctsConfig.Iterations, "iterations of the mcts policy per move")
+	flags.IntVar(&mctsConfig.Workers, "mcts-workers", 1, "goroutines used by the mcts policy in each game")
+	flags.IntVar(
+		&mctsConfig.RolloutDepth, "mcts-rollout-depth", mctsConfig.RolloutDepth,
+		"maximum number of random turns after leaving the mcts tree, 0 for no limit",
+	)
+	//nolint:errcheck// flag.ExitOnError makes Parse() exit instead of returning an error
+	flags.Parse(os.Args[1:])
+
+	switch policy {
+	case "random":
+		config.NewPolicy = func(seed int64) selfplay.Policy {
+			return selfplay.NewAgentPolicy(agents.NewRandomAgent(seed))
+		}
+	case "greedy":
+		config.NewPolicy = func(seed int64) selfplay.Policy {
+			return selfplay.NewAgentPolicy(agents.NewGreedyAgent(seed))
+		}
+	case "mcts":
+		config.NewPolicy = func(seed int64) selfplay.Policy {
+			return selfplay.NewMCTSPolicy(mctsConfig, seed)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown policy: %q\n", policy)
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	eng, err := engine.StartGameEngine(workers, "")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	summary, err := selfplay.Run(eng, config)
+	eng.Close()
+	fmt.Printf("recorded %v turns of %v games in %v shards\n", summary.Turns, summary.Games, len(summary.Shards))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
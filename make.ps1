@@ -97,7 +97,7 @@ function build() {
 
 function build-go() {
     Write-Output "Building the Go project..."
-    & go build "./pkg/..."
+    & go build "./pkg/..." "./cmd/..."
     Exit-On-Fail $LASTEXITCODE
 }
 
@@ -129,7 +129,7 @@ function test() {
 
 function test-go() {
     Write-Output "Running the Go test suite..."
-    & go test -race "-coverprofile=coverage.txt" "./pkg/..."
+    & go test -race "-coverprofile=coverage.txt" "./pkg/..." "./cmd/..."
     Exit-On-Fail $LASTEXITCODE
 }
 
@@ -0,0 +1,52 @@
+package agents
+
+import (
+	"slices"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+)
+
+// Chooses the moves for the current player of the game.
+//
+// The agents can be used as baselines or opponents for other agents.
+// They are not safe for concurrent use, each goroutine should use its own agent.
+type Agent interface {
+	// Returns the move that the current player should play in the current turn.
+	// The given game is not modified.
+	//
+	// Has to be called on a game that is not finished yet
+	// (i.e. the game has a current tile).
+	ChooseMove(game *game.Game) elements.PlacedTile
+}
+
+// Returns all moves that the current player can play with the current tile of the game
+// or an empty slice, if the game is finished.
+func LegalMoves(game *game.Game) []elements.PlacedTile {
+	moves := []elements.PlacedTile{}
+	tile, err := game.GetCurrentTile()
+	if err != nil {
+		return moves
+	}
+	for _, placement := range game.GetTilePlacementsFor(tile) {
+		moves = append(moves, game.GetLegalMovesFor(placement)...)
+	}
+	return moves
+}
+
+// Returns the index of the move in the given moves (see sameMove())
+// or -1, if the move is not one of them.
+func IndexOfMove(moves []elements.PlacedTile, move elements.PlacedTile) int {
+	return slices.IndexFunc(moves, func(other elements.PlacedTile) bool {
+		return sameMove(other, move)
+	})
+}
+
+// Returns true if both moves place the tile in the same way with the same meeple
+// (or abbot recall) and false otherwise.
+func sameMove(move elements.PlacedTile, other elements.PlacedTile) bool {
+	return move.Position == other.Position &&
+		move.RecallAbbot == other.RecallAbbot &&
+		move.RecalledAbbotPosition == other.RecalledAbbotPosition &&
+		slices.Equal(move.Features, other.Features)
+}
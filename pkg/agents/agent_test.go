@@ -0,0 +1,79 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func newSeededGame(t *testing.T, tileSet tilesets.TileSet, seed int64) *game.Game {
+	deckStack := stack.NewSeeded(tileSet.Tiles, seed)
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+	g, err := game.NewFromDeck(deck, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return g
+}
+
+// Plays the game until the end with the given agents taking turns
+// and checks that each of the chosen moves is legal.
+func playGame(t *testing.T, g *game.Game, players []Agent) elements.ScoreReport {
+	for {
+		if _, err := g.GetCurrentTile(); err != nil {
+			break
+		}
+		agent := players[g.CurrentPlayer().ID()-1]
+		serialized := g.Serialized()
+		move := agent.ChooseMove(g)
+
+		// the game should not be modified by the agent
+		if g.Serialized().CurrentPlayerID != serialized.CurrentPlayerID ||
+			len(g.GetBoard().Tiles()) != len(serialized.Tiles) {
+			t.Fatalf("expected game to stay unmodified by %#v", agent)
+		}
+
+		isLegal := false
+		for _, legalMove := range LegalMoves(g) {
+			isLegal = isLegal || sameMove(move, legalMove)
+		}
+		if !isLegal {
+			t.Fatalf("expected a legal move, got %#v instead", move)
+		}
+		if err := g.PlayTurn(move); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	scores, err := g.Finalize()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return scores
+}
+
+func TestLegalMovesReturnsNoMovesWhenGameIsFinished(t *testing.T) {
+	tileSet := tilesets.StandardTileSet()
+	tileSet.Tiles = tileSet.Tiles[:1]
+	g := newSeededGame(t, tileSet, 1)
+
+	if len(LegalMoves(g)) == 0 {
+		t.Fatal("expected legal moves before the end of the game")
+	}
+	playGame(t, g, []Agent{NewRandomAgent(1), NewRandomAgent(2)})
+
+	moves := LegalMoves(g)
+	if len(moves) != 0 {
+		t.Fatalf("expected %#v, got %#v instead", 0, len(moves))
+	}
+}
+
+func TestRandomAgentPlaysFullGame(t *testing.T) {
+	g := newSeededGame(t, tilesets.StandardTileSet(), 2)
+	playGame(t, g, []Agent{NewRandomAgent(1), NewRandomAgent(2)})
+}
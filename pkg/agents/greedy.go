@@ -0,0 +1,67 @@
+package agents
+
+import (
+	"math"
+	"math/rand" //nolint:gosec// Weak number generator is sufficent in our case
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+)
+
+// Agent that chooses the move with the best immediate outcome,
+// as measured by the change of the mid game score (see game.GetMidGameScore()).
+//
+// The outcome of a move is the lead of the current player over the best of the other players
+// after the move is played. Ties between the moves are broken at random.
+type GreedyAgent struct {
+	rng *rand.Rand
+}
+
+func NewGreedyAgent(seed int64) *GreedyAgent {
+	return &GreedyAgent{
+		rng: rand.New(rand.NewSource(seed)), //nolint:gosec// Weak number generator is sufficent in our case
+	}
+}
+
+func (agent *GreedyAgent) ChooseMove(g *game.Game) elements.PlacedTile {
+	moves := LegalMoves(g)
+	playerID := g.CurrentPlayer().ID()
+
+	// the moves are played and undone on a clone to leave the given game unmodified
+	clone := g.DeepClone()
+	clone.EnableUndo()
+	bestLead := math.MinInt
+	bestMoves := []elements.PlacedTile{}
+	for _, move := range moves {
+		if err := clone.PlayTurn(move); err != nil {
+			// legal moves can always be played
+			panic(err)
+		}
+		lead := getLead(clone.GetMidGameScore(), playerID, clone.PlayerCount())
+		if err := clone.UndoTurn(); err != nil {
+			panic(err)
+		}
+
+		if lead > bestLead {
+			bestLead = lead
+			bestMoves = bestMoves[:0]
+		}
+		if lead == bestLead {
+			bestMoves = append(bestMoves, move)
+		}
+	}
+	return bestMoves[agent.rng.Intn(len(bestMoves))]
+}
+
+// Returns the difference between the score of the given player
+// and the best score of the other players.
+func getLead(scoreReport elements.ScoreReport, playerID elements.ID, playerCount int) int {
+	bestOtherScore := 0
+	for i := range playerCount {
+		otherID := elements.ID(i + 1)
+		if otherID != playerID {
+			bestOtherScore = max(bestOtherScore, int(scoreReport.ReceivedPoints[otherID]))
+		}
+	}
+	return int(scoreReport.ReceivedPoints[playerID]) - bestOtherScore
+}
@@ -0,0 +1,40 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func TestGreedyAgentChoosesMoveWithBestScore(t *testing.T) {
+	// the city on the starting tile can be completed with the only tile in the deck
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles:        []tiles.Tile{tiletemplates.SingleCityEdgeNoRoads()},
+	}
+	g := newSeededGame(t, tileSet, 1)
+
+	move := NewGreedyAgent(1).ChooseMove(g)
+	if err := g.PlayTurn(move); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// completed city with a meeple is worth 4 points and that's more than anything else
+	expected := uint32(4)
+	actual := g.GetMidGameScore().ReceivedPoints[elements.ID(1)]
+	if actual != expected {
+		t.Fatalf("expected %#v, got %#v instead", expected, actual)
+	}
+}
+
+func TestGreedyAgentBeatsRandomAgent(t *testing.T) {
+	g := newSeededGame(t, tilesets.StandardTileSet(), 3)
+	scores := playGame(t, g, []Agent{NewGreedyAgent(1), NewRandomAgent(2)})
+
+	if scores.ReceivedPoints[1] <= scores.ReceivedPoints[2] {
+		t.Fatalf("expected greedy agent to win, got %#v instead", scores.ReceivedPoints)
+	}
+}
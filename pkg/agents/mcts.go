@@ -0,0 +1,284 @@
+package agents
+
+import (
+	"math"
+	"math/rand" //nolint:gosec// Weak number generator is sufficent in our case
+	"runtime"
+	"sync"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+)
+
+type MCTSConfig struct {
+	// Number of iterations performed for each chosen move, split evenly between the workers
+	Iterations int
+	// Number of goroutines searching in parallel, each of them builds its own tree
+	Workers int
+	// Exploration constant used in the UCB formula
+	Exploration float64
+	// Maximum number of turns played at random after leaving the tree,
+	// 0 means that the turns are played until the end of the game
+	RolloutDepth int
+}
+
+func DefaultMCTSConfig() MCTSConfig {
+	return MCTSConfig{
+		Iterations:   1000,
+		Workers:      runtime.NumCPU(),
+		Exploration:  math.Sqrt2,
+		RolloutDepth: 0,
+	}
+}
+
+// Agent that chooses the move using Monte Carlo Tree Search.
+//
+// The order of the tiles in the deck is hidden from the agent - in each iteration,
+// the tiles drawn after the current one are chosen at random from the remaining tiles
+// (using the clones created with game.DeepCloneWithSwappableTiles()).
+// Since different tiles allow different moves, the tree is shared between
+// all of the drawn tiles and only the children allowed by the drawn tile are considered
+// during selection (single-observer information set MCTS).
+//
+// The workers search independently (root parallelization) and the move
+// that was visited the most times in all of the trees is chosen.
+// The result of a playout is 1 for the winner of the game (split in case of a tie)
+// and 0 for the other players, based on the mid game score at the end of the playout.
+type MCTSAgent struct {
+	config MCTSConfig
+	rng    *rand.Rand
+}
+
+func NewMCTSAgent(config MCTSConfig, seed int64) *MCTSAgent {
+	return &MCTSAgent{
+		config: config,
+		rng:    rand.New(rand.NewSource(seed)), //nolint:gosec// Weak number generator is sufficent in our case
+	}
+}
+
+type mctsNode struct {
+	parent *mctsNode
+	move   elements.PlacedTile
+	// player that played the move leading to this node
+	playerID elements.ID
+	children []*mctsNode
+	// number of iterations that went through this node
+	visits int
+	// number of iterations in which this node could have been selected
+	availability int
+	// sum of playout results of the player that played the move
+	reward float64
+}
+
+func (agent *MCTSAgent) ChooseMove(g *game.Game) elements.PlacedTile {
+	moves, visits := agent.Search(g)
+	if visits == nil {
+		return moves[0]
+	}
+	bestMove := 0
+	for moveIndex := range moves {
+		if visits[moveIndex] > visits[bestMove] {
+			bestMove = moveIndex
+		}
+	}
+	return moves[bestMove]
+}
+
+// Searches the game tree of the game's current turn and returns the legal moves
+// (in the order of LegalMoves()) with the number of visits of each of them
+// summed over the trees of all of the workers.
+//
+// The only legal move is returned without searching, with nil visits.
+func (agent *MCTSAgent) Search(g *game.Game) ([]elements.PlacedTile, []int) {
+	moves := LegalMoves(g)
+	if len(moves) == 1 {
+		return moves, nil
+	}
+
+	workers := max(1, agent.config.Workers)
+	visits := make([][]int, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		// clones and seeds are created upfront to not share any state between the workers
+		worker := mctsWorker{
+			config: agent.config,
+			root:   g.DeepCloneWithSwappableTiles(),
+			rng:    rand.New(rand.NewSource(agent.rng.Int63())), //nolint:gosec// Weak number generator is sufficent in our case
+		}
+		iterations := agent.config.Iterations / workers
+		if i < agent.config.Iterations%workers {
+			iterations++
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			visits[i] = worker.search(moves, iterations)
+		}()
+	}
+	wg.Wait()
+
+	totalVisits := make([]int, len(moves))
+	for _, workerVisits := range visits {
+		for moveIndex, moveVisits := range workerVisits {
+			totalVisits[moveIndex] += moveVisits
+		}
+	}
+	return moves, totalVisits
+}
+
+type mctsWorker struct {
+	config MCTSConfig
+	root   *game.Game
+	rng    *rand.Rand
+}
+
+// Builds the tree with the given number of iterations and returns
+// the number of visits of each of the given root moves.
+func (worker *mctsWorker) search(moves []elements.PlacedTile, iterations int) []int {
+	root := &mctsNode{}
+	for range iterations {
+		worker.iterate(root)
+	}
+
+	visits := make([]int, len(moves))
+	for i, move := range moves {
+		for _, child := range root.children {
+			if sameMove(child.move, move) {
+				visits[i] = child.visits
+				break
+			}
+		}
+	}
+	return visits
+}
+
+func (worker *mctsWorker) iterate(root *mctsNode) {
+	state := worker.root.DeepCloneWithSwappableTiles()
+	node := root
+
+	// selection and expansion,
+	// the current tile of the root is known so it is only drawn at random below the root
+	for depth := 0; ; depth++ {
+		if depth != 0 && !drawRandomTile(state, worker.rng) {
+			break
+		}
+		moves := LegalMoves(state)
+		if len(moves) == 0 {
+			break
+		}
+
+		child, expanded := worker.selectChild(node, state.CurrentPlayer().ID(), moves)
+		if err := state.PlayTurn(child.move); err != nil {
+			// legal moves can always be played
+			panic(err)
+		}
+		node = child
+		if expanded {
+			break
+		}
+	}
+
+	// rollout
+	for turn := 0; worker.config.RolloutDepth == 0 || turn < worker.config.RolloutDepth; turn++ {
+		if !drawRandomTile(state, worker.rng) {
+			break
+		}
+		moves := LegalMoves(state)
+		if err := state.PlayTurn(moves[worker.rng.Intn(len(moves))]); err != nil {
+			panic(err)
+		}
+	}
+
+	// backpropagation
+	results := getPlayoutResults(state.GetMidGameScore(), state.PlayerCount())
+	for ; node != root; node = node.parent {
+		node.visits++
+		node.reward += results[node.playerID]
+	}
+}
+
+// Returns the child of the node to play next out of the children allowed by the given moves
+// and whether the child was just added to the tree.
+//
+// If some of the moves are not in the tree yet, one of them is added at random.
+// Otherwise, the child with the highest UCB value is chosen.
+func (worker *mctsWorker) selectChild(
+	node *mctsNode, playerID elements.ID, moves []elements.PlacedTile,
+) (*mctsNode, bool) {
+	available := []*mctsNode{}
+	untried := []elements.PlacedTile{}
+	for _, move := range moves {
+		found := false
+		for _, child := range node.children {
+			if child.playerID == playerID && sameMove(child.move, move) {
+				available = append(available, child)
+				found = true
+				break
+			}
+		}
+		if !found {
+			untried = append(untried, move)
+		}
+	}
+	for _, child := range available {
+		child.availability++
+	}
+
+	if len(untried) != 0 {
+		child := &mctsNode{parent: node, move: untried[worker.rng.Intn(len(untried))], playerID: playerID}
+		child.availability++
+		node.children = append(node.children, child)
+		return child, true
+	}
+
+	var bestChild *mctsNode
+	bestValue := math.Inf(-1)
+	for _, child := range available {
+		value := child.reward/float64(child.visits) +
+			worker.config.Exploration*math.Sqrt(math.Log(float64(child.availability))/float64(child.visits))
+		if value > bestValue {
+			bestChild = child
+			bestValue = value
+		}
+	}
+	return bestChild, false
+}
+
+// Replaces the current tile of the game with a tile drawn at random from the remaining tiles
+// that can be placed on the board. Returns false, if there's no such tile.
+func drawRandomTile(state *game.Game, rng *rand.Rand) bool {
+	remaining := state.GetRemainingTiles()
+	rng.Shuffle(len(remaining), func(i, j int) {
+		remaining[i], remaining[j] = remaining[j], remaining[i]
+	})
+	for _, tile := range remaining {
+		if state.GetBoard().TileHasValidPlacement(tile) {
+			if err := state.SwapCurrentTile(tile); err != nil {
+				panic(err)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the result of the playout for each of the players (indexed by player ID),
+// the players with the highest score share the win.
+func getPlayoutResults(scoreReport elements.ScoreReport, playerCount int) []float64 {
+	var bestScore uint32
+	for i := range playerCount {
+		bestScore = max(bestScore, scoreReport.ReceivedPoints[elements.ID(i+1)])
+	}
+	winners := []elements.ID{}
+	for i := range playerCount {
+		if scoreReport.ReceivedPoints[elements.ID(i+1)] == bestScore {
+			winners = append(winners, elements.ID(i+1))
+		}
+	}
+
+	results := make([]float64, playerCount+1)
+	for _, playerID := range winners {
+		results[playerID] = 1 / float64(len(winners))
+	}
+	return results
+}
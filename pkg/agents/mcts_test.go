@@ -0,0 +1,66 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func TestMCTSAgentChoosesWinningMove(t *testing.T) {
+	// with a single tile in the deck, player 1 only wins if they place a meeple
+	// (otherwise nobody scores any points)
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles:        []tiles.Tile{tiletemplates.SingleCityEdgeNoRoads()},
+	}
+	g := newSeededGame(t, tileSet, 1)
+	config := DefaultMCTSConfig()
+	config.Iterations = 500
+	config.Workers = 2
+
+	move := NewMCTSAgent(config, 1).ChooseMove(g)
+	if err := g.PlayTurn(move); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	scores, err := g.Finalize()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if scores.ReceivedPoints[1] <= scores.ReceivedPoints[2] {
+		t.Fatalf("expected player 1 to win, got %#v instead", scores.ReceivedPoints)
+	}
+}
+
+func TestMCTSAgentPlaysFullGame(t *testing.T) {
+	tileSet := tilesets.StandardTileSet()
+	tileSet.Tiles = tileSet.Tiles[:15]
+	g := newSeededGame(t, tileSet, 4)
+	config := DefaultMCTSConfig()
+	config.Iterations = 20
+	config.Workers = 2
+	config.RolloutDepth = 5
+
+	playGame(t, g, []Agent{NewMCTSAgent(config, 1), NewRandomAgent(2)})
+}
+
+func TestMCTSAgentSearchVisitsMovesWithAllIterations(t *testing.T) {
+	g := newSeededGame(t, tilesets.StandardTileSet(), 1)
+	config := DefaultMCTSConfig()
+	config.Iterations = 50
+	config.Workers = 3
+
+	moves, visits := NewMCTSAgent(config, 1).Search(g)
+	if len(visits) != len(moves) {
+		t.Fatalf("expected %#v, got %#v instead", len(moves), len(visits))
+	}
+	totalVisits := 0
+	for _, moveVisits := range visits {
+		totalVisits += moveVisits
+	}
+	if totalVisits != config.Iterations {
+		t.Fatalf("expected %#v, got %#v instead", config.Iterations, totalVisits)
+	}
+}
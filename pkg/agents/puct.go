@@ -0,0 +1,499 @@
+package agents
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand" //nolint:gosec// Weak number generator is sufficent in our case
+	"sync"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/encoding"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+)
+
+var (
+	ErrInvalidPUCTConfig = errors.New("invalid PUCT config")
+	ErrInvalidEvaluation = errors.New("invalid evaluation of the search leaves")
+	ErrLeavesNotExpanded = errors.New("leaves of the searches have not been expanded yet")
+)
+
+type PUCTConfig struct {
+	// Number of simulations of each search, each of them ends either in a leaf
+	// evaluated by the evaluator or in a finished game
+	Simulations int
+	// Exploration constant used in the PUCT formula
+	Exploration float64
+	// Options of the encoding of the leaves' states
+	EncodingOptions encoding.Options
+}
+
+func DefaultPUCTConfig() PUCTConfig {
+	return PUCTConfig{
+		Simulations:     200,
+		Exploration:     1.5,
+		EncodingOptions: encoding.DefaultOptions(),
+	}
+}
+
+// State at the leaf of one of the searches that has to be evaluated.
+type SearchLeaf struct {
+	// index of the search (the game given to NewPUCTDriver()) that the leaf belongs to
+	Search int
+	// player to move in the leaf's state
+	PlayerID elements.ID
+	// tile to place in the leaf's state
+	Tile     tiles.Tile
+	Encoding encoding.Encoding
+	// legal moves of the player, in the order of LegalMoves()
+	LegalMoves []elements.PlacedTile
+	// action of each of the legal moves (see encoding.ActionSpace)
+	// or -1, if the move places the tile outside of the encoded window
+	LegalActions []int
+}
+
+// Evaluation of the search leaf.
+type SearchEvaluation struct {
+	// prior probability of each of the leaf's legal moves, normalized by the search
+	// (the moves get the same probability, if none of them has a positive prior)
+	Priors []float32
+	// expected result of the game for each of the players (indexed by player ID - 1),
+	// 1 for the winner of the game (split in case of a tie) and 0 for the other players
+	Values []float32
+}
+
+// Evaluates the leaves of the searches in batches, e.g. with a neural network.
+type Evaluator interface {
+	// Returns the evaluation of each of the given leaves, in the same order.
+	Evaluate(leaves []SearchLeaf) ([]SearchEvaluation, error)
+}
+
+// Outcome of the search of a single game.
+type SearchResult struct {
+	// legal moves of the game's current player, in the order of LegalMoves()
+	LegalMoves []elements.PlacedTile
+	// number of visits of each of the legal moves
+	Visits []int
+	// mean value of all simulations for the game's current player
+	Value float64
+}
+
+// Runs the PUCT searches (Monte Carlo Tree Search guided by the priors and values
+// of the evaluator, as in AlphaZero) of many games at the same time and collects
+// the leaves of all of them so that they can be evaluated in a single batch.
+//
+// Like in MCTSAgent, the order of the tiles in the deck is hidden from the search -
+// after each move, the next tile is drawn at random from the remaining tiles.
+// Each move of the tree has a separate child for each of the drawn tiles.
+//
+// The searches are driven by calling NextLeaves() and evaluating the returned leaves
+// with Expand() until Done() returns true, each search has at most one leaf
+// in each batch. Run() (or PUCTSearch()) drives the searches with an Evaluator.
+// The driver is not safe for concurrent use.
+type PUCTDriver struct {
+	config   PUCTConfig
+	searches []*puctSearch
+	// searches with the leaves returned by the last call to NextLeaves()
+	pending []*puctSearch
+}
+
+type puctSearch struct {
+	root        *game.Game
+	rootNode    *puctNode
+	rng         *rand.Rand
+	simulations int
+	// the leaf waiting for its evaluation and the path leading to it
+	leaf      *puctNode
+	leafPath  []puctStep
+	leafMoves []elements.PlacedTile
+}
+
+type puctNode struct {
+	// player to move
+	playerID elements.ID
+	// nil until the node is evaluated
+	edges []*puctEdge
+	// number of simulations that went through this node
+	visits int
+	// sum of the values of the player to move
+	value float64
+}
+
+type puctEdge struct {
+	move  elements.PlacedTile
+	prior float64
+	// number of simulations that went through this move
+	visits int
+	// sum of the values of the player that played the move
+	value float64
+	// child node for each of the tiles drawn after the move
+	children []puctChild
+}
+
+type puctChild struct {
+	tile tiles.Tile
+	node *puctNode
+}
+
+type puctStep struct {
+	node *puctNode
+	edge *puctEdge
+}
+
+// Returns the driver of the searches of the current turns of the given games.
+// The games are not modified by the searches, but the caller must not modify them
+// until the searches are done.
+func NewPUCTDriver(games []*game.Game, config PUCTConfig, seed int64) (*PUCTDriver, error) {
+	if config.Simulations <= 0 {
+		return nil, fmt.Errorf("%w: simulations have to be positive, got %v", ErrInvalidPUCTConfig, config.Simulations)
+	}
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec// Weak number generator is sufficent in our case
+	driver := &PUCTDriver{config: config, searches: make([]*puctSearch, len(games))}
+	for i, g := range games {
+		if _, err := g.GetCurrentTile(); err != nil {
+			return nil, fmt.Errorf("game %v: %w", i, err)
+		}
+		if _, err := encoding.NewActionSpace(g, config.EncodingOptions); err != nil {
+			return nil, err
+		}
+		driver.searches[i] = &puctSearch{
+			root:     g,
+			rootNode: &puctNode{playerID: g.CurrentPlayer().ID()},
+			rng:      rand.New(rand.NewSource(rng.Int63())), //nolint:gosec// Weak number generator is sufficent in our case
+		}
+	}
+	return driver, nil
+}
+
+// Returns true if all of the searches have finished their simulations.
+func (driver *PUCTDriver) Done() bool {
+	if len(driver.pending) != 0 {
+		return false
+	}
+	for _, search := range driver.searches {
+		if search.simulations < driver.config.Simulations {
+			return false
+		}
+	}
+	return true
+}
+
+// Runs the simulations of the searches until each of them reaches a leaf
+// that has to be evaluated (or finishes all of its simulations)
+// and returns the leaves in the order of the searches.
+//
+// The leaves have to be evaluated with Expand() before the next call.
+func (driver *PUCTDriver) NextLeaves() ([]SearchLeaf, error) {
+	if len(driver.pending) != 0 {
+		return nil, ErrLeavesNotExpanded
+	}
+
+	leaves := make([]*SearchLeaf, len(driver.searches))
+	errs := make([]error, len(driver.searches))
+	var wg sync.WaitGroup
+	for i, search := range driver.searches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			leaves[i], errs[i] = search.nextLeaf(driver.config)
+		}()
+	}
+	wg.Wait()
+
+	batch := []SearchLeaf{}
+	for i, leaf := range leaves {
+		if errs[i] != nil {
+			driver.pending = nil
+			return nil, fmt.Errorf("game %v: %w", i, errs[i])
+		}
+		if leaf != nil {
+			leaf.Search = i
+			batch = append(batch, *leaf)
+			driver.pending = append(driver.pending, driver.searches[i])
+		}
+	}
+	return batch, nil
+}
+
+// Expands the leaves returned by the last call to NextLeaves() with their evaluations
+// and backpropagates the values. The leaves are left unexpanded, if any of the evaluations
+// is invalid.
+func (driver *PUCTDriver) Expand(evaluations []SearchEvaluation) error {
+	if len(evaluations) != len(driver.pending) {
+		return fmt.Errorf(
+			"%w: expected %v evaluations, got %v", ErrInvalidEvaluation, len(driver.pending), len(evaluations),
+		)
+	}
+	for i, search := range driver.pending {
+		evaluation := evaluations[i]
+		if len(evaluation.Priors) != len(search.leafMoves) {
+			return fmt.Errorf(
+				"%w: expected %v priors for leaf %v, got %v",
+				ErrInvalidEvaluation, len(search.leafMoves), i, len(evaluation.Priors),
+			)
+		}
+		if len(evaluation.Values) != search.root.PlayerCount() {
+			return fmt.Errorf(
+				"%w: expected %v values for leaf %v, got %v",
+				ErrInvalidEvaluation, search.root.PlayerCount(), i, len(evaluation.Values),
+			)
+		}
+		if !allFinite(evaluation.Priors) || !allFinite(evaluation.Values) {
+			return fmt.Errorf("%w: leaf %v has NaN or infinite priors or values", ErrInvalidEvaluation, i)
+		}
+	}
+
+	for i, search := range driver.pending {
+		search.expand(evaluations[i])
+	}
+	driver.pending = nil
+	return nil
+}
+
+// Returns the results of the searches, in the order of the games.
+func (driver *PUCTDriver) Results() []SearchResult {
+	results := make([]SearchResult, len(driver.searches))
+	for i, search := range driver.searches {
+		node := search.rootNode
+		result := SearchResult{
+			LegalMoves: make([]elements.PlacedTile, len(node.edges)),
+			Visits:     make([]int, len(node.edges)),
+		}
+		for moveIndex, edge := range node.edges {
+			result.LegalMoves[moveIndex] = edge.move
+			result.Visits[moveIndex] = edge.visits
+		}
+		if node.visits != 0 {
+			result.Value = node.value / float64(node.visits)
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// Searches the current turns of the given games, evaluating the leaves of all of them
+// in batches with the evaluator, see PUCTDriver.
+func PUCTSearch(games []*game.Game, evaluator Evaluator, config PUCTConfig, seed int64) ([]SearchResult, error) {
+	driver, err := NewPUCTDriver(games, config, seed)
+	if err != nil {
+		return nil, err
+	}
+	return driver.Run(evaluator)
+}
+
+// Drives the searches until they are done, evaluating the leaves with the evaluator,
+// and returns their results.
+func (driver *PUCTDriver) Run(evaluator Evaluator) ([]SearchResult, error) {
+	for !driver.Done() {
+		leaves, err := driver.NextLeaves()
+		if err != nil {
+			return nil, err
+		}
+		if len(leaves) == 0 {
+			continue
+		}
+		evaluations, err := evaluator.Evaluate(leaves)
+		if err != nil {
+			return nil, err
+		}
+		if err := driver.Expand(evaluations); err != nil {
+			return nil, err
+		}
+	}
+	return driver.Results(), nil
+}
+
+// Runs the simulations until one of them reaches a leaf that has to be evaluated
+// and returns that leaf or nil, if all simulations have finished.
+func (search *puctSearch) nextLeaf(config PUCTConfig) (*SearchLeaf, error) {
+	for search.simulations < config.Simulations {
+		state := search.root.DeepCloneWithSwappableTiles()
+		node := search.rootNode
+		path := []puctStep{}
+		for {
+			if node.edges == nil {
+				return search.newLeaf(state, node, path, config.EncodingOptions)
+			}
+
+			edge := node.selectEdge(config.Exploration)
+			path = append(path, puctStep{node: node, edge: edge})
+			if err := state.PlayTurn(edge.move); err != nil {
+				// legal moves can always be played
+				panic(err)
+			}
+			if !drawRandomTile(state, search.rng) {
+				results := getPlayoutResults(state.GetMidGameScore(), state.PlayerCount())
+				search.backpropagate(path, nil, results)
+				break
+			}
+			tile, err := state.GetCurrentTile()
+			if err != nil {
+				panic(err)
+			}
+			node = edge.child(tile, state.CurrentPlayer().ID())
+		}
+	}
+	return nil, nil
+}
+
+func (search *puctSearch) newLeaf(
+	state *game.Game, node *puctNode, path []puctStep, options encoding.Options,
+) (*SearchLeaf, error) {
+	tile, err := state.GetCurrentTile()
+	if err != nil {
+		return nil, err
+	}
+	stateEncoding, err := encoding.Encode(state, tile, options)
+	if err != nil {
+		return nil, err
+	}
+	space, err := encoding.NewActionSpace(state, options)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := LegalMoves(state)
+	legalActions := make([]int, len(moves))
+	for i, move := range moves {
+		legalActions[i], err = space.EncodeMove(tile, move)
+		if errors.Is(err, encoding.ErrMoveNotEncodable) {
+			legalActions[i] = -1
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	search.leaf = node
+	search.leafPath = path
+	search.leafMoves = moves
+	return &SearchLeaf{
+		PlayerID:     node.playerID,
+		Tile:         tile,
+		Encoding:     stateEncoding,
+		LegalMoves:   moves,
+		LegalActions: legalActions,
+	}, nil
+}
+
+func (search *puctSearch) expand(evaluation SearchEvaluation) {
+	var priorSum float64
+	for _, prior := range evaluation.Priors {
+		if prior > 0 {
+			priorSum += float64(prior)
+		}
+	}
+	node := search.leaf
+	node.edges = make([]*puctEdge, len(search.leafMoves))
+	for i, move := range search.leafMoves {
+		edge := &puctEdge{move: move}
+		switch {
+		case priorSum == 0:
+			edge.prior = 1 / float64(len(search.leafMoves))
+		case evaluation.Priors[i] > 0:
+			edge.prior = float64(evaluation.Priors[i]) / priorSum
+		}
+		node.edges[i] = edge
+	}
+
+	// values indexed by player ID, like the playout results
+	values := make([]float64, len(evaluation.Values)+1)
+	for i, value := range evaluation.Values {
+		values[i+1] = float64(value)
+	}
+	search.backpropagate(search.leafPath, node, values)
+	search.leaf = nil
+	search.leafPath = nil
+	search.leafMoves = nil
+}
+
+// Adds the values (indexed by player ID) to the nodes and moves of the path and to the leaf.
+func (search *puctSearch) backpropagate(path []puctStep, leaf *puctNode, values []float64) {
+	for _, step := range path {
+		step.node.visits++
+		step.node.value += values[step.node.playerID]
+		step.edge.visits++
+		step.edge.value += values[step.node.playerID]
+	}
+	if leaf != nil {
+		leaf.visits++
+		leaf.value += values[leaf.playerID]
+	}
+	search.simulations++
+}
+
+// Returns the move with the highest PUCT value, the value of the unvisited moves
+// is the mean value of the node.
+func (node *puctNode) selectEdge(exploration float64) *puctEdge {
+	nodeValue := node.value / float64(node.visits)
+	sqrtVisits := math.Sqrt(float64(node.visits))
+
+	bestEdge := node.edges[0]
+	bestValue := math.Inf(-1)
+	for _, edge := range node.edges {
+		value := nodeValue
+		if edge.visits != 0 {
+			value = edge.value / float64(edge.visits)
+		}
+		value += exploration * edge.prior * sqrtVisits / float64(1+edge.visits)
+		if value > bestValue {
+			bestEdge = edge
+			bestValue = value
+		}
+	}
+	return bestEdge
+}
+
+func allFinite(values []float32) bool {
+	for _, value := range values {
+		if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the child node of the move for the drawn tile, adding it if necessary.
+func (edge *puctEdge) child(tile tiles.Tile, playerID elements.ID) *puctNode {
+	for _, child := range edge.children {
+		if child.tile.Equals(tile) {
+			return child.node
+		}
+	}
+	node := &puctNode{playerID: playerID}
+	edge.children = append(edge.children, puctChild{tile: tile, node: node})
+	return node
+}
+
+// Agent that chooses the most visited move of the PUCT search with the given evaluator,
+// each of its searches evaluates the leaves of a single game.
+// Use PUCTDriver to batch the leaves of many games.
+type PUCTAgent struct {
+	config    PUCTConfig
+	evaluator Evaluator
+	rng       *rand.Rand
+}
+
+func NewPUCTAgent(config PUCTConfig, evaluator Evaluator, seed int64) *PUCTAgent {
+	return &PUCTAgent{
+		config:    config,
+		evaluator: evaluator,
+		rng:       rand.New(rand.NewSource(seed)), //nolint:gosec// Weak number generator is sufficent in our case
+	}
+}
+
+// Panics if the search fails, e.g. when the evaluator returns an error.
+func (agent *PUCTAgent) ChooseMove(g *game.Game) elements.PlacedTile {
+	results, err := PUCTSearch([]*game.Game{g}, agent.evaluator, agent.config, agent.rng.Int63())
+	if err != nil {
+		panic(err)
+	}
+	result := results[0]
+	bestMove := 0
+	for moveIndex, visits := range result.Visits {
+		if visits > result.Visits[bestMove] {
+			bestMove = moveIndex
+		}
+	}
+	return result.LegalMoves[bestMove]
+}
@@ -0,0 +1,126 @@
+package agents
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/encoding"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+// Evaluator giving the same prior to all moves and the same value to all players.
+type uniformEvaluator struct {
+	batchSizes []int
+}
+
+func (evaluator *uniformEvaluator) Evaluate(leaves []SearchLeaf) ([]SearchEvaluation, error) {
+	evaluator.batchSizes = append(evaluator.batchSizes, len(leaves))
+	evaluations := make([]SearchEvaluation, len(leaves))
+	for i, leaf := range leaves {
+		evaluations[i] = SearchEvaluation{
+			Priors: make([]float32, len(leaf.LegalMoves)),
+			Values: []float32{0.5, 0.5},
+		}
+	}
+	return evaluations, nil
+}
+
+func TestPUCTSearchBatchesLeavesOfAllGames(t *testing.T) {
+	games := []*game.Game{}
+	for seed := range int64(3) {
+		games = append(games, newSeededGame(t, tilesets.StandardTileSet(), seed))
+	}
+	config := DefaultPUCTConfig()
+	config.Simulations = 20
+	config.EncodingOptions = encoding.Options{Width: 7, Height: 7}
+	evaluator := &uniformEvaluator{}
+
+	results, err := PUCTSearch(games, evaluator, config, 1)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if evaluator.batchSizes[0] != len(games) {
+		t.Fatalf("expected %#v, got %#v instead", len(games), evaluator.batchSizes[0])
+	}
+	for _, batchSize := range evaluator.batchSizes {
+		if batchSize > len(games) {
+			t.Fatalf("expected at most one leaf of each game, got %#v", evaluator.batchSizes)
+		}
+	}
+	for i, result := range results {
+		if len(result.LegalMoves) != len(LegalMoves(games[i])) {
+			t.Fatalf("expected %#v, got %#v instead", len(LegalMoves(games[i])), len(result.LegalMoves))
+		}
+		totalVisits := 0
+		for _, visits := range result.Visits {
+			totalVisits += visits
+		}
+		// the first simulation only evaluates the root
+		if totalVisits != config.Simulations-1 {
+			t.Fatalf("expected %#v, got %#v instead", config.Simulations-1, totalVisits)
+		}
+	}
+}
+
+func TestPUCTAgentChoosesWinningMove(t *testing.T) {
+	// with a single tile in the deck, player 1 only wins if they place a meeple
+	// (otherwise nobody scores any points)
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles:        []tiles.Tile{tiletemplates.SingleCityEdgeNoRoads()},
+	}
+	g := newSeededGame(t, tileSet, 1)
+	config := DefaultPUCTConfig()
+	config.Simulations = 200
+
+	move := NewPUCTAgent(config, &uniformEvaluator{}, 1).ChooseMove(g)
+	if err := g.PlayTurn(move); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	scores, err := g.Finalize()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if scores.ReceivedPoints[1] <= scores.ReceivedPoints[2] {
+		t.Fatalf("expected player 1 to win, got %#v instead", scores.ReceivedPoints)
+	}
+}
+
+func TestPUCTDriverReturnsErrorForInvalidEvaluations(t *testing.T) {
+	games := []*game.Game{newSeededGame(t, tilesets.StandardTileSet(), 1)}
+	driver, err := NewPUCTDriver(games, DefaultPUCTConfig(), 1)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	leaves, err := driver.NextLeaves()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := driver.NextLeaves(); !errors.Is(err, ErrLeavesNotExpanded) {
+		t.Fatalf("expected %#v, got %#v instead", ErrLeavesNotExpanded, err)
+	}
+
+	evaluation := SearchEvaluation{Priors: []float32{1}, Values: []float32{0.5, 0.5}}
+	if err := driver.Expand([]SearchEvaluation{evaluation}); !errors.Is(err, ErrInvalidEvaluation) {
+		t.Fatalf("expected %#v, got %#v instead", ErrInvalidEvaluation, err)
+	}
+	evaluation.Priors = make([]float32, len(leaves[0].LegalMoves))
+	evaluation.Priors[0] = float32(math.Inf(1))
+	if err := driver.Expand([]SearchEvaluation{evaluation}); !errors.Is(err, ErrInvalidEvaluation) {
+		t.Fatalf("expected %#v, got %#v instead", ErrInvalidEvaluation, err)
+	}
+	evaluation.Priors[0] = 0
+	evaluation.Values[0] = float32(math.NaN())
+	if err := driver.Expand([]SearchEvaluation{evaluation}); !errors.Is(err, ErrInvalidEvaluation) {
+		t.Fatalf("expected %#v, got %#v instead", ErrInvalidEvaluation, err)
+	}
+	evaluation.Values[0] = 0.5
+	if err := driver.Expand([]SearchEvaluation{evaluation}); err != nil {
+		t.Fatal(err.Error())
+	}
+}
@@ -0,0 +1,24 @@
+package agents
+
+import (
+	"math/rand" //nolint:gosec// Weak number generator is sufficent in our case
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+)
+
+// Agent that chooses uniformly at random from all legal moves.
+type RandomAgent struct {
+	rng *rand.Rand
+}
+
+func NewRandomAgent(seed int64) *RandomAgent {
+	return &RandomAgent{
+		rng: rand.New(rand.NewSource(seed)), //nolint:gosec// Weak number generator is sufficent in our case
+	}
+}
+
+func (agent *RandomAgent) ChooseMove(game *game.Game) elements.PlacedTile {
+	moves := LegalMoves(game)
+	return moves[agent.rng.Intn(len(moves))]
+}
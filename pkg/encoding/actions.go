@@ -0,0 +1,181 @@
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+)
+
+var (
+	ErrMoveNotEncodable = errors.New("move cannot be encoded as an action")
+	ErrInvalidAction    = errors.New("invalid action")
+)
+
+// maximum number of the features of a single tile in all of the tile sets
+const MaxTileFeatures = 8
+
+// number of the rotations of the tile, the rotations that are the same
+// as one of the previous ones are never legal (see tiles.Tile.GetTileRotations())
+const RotationCount = 4
+
+// Meeple slots of the action - the meeple placed with the tile.
+const (
+	// tile placed without a meeple
+	NoMeepleSlot = iota
+	// tile placed with the abbot recalled from the board
+	RecallAbbotSlot
+	// first of the slots with a meeple placed on the tile's feature,
+	// there is one slot for each meeple type (except for NoneMeeple) on each feature,
+	// ordered by the feature's index and then by the meeple type
+	FeatureMeepleSlotsStart
+)
+
+// number of the meeple slots of each position and rotation
+const MeepleSlotCount = FeatureMeepleSlotsStart + MaxTileFeatures*(elements.MeepleTypeCount-1)
+
+// Maps the moves to the integer actions and back.
+//
+// The action is made of the move's position in the window of the board
+// (the same window that Encode() renders the planes of), the rotation
+// of the tile to place and the meeple slot:
+//
+//	((row * Width + column) * RotationCount + rotation) * MeepleSlotCount + slot
+//
+// The rotation is the index of the tile's rotation returned by
+// tiles.Tile.GetTileRotations() and the features are indexed in the order of that tile,
+// so the symmetric rotations of the same move always get the same action.
+type ActionSpace struct {
+	// position of the tile in the top-left corner of the window
+	Origin position.Position
+	// size of the window of the board, in tiles
+	Width  int
+	Height int
+}
+
+// Returns the action space of the game's board window with the given options.
+func NewActionSpace(g *game.Game, options Options) (ActionSpace, error) {
+	if err := validateOptions(options); err != nil {
+		return ActionSpace{}, err
+	}
+	return ActionSpace{
+		Origin: windowOrigin(placedTiles(g.GetBoard()), options),
+		Width:  options.Width,
+		Height: options.Height,
+	}, nil
+}
+
+// Returns the number of the actions.
+func (space ActionSpace) Size() int {
+	return space.Width * space.Height * RotationCount * MeepleSlotCount
+}
+
+// Returns the action of the move placing the given tile.
+func (space ActionSpace) EncodeMove(tile tiles.Tile, move elements.PlacedTile) (int, error) {
+	column := int(move.Position.X()) - int(space.Origin.X())
+	// the Y axis points up while the rows go down
+	row := int(space.Origin.Y()) - int(move.Position.Y())
+	if row < 0 || row >= space.Height || column < 0 || column >= space.Width {
+		return 0, fmt.Errorf("%w: position %v is outside of the window", ErrMoveNotEncodable, move.Position)
+	}
+
+	rotations := tile.GetTileRotations()
+	rotation := slices.IndexFunc(rotations, func(rotated tiles.Tile) bool {
+		return hasSameFeatures(rotated, move)
+	})
+	if rotation == -1 {
+		return 0, fmt.Errorf("%w: move does not place the given tile", ErrMoveNotEncodable)
+	}
+
+	slot := NoMeepleSlot
+	if move.RecallAbbot {
+		slot = RecallAbbotSlot
+	}
+	for _, feat := range move.Features {
+		if feat.Meeple.Type == elements.NoneMeeple {
+			continue
+		}
+		if move.RecallAbbot {
+			return 0, fmt.Errorf("%w: move places a meeple and recalls the abbot", ErrMoveNotEncodable)
+		}
+		featureIndex := slices.Index(rotations[rotation].Features, feat.Feature)
+		slot = FeatureMeepleSlotsStart + featureIndex*(elements.MeepleTypeCount-1) + int(feat.Meeple.Type) - 1
+	}
+
+	return ((row*space.Width+column)*RotationCount+rotation)*MeepleSlotCount + slot, nil
+}
+
+// Returns the move of the action placing the given tile by the game's current player.
+// The returned move does not have to be legal, see LegalActionMask().
+func (space ActionSpace) DecodeMove(g *game.Game, tile tiles.Tile, action int) (elements.PlacedTile, error) {
+	if action < 0 || action >= space.Size() {
+		return elements.PlacedTile{}, fmt.Errorf("%w: %v is outside of the action space", ErrInvalidAction, action)
+	}
+	slot := action % MeepleSlotCount
+	action /= MeepleSlotCount
+	rotation := action % RotationCount
+	action /= RotationCount
+	row, column := action/space.Width, action%space.Width
+
+	rotations := tile.GetTileRotations()
+	if rotation >= len(rotations) {
+		return elements.PlacedTile{}, fmt.Errorf("%w: tile has only %v distinct rotations", ErrInvalidAction, len(rotations))
+	}
+	move := elements.ToPlacedTile(rotations[rotation])
+	move.Position = position.New(
+		int16(int(space.Origin.X())+column),
+		int16(int(space.Origin.Y())-row),
+	)
+
+	player := g.CurrentPlayer()
+	switch {
+	case slot == NoMeepleSlot:
+	case slot == RecallAbbotSlot:
+		abbotPosition, ok := g.GetBoard().GetAbbotPosition(player.ID())
+		if !ok {
+			return elements.PlacedTile{}, fmt.Errorf("%w: player has no abbot to recall", ErrInvalidAction)
+		}
+		move.RecallAbbot = true
+		move.RecalledAbbotPosition = abbotPosition
+	default:
+		featureIndex := (slot - FeatureMeepleSlotsStart) / (elements.MeepleTypeCount - 1)
+		meepleType := elements.MeepleType((slot-FeatureMeepleSlotsStart)%(elements.MeepleTypeCount-1) + 1)
+		if featureIndex >= len(move.Features) {
+			return elements.PlacedTile{}, fmt.Errorf("%w: tile has only %v features", ErrInvalidAction, len(move.Features))
+		}
+		move.Features[featureIndex].Meeple = elements.Meeple{Type: meepleType, PlayerID: player.ID()}
+	}
+	return move, nil
+}
+
+// Returns the mask of the legal actions of the game's current player placing the given tile,
+// with 1 for each of the legal actions and 0 for all of the others.
+// The legal moves placing the tile outside of the window are not included.
+func (space ActionSpace) LegalActionMask(g *game.Game, tile tiles.Tile) []uint8 {
+	mask := make([]uint8, space.Size())
+	for _, placement := range g.GetTilePlacementsFor(tile) {
+		for _, move := range g.GetLegalMovesFor(placement) {
+			if action, err := space.EncodeMove(tile, move); err == nil {
+				mask[action] = 1
+			}
+		}
+	}
+	return mask
+}
+
+// Checks whether the move places the tile with the same features, regardless of their order.
+func hasSameFeatures(tile tiles.Tile, move elements.PlacedTile) bool {
+	if len(tile.Features) != len(move.Features) {
+		return false
+	}
+	for _, feat := range move.Features {
+		if !slices.Contains(tile.Features, feat.Feature) {
+			return false
+		}
+	}
+	return true
+}
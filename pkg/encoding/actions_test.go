@@ -0,0 +1,152 @@
+package encoding
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func TestActionSpaceEncodesAndDecodesLegalMovesDuringGame(t *testing.T) {
+	tileSets := []tilesets.TileSet{
+		tilesets.StandardTileSet(),
+		tilesets.InnsAndCathedralsTileSet(),
+		tilesets.TradersAndBuildersTileSet(),
+		tilesets.GardensTileSet(),
+	}
+	for i, tileSet := range tileSets {
+		deckStack := stack.NewSeeded(tileSet.Tiles, int64(i))
+		deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+		g, err := game.NewFromDeck(deck, nil, 2, rules.Standard())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		rng := rand.New(rand.NewSource(int64(i))) //nolint:gosec// Weak number generator is sufficent in our case
+		for {
+			tile, err := g.GetCurrentTile()
+			if err != nil {
+				break
+			}
+			// the window is large enough to contain the whole board
+			space, err := NewActionSpace(g, Options{Width: 2*len(tileSet.Tiles) + 3, Height: 2*len(tileSet.Tiles) + 3})
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			moves := []elements.PlacedTile{}
+			actions := map[int]struct{}{}
+			for _, placement := range g.GetTilePlacementsFor(tile) {
+				for _, move := range g.GetLegalMovesFor(placement) {
+					action, err := space.EncodeMove(tile, move)
+					if err != nil {
+						t.Fatal(err.Error())
+					}
+					if _, ok := actions[action]; ok {
+						t.Fatalf("expected unique actions, got %#v more than once", action)
+					}
+					actions[action] = struct{}{}
+
+					decoded, err := space.DecodeMove(g, tile, action)
+					if err != nil {
+						t.Fatal(err.Error())
+					}
+					if !reflect.DeepEqual(decoded, move) {
+						t.Fatalf("expected %#v, got %#v instead", move, decoded)
+					}
+					moves = append(moves, move)
+				}
+			}
+
+			mask := space.LegalActionMask(g, tile)
+			legalCount := 0
+			for action, value := range mask {
+				if value == 0 {
+					continue
+				}
+				legalCount++
+				if _, ok := actions[action]; !ok {
+					t.Fatalf("expected action %#v to be illegal", action)
+				}
+			}
+			if legalCount != len(actions) {
+				t.Fatalf("expected %#v, got %#v instead", len(actions), legalCount)
+			}
+
+			if err = g.PlayTurn(moves[rng.Intn(len(moves))]); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	}
+}
+
+func TestActionSpaceEncodesSymmetricRotationsAsSameAction(t *testing.T) {
+	g, err := game.NewFromTileSet(tilesets.StandardTileSet(), nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	space, err := NewActionSpace(g, DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// straight road has only 2 distinct rotations
+	tile := tiletemplates.StraightRoads()
+	move := elements.ToPlacedTile(tile.Rotate(1))
+	move.Position = position.New(0, -1)
+	move.Features[0].Meeple = elements.Meeple{Type: elements.NormalMeeple, PlayerID: 1}
+	symmetricMove := elements.ToPlacedTile(tile.Rotate(3))
+	symmetricMove.Position = position.New(0, -1)
+	for i, feat := range symmetricMove.Features {
+		if feat.Feature == move.Features[0].Feature {
+			symmetricMove.Features[i].Meeple = elements.Meeple{Type: elements.NormalMeeple, PlayerID: 1}
+		}
+	}
+
+	action, err := space.EncodeMove(tile, move)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	symmetricAction, err := space.EncodeMove(tile, symmetricMove)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if action != symmetricAction {
+		t.Fatalf("expected %#v, got %#v instead", action, symmetricAction)
+	}
+}
+
+func TestActionSpaceReturnsErrorForMovesNotInActionSpace(t *testing.T) {
+	g, err := game.NewFromTileSet(tilesets.StandardTileSet(), nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	space, err := NewActionSpace(g, Options{Width: 3, Height: 3})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tile := tiletemplates.StraightRoads()
+	move := elements.ToPlacedTile(tile)
+	move.Position = position.New(2, 0)
+	if _, err := space.EncodeMove(tile, move); !errors.Is(err, ErrMoveNotEncodable) {
+		t.Fatalf("expected %#v, got %#v instead", ErrMoveNotEncodable, err)
+	}
+	// the move does not place the given tile
+	move.Position = position.New(0, -1)
+	if _, err := space.EncodeMove(tiletemplates.MonasteryWithoutRoads(), move); !errors.Is(err, ErrMoveNotEncodable) {
+		t.Fatalf("expected %#v, got %#v instead", ErrMoveNotEncodable, err)
+	}
+	if _, err := space.DecodeMove(g, tile, space.Size()); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("expected %#v, got %#v instead", ErrInvalidAction, err)
+	}
+}
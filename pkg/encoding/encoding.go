@@ -0,0 +1,297 @@
+// Package encoding renders the game state into fixed-size planes
+// that can be fed to the neural networks of the agents.
+//
+// Each tile of the board is rendered as a CellSize x CellSize block of cells:
+//
+//	top-left corner     top side     top-right corner
+//	left side           center       right side
+//	bottom-left corner  bottom side  bottom-right corner
+//
+// The planes cover a window of the board of the size given in the Options,
+// which is centered on the bounds of the placed tiles (including the positions
+// next to them) - the tiles outside of the window are cropped
+// and the empty positions are padded with zeros.
+package encoding
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+var ErrInvalidOptions = errors.New("invalid encoding options")
+
+// number of the cells in each dimension of a single tile
+const CellSize = 3
+
+// Default size of the window of the board, in tiles.
+const DefaultBoardSize = 21
+
+// Indices of the board planes. The values of all of the planes are either 0 or 1.
+const (
+	// cells covered by the features of each type
+	RoadPlane = iota
+	CityPlane
+	FieldPlane
+	MonasteryPlane
+	RiverPlane
+	GardenPlane
+	// side cells of the placed tiles that have no neighbouring tile on that side
+	OpenEdgePlane
+	// all cells of the positions where the tile to place can be placed
+	PlaceablePlane
+	// first of the planes with the cells covered by the features with meeples,
+	// there is one plane for each player, ordered by player ID
+	MeeplePlanesStart
+)
+
+var featurePlanes = map[feature.Type]int{
+	feature.Road:      RoadPlane,
+	feature.City:      CityPlane,
+	feature.Field:     FieldPlane,
+	feature.Monastery: MonasteryPlane,
+	feature.River:     RiverPlane,
+	feature.Garden:    GardenPlane,
+}
+
+// cell (row, column) of each of the primary sides
+var sideCells = map[side.Side][2]int{
+	side.Top:    {0, 1},
+	side.Right:  {1, 2},
+	side.Bottom: {2, 1},
+	side.Left:   {1, 0},
+}
+
+// cell (row, column) of each of the corners, keyed by the edges meeting at the corner
+var cornerCells = map[side.Side][2]int{
+	side.LeftTopEdge | side.TopLeftEdge:         {0, 0},
+	side.TopRightEdge | side.RightTopEdge:       {0, 2},
+	side.RightBottomEdge | side.BottomRightEdge: {2, 2},
+	side.BottomLeftEdge | side.LeftBottomEdge:   {2, 0},
+}
+
+type Options struct {
+	// size of the window of the board, in tiles
+	Width  int
+	Height int
+}
+
+func DefaultOptions() Options {
+	return Options{Width: DefaultBoardSize, Height: DefaultBoardSize}
+}
+
+// Game state rendered into planes.
+type Encoding struct {
+	// shape of Planes: plane count, height and width (in cells)
+	Shape []int
+	// the board planes as a flat buffer in row-major order
+	Planes []uint8
+	// position of the tile in the top-left corner of the planes
+	Origin position.Position
+	// number of the remaining tiles of each of the distinct tiles of the tile set,
+	// ordered as returned by DistinctTiles()
+	TileHistogram []uint8
+}
+
+// Returns the planes converted to float32 values.
+func (encoding Encoding) Float32Planes() []float32 {
+	planes := make([]float32, len(encoding.Planes))
+	for i, value := range encoding.Planes {
+		planes[i] = float32(value)
+	}
+	return planes
+}
+
+// Returns the distinct tiles of the tile set (regardless of the orientation)
+// in the order of their first occurrence.
+func DistinctTiles(tileSet tilesets.TileSet) []tiles.Tile {
+	distinct := []tiles.Tile{}
+	for _, tile := range tileSet.Tiles {
+		if indexOfTile(distinct, tile) == -1 {
+			distinct = append(distinct, tile)
+		}
+	}
+	return distinct
+}
+
+func indexOfTile(tileList []tiles.Tile, tile tiles.Tile) int {
+	for i, other := range tileList {
+		if other.Equals(tile) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Renders the state of the game into planes. The placeable positions are found
+// for the given tile, an empty tile (e.g. when the tile to place is not known)
+// leaves PlaceablePlane empty.
+func Encode(g *game.Game, tileToPlace tiles.Tile, options Options) (Encoding, error) {
+	if err := validateOptions(options); err != nil {
+		return Encoding{}, err
+	}
+
+	board := g.GetBoard()
+	placedTiles := placedTiles(board)
+
+	planeCount := MeeplePlanesStart + g.PlayerCount()
+	encoder := encoder{
+		width:  options.Width * CellSize,
+		height: options.Height * CellSize,
+		origin: windowOrigin(placedTiles, options),
+	}
+	encoder.planes = make([]uint8, planeCount*encoder.height*encoder.width)
+
+	for _, tile := range placedTiles {
+		for _, feat := range tile.Features {
+			plane, ok := featurePlanes[feat.FeatureType]
+			if !ok {
+				continue
+			}
+			encoder.setFeature(plane, tile.Position, feat.Feature)
+			if feat.Meeple.Type != elements.NoneMeeple {
+				encoder.setFeature(MeeplePlanesStart+int(feat.Meeple.PlayerID)-1, tile.Position, feat.Feature)
+			}
+		}
+		for _, primarySide := range side.PrimarySides {
+			if _, ok := board.GetTileAt(tile.Position.Add(position.FromSide(primarySide))); !ok {
+				cell := sideCells[primarySide]
+				encoder.set(OpenEdgePlane, tile.Position, cell[0], cell[1])
+			}
+		}
+	}
+
+	if len(tileToPlace.Features) != 0 {
+		for _, placement := range g.GetTilePlacementsFor(tileToPlace) {
+			for row := range CellSize {
+				for column := range CellSize {
+					encoder.set(PlaceablePlane, placement.Position, row, column)
+				}
+			}
+		}
+	}
+
+	distinctTiles := DistinctTiles(g.TileSet())
+	histogram := make([]uint8, len(distinctTiles))
+	for _, tile := range g.GetRemainingTiles() {
+		if i := indexOfTile(distinctTiles, tile); i != -1 {
+			histogram[i]++
+		}
+	}
+
+	return Encoding{
+		Shape:         []int{planeCount, encoder.height, encoder.width},
+		Planes:        encoder.planes,
+		Origin:        encoder.origin,
+		TileHistogram: histogram,
+	}, nil
+}
+
+func validateOptions(options Options) error {
+	if options.Width <= 0 || options.Height <= 0 {
+		return fmt.Errorf(
+			"%w: board size has to be positive, got %vx%v", ErrInvalidOptions, options.Width, options.Height,
+		)
+	}
+	return nil
+}
+
+func placedTiles(board elements.Board) []elements.PlacedTile {
+	placedTiles := []elements.PlacedTile{}
+	for _, tile := range board.Tiles() {
+		// unplaced tiles are zero values
+		if tile.Features != nil {
+			placedTiles = append(placedTiles, tile)
+		}
+	}
+	return placedTiles
+}
+
+// Returns the position of the top-left tile of the window centered on the bounds
+// of the placed tiles and the positions next to them.
+func windowOrigin(placedTiles []elements.PlacedTile, options Options) position.Position {
+	if len(placedTiles) == 0 {
+		return position.New(int16(-(options.Width-1)/2), int16((options.Height-1)/2))
+	}
+	minX, maxX := int(placedTiles[0].Position.X()), int(placedTiles[0].Position.X())
+	minY, maxY := int(placedTiles[0].Position.Y()), int(placedTiles[0].Position.Y())
+	for _, tile := range placedTiles[1:] {
+		minX = min(minX, int(tile.Position.X()))
+		maxX = max(maxX, int(tile.Position.X()))
+		minY = min(minY, int(tile.Position.Y()))
+		maxY = max(maxY, int(tile.Position.Y()))
+	}
+	// extending the bounds by the positions next to the tiles does not move their center
+	centerX := floorDiv(minX+maxX, 2)
+	centerY := floorDiv(minY+maxY, 2)
+	return position.New(int16(centerX-(options.Width-1)/2), int16(centerY+(options.Height-1)/2))
+}
+
+func floorDiv(a int, b int) int {
+	quotient := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		quotient--
+	}
+	return quotient
+}
+
+type encoder struct {
+	planes []uint8
+	// size of a single plane, in cells
+	width  int
+	height int
+	origin position.Position
+}
+
+// Set the cell of the plane at the given cell (row, column) of the tile at the position.
+// The cells outside of the window are ignored.
+func (encoder *encoder) set(plane int, pos position.Position, row int, column int) {
+	tileColumn := int(pos.X()) - int(encoder.origin.X())
+	// the Y axis points up while the rows go down
+	tileRow := int(encoder.origin.Y()) - int(pos.Y())
+	row += tileRow * CellSize
+	column += tileColumn * CellSize
+	if row < 0 || row >= encoder.height || column < 0 || column >= encoder.width {
+		return
+	}
+	encoder.planes[(plane*encoder.height+row)*encoder.width+column] = 1
+}
+
+// Set the cells of the plane that are covered by the feature of the tile at the position.
+func (encoder *encoder) setFeature(plane int, pos position.Position, feat feature.Feature) {
+	sideCount := 0
+	for primarySide, cell := range sideCells {
+		if feat.Sides.HasSide(primarySide) {
+			encoder.set(plane, pos, cell[0], cell[1])
+			sideCount++
+		}
+	}
+	for corner, cell := range cornerCells {
+		// the fields take up the corners even when they reach just one of their edges
+		if feat.Sides.HasSide(corner) || feat.FeatureType == feature.Field && feat.Sides.OverlapsSide(corner) {
+			encoder.set(plane, pos, cell[0], cell[1])
+		}
+	}
+
+	switch feat.FeatureType {
+	case feature.Monastery, feature.Garden:
+		encoder.set(plane, pos, 1, 1)
+	case feature.Field:
+		// unconnected field, e.g. around a monastery
+		if feat.Sides == side.NoSide {
+			encoder.set(plane, pos, 1, 1)
+		}
+	default:
+		// the features connecting multiple sides go through the center
+		if sideCount >= 2 {
+			encoder.set(plane, pos, 1, 1)
+		}
+	}
+}
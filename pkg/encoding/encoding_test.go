@@ -0,0 +1,177 @@
+package encoding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func getCell(encoding Encoding, plane int, row int, column int) uint8 {
+	height, width := encoding.Shape[1], encoding.Shape[2]
+	return encoding.Planes[(plane*height+row)*width+column]
+}
+
+func sumPlane(encoding Encoding, plane int) int {
+	size := encoding.Shape[1] * encoding.Shape[2]
+	sum := 0
+	for _, value := range encoding.Planes[plane*size : (plane+1)*size] {
+		sum += int(value)
+	}
+	return sum
+}
+
+func TestEncodeRendersStartingTile(t *testing.T) {
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles:        []tiles.Tile{tiletemplates.MonasteryWithoutRoads()},
+	}
+	g, err := game.NewFromTileSet(tileSet, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	encoding, err := Encode(g, tiletemplates.MonasteryWithoutRoads(), Options{Width: 3, Height: 3})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expectedShape := []int{MeeplePlanesStart + 2, 9, 9}
+	for i := range expectedShape {
+		if encoding.Shape[i] != expectedShape[i] {
+			t.Fatalf("expected %#v, got %#v instead", expectedShape, encoding.Shape)
+		}
+	}
+	if encoding.Origin != position.New(-1, 1) {
+		t.Fatalf("expected %#v, got %#v instead", position.New(-1, 1), encoding.Origin)
+	}
+
+	// the starting tile is in the middle of the window (cells 3-5)
+	// with the city on top and the road going from left to right
+	expectedCells := []struct {
+		plane  int
+		row    int
+		column int
+	}{
+		{CityPlane, 3, 4},
+		{RoadPlane, 4, 3},
+		{RoadPlane, 4, 4},
+		{RoadPlane, 4, 5},
+		{FieldPlane, 3, 3},
+		{FieldPlane, 5, 4},
+		{OpenEdgePlane, 3, 4},
+		{OpenEdgePlane, 4, 3},
+		{OpenEdgePlane, 4, 5},
+		{OpenEdgePlane, 5, 4},
+	}
+	for _, cell := range expectedCells {
+		if getCell(encoding, cell.plane, cell.row, cell.column) != 1 {
+			t.Fatalf("expected cell %#v to be set", cell)
+		}
+	}
+	if sumPlane(encoding, CityPlane) != 1 || sumPlane(encoding, RoadPlane) != 3 {
+		t.Fatalf("expected only the starting tile's features to be set, got %#v instead", encoding.Planes)
+	}
+
+	// the monastery without roads can only be placed below the starting tile
+	if sumPlane(encoding, PlaceablePlane) != CellSize*CellSize {
+		t.Fatalf("expected %#v, got %#v instead", CellSize*CellSize, sumPlane(encoding, PlaceablePlane))
+	}
+	if getCell(encoding, PlaceablePlane, 7, 4) != 1 {
+		t.Fatal("expected the position below the starting tile to be placeable")
+	}
+
+	if len(encoding.TileHistogram) != 1 || encoding.TileHistogram[0] != 1 {
+		t.Fatalf("expected %#v, got %#v instead", []uint8{1}, encoding.TileHistogram)
+	}
+}
+
+func TestEncodeRendersMeeplesOfEachPlayer(t *testing.T) {
+	tile := tiletemplates.MonasteryWithoutRoads()
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles:        []tiles.Tile{tile, tile},
+	}
+	g, err := game.NewFromTileSet(tileSet, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	move := elements.ToPlacedTile(tile)
+	move.Position = position.New(0, -1)
+	// meeple on the monastery
+	move.Features[1].Meeple = elements.Meeple{Type: elements.NormalMeeple, PlayerID: 1}
+	if err := g.PlayTurn(move); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	encoding, err := Encode(g, tiles.Tile{}, DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if sumPlane(encoding, MeeplePlanesStart) == 0 {
+		t.Fatal("expected the meeple of the first player to be rendered")
+	}
+	if sumPlane(encoding, MeeplePlanesStart+1) != 0 {
+		t.Fatal("expected no meeples of the second player")
+	}
+	if sumPlane(encoding, PlaceablePlane) != 0 {
+		t.Fatal("expected no placeable positions without the tile to place")
+	}
+	if len(encoding.Float32Planes()) != len(encoding.Planes) {
+		t.Fatalf("expected %#v, got %#v instead", len(encoding.Planes), len(encoding.Float32Planes()))
+	}
+}
+
+func TestEncodeCropsTilesOutsideOfWindow(t *testing.T) {
+	tile := tiletemplates.StraightRoads()
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles:        []tiles.Tile{tile, tile},
+	}
+	g, err := game.NewFromTileSet(tileSet, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, x := range []int16{1, 2} {
+		move := elements.ToPlacedTile(tile)
+		move.Position = position.New(x, 0)
+		if err := g.PlayTurn(move); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// the window is centered on the middle tile
+	encoding, err := Encode(g, tiles.Tile{}, Options{Width: 1, Height: 1})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if encoding.Origin != position.New(1, 0) {
+		t.Fatalf("expected %#v, got %#v instead", position.New(1, 0), encoding.Origin)
+	}
+	if sumPlane(encoding, RoadPlane) != 3 {
+		t.Fatalf("expected %#v, got %#v instead", 3, sumPlane(encoding, RoadPlane))
+	}
+	// neither of the tile's horizontal sides is open
+	if getCell(encoding, OpenEdgePlane, 1, 0) != 0 || getCell(encoding, OpenEdgePlane, 1, 2) != 0 {
+		t.Fatal("expected the sides connected to the neighbouring tiles to be closed")
+	}
+}
+
+func TestEncodeReturnsErrorForInvalidOptions(t *testing.T) {
+	g, err := game.NewFromTileSet(tilesets.StandardTileSet(), nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	_, err = Encode(g, tiles.Tile{}, Options{})
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("expected %#v, got %#v instead", ErrInvalidOptions, err)
+	}
+}
@@ -0,0 +1,66 @@
+package encoding
+
+import (
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+)
+
+// number of the symmetries of the board (rotations with and without mirroring)
+const SymmetryCount = 8
+
+// Game state transformed by one of the symmetries of the board,
+// see game.Game.Transformed().
+type SymmetricVariant struct {
+	Rotation uint
+	Mirror   bool
+	Game     *game.Game
+	// the tile to place, transformed the same way as the game
+	TileToPlace tiles.Tile
+	// legal moves of the variant, in the same order as the legal moves
+	// of the original state that they were transformed from
+	LegalMoves []elements.PlacedTile
+	Encoding   Encoding
+}
+
+// Returns the variants of the game state under all of the symmetries of the board,
+// starting with the untransformed state, meant for augmenting the training data.
+//
+// The legal moves are found for the given tile (none are found, if it is empty)
+// so that the policy over the moves of the original state applies to each of the variants.
+func SymmetricVariants(g *game.Game, tileToPlace tiles.Tile, options Options) ([]SymmetricVariant, error) {
+	legalMoves := []elements.PlacedTile{}
+	if len(tileToPlace.Features) != 0 {
+		for _, placement := range g.GetTilePlacementsFor(tileToPlace) {
+			legalMoves = append(legalMoves, g.GetLegalMovesFor(placement)...)
+		}
+	}
+
+	variants := make([]SymmetricVariant, 0, SymmetryCount)
+	for _, mirror := range []bool{false, true} {
+		for rotation := range uint(4) {
+			transformed, err := g.Transformed(rotation, mirror)
+			if err != nil {
+				return nil, err
+			}
+			variant := SymmetricVariant{
+				Rotation:   rotation,
+				Mirror:     mirror,
+				Game:       transformed,
+				LegalMoves: make([]elements.PlacedTile, len(legalMoves)),
+			}
+			if len(tileToPlace.Features) != 0 {
+				variant.TileToPlace = game.TransformTile(tileToPlace, rotation, mirror)
+			}
+			for i, move := range legalMoves {
+				variant.LegalMoves[i] = game.TransformMove(move, rotation, mirror)
+			}
+			variant.Encoding, err = Encode(transformed, variant.TileToPlace, options)
+			if err != nil {
+				return nil, err
+			}
+			variants = append(variants, variant)
+		}
+	}
+	return variants, nil
+}
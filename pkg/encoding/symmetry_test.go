@@ -0,0 +1,82 @@
+package encoding
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func TestSymmetricVariantsTransformStateAndLegalMoves(t *testing.T) {
+	g, err := game.NewFromTileSet(tilesets.StandardTileSet(), nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	rng := rand.New(rand.NewSource(0)) //nolint:gosec// Weak number generator is sufficent in our case
+	for range 10 {
+		tile, err := g.GetCurrentTile()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		moves := []elements.PlacedTile{}
+		for _, placement := range g.GetTilePlacementsFor(tile) {
+			moves = append(moves, g.GetLegalMovesFor(placement)...)
+		}
+		if err = g.PlayTurn(moves[rng.Intn(len(moves))]); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	tile, err := g.GetCurrentTile()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// the window is large enough to contain the whole board
+	options := Options{Width: 25, Height: 25}
+	variants, err := SymmetricVariants(g, tile, options)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(variants) != SymmetryCount {
+		t.Fatalf("expected %#v, got %#v instead", SymmetryCount, len(variants))
+	}
+
+	for _, variant := range variants {
+		// the variants have the same amount of each feature
+		for plane := range variant.Encoding.Shape[0] {
+			if sumPlane(variant.Encoding, plane) != sumPlane(variants[0].Encoding, plane) {
+				t.Fatalf(
+					"expected %#v, got %#v instead",
+					sumPlane(variants[0].Encoding, plane),
+					sumPlane(variant.Encoding, plane),
+				)
+			}
+		}
+
+		// the transformed legal moves are exactly the legal moves of the variant
+		space, err := NewActionSpace(variant.Game, options)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		mask := space.LegalActionMask(variant.Game, variant.TileToPlace)
+		legalCount := 0
+		for _, value := range mask {
+			legalCount += int(value)
+		}
+		if legalCount != len(variant.LegalMoves) {
+			t.Fatalf("expected %#v, got %#v instead", len(variant.LegalMoves), legalCount)
+		}
+		for _, move := range variant.LegalMoves {
+			action, err := space.EncodeMove(variant.TileToPlace, move)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if mask[action] != 1 {
+				t.Fatalf("expected action %#v to be legal", action)
+			}
+		}
+	}
+}
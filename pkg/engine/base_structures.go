@@ -1,7 +1,9 @@
 package engine
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
 )
@@ -9,10 +11,17 @@ import (
 // Internal struct passed to the worker function through the input buffer
 // with the game pointer, the request, and ways to communicate with the requestor.
 type workerInput struct {
+	// the request is not executed, if this is done before it reaches the worker
+	ctx context.Context
+	// the request is not executed, if it reaches the worker past this time (unless zero)
+	deadline     time.Time
 	requestID    int
 	outputBuffer chan workerOutput
 	waitGroup    *sync.WaitGroup
 	game         *game.Game
+	gameLock     *gameLock
+	stateCache   *stateCache
+	helpers      helperPool
 	request      Request
 	canWrite     bool
 }
@@ -25,6 +34,7 @@ type workerOutput struct {
 
 type outputItemInfo struct {
 	GameID        int
+	GameLock      *gameLock
 	RequestIndex  int
 	AcquiredWrite bool
 }
@@ -58,6 +68,20 @@ type Request interface {
 	execute(*game.Game) Response
 }
 
+// Requests implementing this interface resolve the GameStates of their game
+// and get access to the engine's cache of the resolved states.
+// The worker calls `executeWithStateCache()` instead of `execute()` for them.
+type stateResolvingRequest interface {
+	executeWithStateCache(*game.Game, *stateCache) Response
+}
+
+// State resolving requests implementing this interface split their work
+// between the engine's helper goroutines (see helperPool).
+// The worker calls `executeWithHelpers()` instead of `executeWithStateCache()` for them.
+type parallelRequest interface {
+	executeWithHelpers(*game.Game, *stateCache, helperPool) Response
+}
+
 // Concrete type implementing the `Response` interface
 // that can be conveniently embedded into every response type.
 type BaseResponse struct {
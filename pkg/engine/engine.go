@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -9,10 +11,13 @@ import (
 	"runtime/debug"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/logger"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
 )
@@ -21,10 +26,18 @@ var (
 	ErrCommunicatorClosed  = errors.New("communicator is closed")
 	ErrGameNotFound        = errors.New("game with the given ID was not found")
 	ErrLockAlreadyAcquired = errors.New("lock for game with this ID is already acquired")
+	ErrInvalidPlayerCount  = errors.New("player count is outside of the supported range")
+	ErrInvalidBatchRequest = errors.New("batch request has to hold exactly one request")
+	// returned for the requests that did not reach a worker before their deadline
+	ErrRequestDeadlineExceeded = errors.New("request did not start before its deadline")
+	// returned for the requests that did not reach a worker before the batch was cancelled
+	ErrRequestCancelled = errors.New("request was cancelled")
 )
 
 const (
 	inputBufferSize = 10_000_000
+	MinPlayerCount  = 2
+	MaxPlayerCount  = 6
 )
 
 type ExecutionPanicError struct {
@@ -59,6 +72,23 @@ func processWorkerInput(input *workerInput) (resp Response) {
 		}
 	}()
 
+	if input.ctx.Err() != nil {
+		return &SyncResponse{BaseResponse{gameID: input.request.gameID(), err: ErrRequestCancelled}}
+	}
+	if !input.deadline.IsZero() && !time.Now().Before(input.deadline) {
+		return &SyncResponse{BaseResponse{gameID: input.request.gameID(), err: ErrRequestDeadlineExceeded}}
+	}
+
+	if input.canWrite {
+		// the states of the game are no longer valid once it changes
+		input.stateCache.removeGame(input.request.gameID())
+	}
+	if req, ok := input.request.(parallelRequest); ok {
+		return req.executeWithHelpers(input.game, input.stateCache, input.helpers)
+	}
+	if req, ok := input.request.(stateResolvingRequest); ok {
+		return req.executeWithStateCache(input.game, input.stateCache)
+	}
 	return input.request.execute(input.game)
 }
 
@@ -66,7 +96,7 @@ func worker(comm *communicator) {
 	defer comm.workGroup.Done()
 
 	for input := range comm.inputBuffer {
-		resp := processWorkerInput(&input)
+		resp := processWorkerInput(input)
 		input.outputBuffer <- workerOutput{
 			requestID: input.requestID,
 			resp:      resp,
@@ -78,14 +108,15 @@ func worker(comm *communicator) {
 // Internal struct with communication tools used by the game engine to send requests
 // and cooperatively shutdown the workers.
 type communicator struct {
-	workGroup   sync.WaitGroup
-	inputBuffer chan workerInput
+	workGroup sync.WaitGroup
+	// pointers keep the memory footprint of the (large) buffer small
+	inputBuffer chan *workerInput
 	closed      bool
 }
 
 func newCommunicator() *communicator {
 	return &communicator{
-		inputBuffer: make(chan workerInput, inputBufferSize),
+		inputBuffer: make(chan *workerInput, inputBufferSize),
 	}
 }
 
@@ -106,17 +137,29 @@ type SerializedGameWithID struct {
 
 // The entry point for Python side of things - the engine keeps track of created games,
 // sends requests to its workers and returns back responses received from them.
+//
+// All of the exported methods are safe for concurrent use.
 type GameEngine struct {
-	comm          *communicator
-	logDir        string
+	comm   *communicator
+	logDir string
+	// guards all of the fields below, it is never held while a request is executed
+	mutex         sync.Mutex
 	games         map[int]*game.Game
-	gameMutexes   map[int]*sync.RWMutex
+	gameLocks     map[int]*gameLock
 	nextGameID    int
 	nextRequestID int
 	closed        bool
-	childGames    map[int]map[int]struct{}
-	parentGames   map[int]int
-	appLogger     *log.Logger
+	// see SetOrderedLocking()
+	orderedLocking bool
+	// see SetUndoEnabled()
+	undoEnabled bool
+	childGames  map[int]map[int]struct{}
+	parentGames map[int]int
+	appLogger   *log.Logger
+	// not guarded by the engine's mutex, it has its own
+	stateCache *stateCache
+	// one helper per worker, not guarded by the engine's mutex
+	helpers helperPool
 }
 
 func StartGameEngine(workerCount int, logDir string) (*GameEngine, error) {
@@ -130,12 +173,14 @@ func StartGameEngine(workerCount int, logDir string) (*GameEngine, error) {
 		comm:          comm,
 		logDir:        logDir,
 		games:         map[int]*game.Game{},
-		gameMutexes:   map[int]*sync.RWMutex{},
+		gameLocks:     map[int]*gameLock{},
 		nextGameID:    1,
 		nextRequestID: 1,
 		childGames:    map[int]map[int]struct{}{},
 		parentGames:   map[int]int{},
 		appLogger:     log.New(os.Stderr, "", log.LstdFlags),
+		stateCache:    newStateCache(DefaultStateCacheSize),
+		helpers:       newHelperPool(workerCount),
 	}
 
 	for range workerCount {
@@ -147,10 +192,14 @@ func StartGameEngine(workerCount int, logDir string) (*GameEngine, error) {
 }
 
 func (engine *GameEngine) IsClosed() bool {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
 	return engine.closed
 }
 
 func (engine *GameEngine) Close() {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
 	if engine.closed {
 		return
 	}
@@ -158,54 +207,236 @@ func (engine *GameEngine) Close() {
 	engine.comm.Close()
 }
 
-// Generate a random game from the given tileset.
-func (engine *GameEngine) GenerateGame(tileSet tilesets.TileSet) (SerializedGameWithID, error) {
-	deckStack := stack.New(tileSet.Tiles)
+// Enable or disable the ordered locking mode (disabled by default).
+//
+// By default, a request fails with `ErrLockAlreadyAcquired`, if another request
+// that is still in flight holds the lock of its game in a conflicting way
+// (i.e. either of the requests requires write access).
+// In the ordered mode, such a request waits for the lock instead and the requests
+// for the same game - both in one batch and across concurrent batches - get executed
+// in the order of their submission, e.g. several `GetLegalMovesRequest`s followed by
+// a `PlayTurnRequest` on the same game can be sent in a single batch.
+//
+// The reading requests that are not separated by a writing request
+// can still be executed concurrently.
+func (engine *GameEngine) SetOrderedLocking(enabled bool) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	engine.orderedLocking = enabled
+}
+
+// Enable or disable recording the turns of the games added to the engine afterwards
+// (disabled by default), allowing them to be reverted with `UndoTurnRequest`.
+//
+// The recorded turns are kept for the whole lifetime of the game
+// so it should only be enabled when the turns actually need to be reverted.
+// The games loaded from logs always record their turns, see game.NewFromLog().
+func (engine *GameEngine) SetUndoEnabled(enabled bool) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	engine.undoEnabled = enabled
+}
+
+// Set the maximum number of the game states resolved by the requests that are kept
+// in the engine's cache (DefaultStateCacheSize by default), 0 disables the cache.
+//
+// The least recently used states are evicted from the cache when it is full,
+// the states that are no longer needed can be released early with ReleaseStates().
+func (engine *GameEngine) SetStateCacheSize(size int) {
+	engine.stateCache.setCapacity(size)
+}
+
+// Returns the statistics of the engine's cache of game states.
+func (engine *GameEngine) StateCacheStats() StateCacheStats {
+	return engine.stateCache.getStats()
+}
+
+// Release the given states of the game with the given ID from the engine's cache.
+// The released states can still be used in the requests but they have to be resolved again.
+func (engine *GameEngine) ReleaseStates(gameID int, states []*GameState) {
+	for _, state := range states {
+		engine.stateCache.release(gameID, state)
+	}
+}
+
+// Generate a random game for the given number of players from the given tileset
+// played with the given ruleset.
+func (engine *GameEngine) GenerateGame(
+	tileSet tilesets.TileSet, playerCount int, ruleset rules.Ruleset,
+) (SerializedGameWithID, error) {
+	deckStack := stack.NewInGroups(tileSet.Tiles, tileSet.Groups)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
-	return engine.generateGameFromDeck(deck)
+	return engine.generateGameFromDeck(deck, playerCount, ruleset)
 }
 
-// Generate a random game from the given tileset and seed.
-func (engine *GameEngine) GenerateSeededGame(tileSet tilesets.TileSet, seed int64) (SerializedGameWithID, error) {
-	deckStack := stack.NewSeeded(tileSet.Tiles, seed)
+// Generate a random game for the given number of players from the given tileset
+// and seed played with the given ruleset.
+func (engine *GameEngine) GenerateSeededGame(
+	tileSet tilesets.TileSet, seed int64, playerCount int, ruleset rules.Ruleset,
+) (SerializedGameWithID, error) {
+	deckStack := stack.NewSeededInGroups(tileSet.Tiles, tileSet.Groups, seed)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
-	return engine.generateGameFromDeck(deck)
+	return engine.generateGameFromDeck(deck, playerCount, ruleset)
 }
 
-// Generate a game from the given tileset using its defined tile order.
+// Generate a game for the given number of players from the given tileset
+// using its defined tile order, played with the given ruleset.
 //
 // Usage for games played by an agent is ill-advised - the serialized game reveals
 // the tileset and the order in it will be consistent with stack's order.
-func (engine *GameEngine) GenerateOrderedGame(tileSet tilesets.TileSet) (SerializedGameWithID, error) {
+func (engine *GameEngine) GenerateOrderedGame(
+	tileSet tilesets.TileSet, playerCount int, ruleset rules.Ruleset,
+) (SerializedGameWithID, error) {
 	deckStack := stack.NewOrdered(tileSet.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
-	return engine.generateGameFromDeck(deck)
+	return engine.generateGameFromDeck(deck, playerCount, ruleset)
+}
+
+func (engine *GameEngine) generateGameFromDeck(
+	deck deck.Deck, playerCount int, ruleset rules.Ruleset,
+) (SerializedGameWithID, error) {
+	if playerCount < MinPlayerCount || playerCount > MaxPlayerCount {
+		return SerializedGameWithID{}, fmt.Errorf(
+			"%w: %v (expected %v-%v)",
+			ErrInvalidPlayerCount,
+			playerCount,
+			MinPlayerCount,
+			MaxPlayerCount,
+		)
+	}
+
+	id := engine.reserveGameIDs(1)[0]
+
+	log, err := engine.newGameLogger(id)
+	if err != nil {
+		return SerializedGameWithID{}, err
+	}
+
+	g, err := game.NewFromDeck(deck, log, uint8(playerCount), ruleset)
+	if err != nil {
+		return SerializedGameWithID{}, err
+	}
+
+	engine.addGames([]int{id}, []*game.Game{g})
+	return SerializedGameWithID{id, g.Serialized()}, nil
 }
 
-func (engine *GameEngine) generateGameFromDeck(deck deck.Deck) (SerializedGameWithID, error) {
-	id := engine.nextGameID
-	engine.nextGameID++
+// Load the game by replaying the log file (as created by the engine) at the given path.
+//
+// The scores recomputed during the replay are verified against the logged ones,
+// see game.NewFromLog() for details.
+func (engine *GameEngine) LoadGameFromLog(logPath string) (SerializedGameWithID, error) {
+	file, err := os.Open(logPath)
+	if err != nil {
+		return SerializedGameWithID{}, err
+	}
+	defer file.Close()
+
+	entries, err := logger.ReadEntries(file)
+	if err != nil {
+		return SerializedGameWithID{}, fmt.Errorf("%w: %w", game.ErrInvalidLog, err)
+	}
+
+	// the game gets its ID and log file only once the log is known to be valid,
+	// the events logged during the replay are kept in memory until then
+	replayedEvents := &bytes.Buffer{}
+	replayLog := logger.New(replayedEvents)
+	g, err := game.NewFromLog(entries, &replayLog)
+	if err != nil {
+		return SerializedGameWithID{}, err
+	}
+
+	id := engine.reserveGameIDs(1)[0]
 
-	var log logger.Logger
-	if engine.logDir != "" {
-		logFile := path.Join(engine.logDir, fmt.Sprintf("%v.jsonl", id))
-		fileLog, err := logger.NewFromFile(logFile)
-		if err != nil {
+	log, err := engine.newGameLogger(id)
+	if err != nil {
+		return SerializedGameWithID{}, err
+	}
+	if log != nil {
+		if _, err := log.AsWriter().Write(replayedEvents.Bytes()); err != nil {
 			return SerializedGameWithID{}, err
 		}
-		log = &fileLog
+	}
+	g.SetLog(log)
+
+	engine.addGames([]int{id}, []*game.Game{g})
+	return SerializedGameWithID{id, g.Serialized()}, nil
+}
+
+// Save the full state of the game with the given ID to a binary file at the given path.
+// The saved game can be restored with LoadGame(), even in another process.
+func (engine *GameEngine) SaveGame(gameID int, savePath string) error {
+	req := &saveGameRequest{GameID: gameID, Path: savePath}
+	responses := engine.sendBatch([]Request{req})
+	return responses[0].Err()
+}
+
+// Load the game saved with SaveGame() from the file at the given path.
+//
+// The loaded game gets a new ID and its log does not contain the events
+// from before the game was saved.
+func (engine *GameEngine) LoadGame(savePath string) (SerializedGameWithID, error) {
+	data, err := os.ReadFile(savePath)
+	if err != nil {
+		return SerializedGameWithID{}, err
+	}
+
+	// the game gets its ID and log file only once the snapshot is known to be valid
+	g, err := game.UnmarshalBinary(data, nil)
+	if err != nil {
+		return SerializedGameWithID{}, err
 	}
 
-	g, err := game.NewFromDeck(deck, log, 2)
+	id := engine.reserveGameIDs(1)[0]
+
+	log, err := engine.newGameLogger(id)
 	if err != nil {
 		return SerializedGameWithID{}, err
 	}
+	g.SetLog(log)
 
-	engine.games[id] = g
-	engine.gameMutexes[id] = &sync.RWMutex{}
+	engine.addGames([]int{id}, []*game.Game{g})
 	return SerializedGameWithID{id, g.Serialized()}, nil
 }
 
+// Reserve the IDs for the given number of games that are about to be created.
+func (engine *GameEngine) reserveGameIDs(count int) []int {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	ids := make([]int, count)
+	for i := range count {
+		ids[i] = engine.nextGameID
+		engine.nextGameID++
+	}
+	return ids
+}
+
+// Add the created games with the given (reserved) IDs to the engine.
+func (engine *GameEngine) addGames(ids []int, games []*game.Game) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	for i, id := range ids {
+		if engine.undoEnabled {
+			games[i].EnableUndo()
+		}
+		engine.games[id] = games[i]
+		engine.gameLocks[id] = &gameLock{}
+	}
+}
+
+// Create the logger for the game with the given ID, returns nil when logging is disabled.
+func (engine *GameEngine) newGameLogger(id int) (logger.Logger, error) {
+	if engine.logDir == "" {
+		return nil, nil
+	}
+	logFile := path.Join(engine.logDir, fmt.Sprintf("%v.jsonl", id))
+	fileLog, err := logger.NewFromFile(logFile)
+	if err != nil {
+		return nil, err
+	}
+	return &fileLog, nil
+}
+
 // *Fully* clone the game (including its log) with the given ID `count` times
 // returning the IDs of the cloned games.
 // Intended use: Allowing multiple agents to play the same game scenario.
@@ -227,6 +458,8 @@ func (engine *GameEngine) SubCloneGame(gameID int, count int) ([]int, error) {
 		return ret, err
 	}
 
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
 	childGames, ok := engine.childGames[gameID]
 	if !ok {
 		childGames = map[int]struct{}{}
@@ -240,11 +473,49 @@ func (engine *GameEngine) SubCloneGame(gameID int, count int) ([]int, error) {
 	return ret, nil
 }
 
+// Start the PUCT searches of the current turns of the games with the given IDs,
+// see agents.PUCTDriver. The searches work on copies of the games
+// so the games can be modified while the searches are driven.
+//
+// Intended use: Evaluating the leaves of the searches in batches outside of Go
+// (e.g. through the Python bindings), otherwise see PUCTSearch().
+func (engine *GameEngine) StartPUCTSearch(
+	gameIDs []int, config agents.PUCTConfig, seed int64,
+) (*agents.PUCTDriver, error) {
+	requests := make([]Request, len(gameIDs))
+	for i, gameID := range gameIDs {
+		requests[i] = &snapshotGameRequest{GameID: gameID}
+	}
+	games := make([]*game.Game, len(gameIDs))
+	for i, resp := range engine.sendBatch(requests) {
+		if resp.Err() != nil {
+			return nil, resp.Err()
+		}
+		games[i] = resp.(*snapshotGameResponse).Game
+	}
+	return agents.NewPUCTDriver(games, config, seed)
+}
+
+// Search the current turns of the games with the given IDs, evaluating the leaves
+// of all of the searches in batches with the given evaluator, see agents.PUCTSearch().
+func (engine *GameEngine) PUCTSearch(
+	gameIDs []int, evaluator agents.Evaluator, config agents.PUCTConfig, seed int64,
+) ([]agents.SearchResult, error) {
+	driver, err := engine.StartPUCTSearch(gameIDs, config, seed)
+	if err != nil {
+		return nil, err
+	}
+	return driver.Run(evaluator)
+}
+
 // Delete games with the given IDs.
 func (engine *GameEngine) DeleteGames(gameIDs []int) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
 	for _, gameID := range gameIDs {
+		engine.stateCache.removeGame(gameID)
 		delete(engine.games, gameID)
-		delete(engine.gameMutexes, gameID)
+		delete(engine.gameLocks, gameID)
 		delete(engine.childGames, gameID)
 		parentID := engine.parentGames[gameID]
 		if parentID != 0 {
@@ -254,11 +525,7 @@ func (engine *GameEngine) DeleteGames(gameIDs []int) {
 }
 
 func (engine *GameEngine) cloneGame(gameID int, count int, full bool) ([]int, error) {
-	reservedIDs := make([]int, count)
-	for i := range count {
-		reservedIDs[i] = engine.nextGameID
-		engine.nextGameID++
-	}
+	reservedIDs := engine.reserveGameIDs(count)
 
 	req := &cloneGameRequest{
 		GameID:      gameID,
@@ -272,120 +539,95 @@ func (engine *GameEngine) cloneGame(gameID int, count int, full bool) ([]int, er
 	}
 
 	resp := responses[0].(*cloneGameResponse)
-	for i, game := range resp.Clones {
-		engine.games[reservedIDs[i]] = game
-		engine.gameMutexes[reservedIDs[i]] = &sync.RWMutex{}
-	}
+	engine.addGames(reservedIDs, resp.Clones)
 
 	return reservedIDs, nil
 }
 
-// Due to limitations of Python bindings generator with []interface return type,
-// this wraps sendBatch() and limits the return type to only one Response type.
-func (engine *GameEngine) SendPlayTurnBatch(concreteRequests []*PlayTurnRequest) []*PlayTurnResponse {
-	requests := make([]Request, len(concreteRequests))
-	for i := range concreteRequests {
-		requests[i] = concreteRequests[i]
-	}
-	responses := engine.sendBatch(requests)
-	concreteResponses := make([]*PlayTurnResponse, len(responses))
-	for i := range responses {
-		var ok bool
-		concreteResponses[i], ok = responses[i].(*PlayTurnResponse)
-		if !ok {
-			// we can get a SyncResponse here, if the request didn't reach
-			// a worker due to failure during prepareWorkerInput
-			// this *is* stupid but it's what we have to deal with due to
-			// a limitation with auto-generated bindings breaking on
-			// a `[]Interface` return:
-			// https://github.com/go-python/gopy/issues/357
-			concreteResponses[i] = &PlayTurnResponse{
-				BaseResponse: responses[i].(*SyncResponse).BaseResponse,
-			}
+// Send a batch of requests of any of the kinds supported by BatchRequest
+// and wait for all of the responses.
+// The order of the returned responses corresponds to the requests slice.
+//
+// This is the typed counterpart of sendBatch() - due to limitations of
+// Python bindings generator with []interface return type, the requests and responses
+// are wrapped in envelopes with a field for each of the request kinds:
+// https://github.com/go-python/gopy/issues/357
+func (engine *GameEngine) SendBatch(batchRequests []*BatchRequest) []*BatchResponse {
+	return engine.SubmitBatch(batchRequests).Responses()
+}
+
+// Send a batch of requests of a single kind and wait for all of the responses,
+// the typed counterpart of SendBatch() behind the Send<Kind>Batch() methods.
+func sendConcreteBatch[Resp Response, Req batchableRequest](
+	engine *GameEngine, concreteRequests []Req,
+) []Resp {
+	requests := make([]*BatchRequest, len(concreteRequests))
+	for i, req := range concreteRequests {
+		requests[i] = req.batchRequest()
+	}
+	responses := engine.SendBatch(requests)
+	concreteResponses := make([]Resp, len(responses))
+	for i, resp := range responses {
+		concreteResp := resp.resp
+		if concreteResp == nil {
+			// the request was nil
+			concreteResp = concreteRequests[i].emptyResponse(resp.BaseResponse)
 		}
+		concreteResponses[i] = concreteResp.(Resp)
 	}
 	return concreteResponses
 }
 
-// Due to limitations of Python bindings generator with []interface return type,
-// this wraps sendBatch() and limits the return type to only one Response type.
+// Send a batch of requests of a single kind, see SendBatch().
+func (engine *GameEngine) SendPlayTurnBatch(concreteRequests []*PlayTurnRequest) []*PlayTurnResponse {
+	return sendConcreteBatch[*PlayTurnResponse](engine, concreteRequests)
+}
+
+// Send a batch of requests of a single kind, see SendBatch().
+func (engine *GameEngine) SendUndoTurnBatch(concreteRequests []*UndoTurnRequest) []*UndoTurnResponse {
+	return sendConcreteBatch[*UndoTurnResponse](engine, concreteRequests)
+}
+
+// Send a batch of requests of a single kind, see SendBatch().
 func (engine *GameEngine) SendGetRemainingTilesBatch(concreteRequests []*GetRemainingTilesRequest) []*GetRemainingTilesResponse {
-	requests := make([]Request, len(concreteRequests))
-	for i := range concreteRequests {
-		requests[i] = concreteRequests[i]
-	}
-	responses := engine.sendBatch(requests)
-	concreteResponses := make([]*GetRemainingTilesResponse, len(responses))
-	for i := range responses {
-		var ok bool
-		concreteResponses[i], ok = responses[i].(*GetRemainingTilesResponse)
-		if !ok {
-			// we can get a SyncResponse here, if the request didn't reach
-			// a worker due to failure during prepareWorkerInput
-			// this *is* stupid but it's what we have to deal with due to
-			// a limitation with auto-generated bindings breaking on
-			// a `[]Interface` return:
-			// https://github.com/go-python/gopy/issues/357
-			concreteResponses[i] = &GetRemainingTilesResponse{
-				BaseResponse: responses[i].(*SyncResponse).BaseResponse,
-			}
-		}
-	}
-	return concreteResponses
+	return sendConcreteBatch[*GetRemainingTilesResponse](engine, concreteRequests)
 }
 
-// Due to limitations of Python bindings generator with []interface return type,
-// this wraps sendBatch() and limits the return type to only one Response type.
+// Send a batch of requests of a single kind, see SendBatch().
 func (engine *GameEngine) SendGetLegalMovesBatch(concreteRequests []*GetLegalMovesRequest) []*GetLegalMovesResponse {
-	requests := make([]Request, len(concreteRequests))
-	for i := range concreteRequests {
-		requests[i] = concreteRequests[i]
-	}
-	responses := engine.sendBatch(requests)
-	concreteResponses := make([]*GetLegalMovesResponse, len(responses))
-	for i := range responses {
-		var ok bool
-		concreteResponses[i], ok = responses[i].(*GetLegalMovesResponse)
-		if !ok {
-			// we can get a SyncResponse here, if the request didn't reach
-			// a worker due to failure during prepareWorkerInput
-			// this *is* stupid but it's what we have to deal with due to
-			// a limitation with auto-generated bindings breaking on
-			// a `[]Interface` return:
-			// https://github.com/go-python/gopy/issues/357
-			concreteResponses[i] = &GetLegalMovesResponse{
-				BaseResponse: responses[i].(*SyncResponse).BaseResponse,
-			}
-		}
-	}
-	return concreteResponses
+	return sendConcreteBatch[*GetLegalMovesResponse](engine, concreteRequests)
 }
 
-// Due to limitations of Python bindings generator with []interface return type,
-// this wraps sendBatch() and limits the return type to only one Response type.
+// Send a batch of requests of a single kind, see SendBatch().
 func (engine *GameEngine) SendGetMidGameScoreBatch(concreteRequests []*GetMidGameScoreRequest) []*GetMidGameScoreResponse {
-	requests := make([]Request, len(concreteRequests))
-	for i := range concreteRequests {
-		requests[i] = concreteRequests[i]
-	}
-	responses := engine.sendBatch(requests)
-	concreteResponses := make([]*GetMidGameScoreResponse, len(responses))
-	for i := range responses {
-		var ok bool
-		concreteResponses[i], ok = responses[i].(*GetMidGameScoreResponse)
-		if !ok {
-			// we can get a SyncResponse here, if the request didn't reach
-			// a worker due to failure during prepareWorkerInput
-			// this *is* stupid but it's what we have to deal with due to
-			// a limitation with auto-generated bindings breaking on
-			// a `[]Interface` return:
-			// https://github.com/go-python/gopy/issues/357
-			concreteResponses[i] = &GetMidGameScoreResponse{
-				BaseResponse: responses[i].(*SyncResponse).BaseResponse,
-			}
-		}
-	}
-	return concreteResponses
+	return sendConcreteBatch[*GetMidGameScoreResponse](engine, concreteRequests)
+}
+
+// Send a batch of requests of a single kind, see SendBatch().
+func (engine *GameEngine) SendGetSuccessorStateBatch(concreteRequests []*GetSuccessorStateRequest) []*GetSuccessorStateResponse {
+	return sendConcreteBatch[*GetSuccessorStateResponse](engine, concreteRequests)
+}
+
+// Send a batch of requests of a single kind, see SendBatch().
+func (engine *GameEngine) SendGetStateEncodingBatch(concreteRequests []*GetStateEncodingRequest) []*GetStateEncodingResponse {
+	return sendConcreteBatch[*GetStateEncodingResponse](engine, concreteRequests)
+}
+
+// Send a batch of requests of a single kind, see SendBatch().
+func (engine *GameEngine) SendGetLegalActionMaskBatch(concreteRequests []*GetLegalActionMaskRequest) []*GetLegalActionMaskResponse {
+	return sendConcreteBatch[*GetLegalActionMaskResponse](engine, concreteRequests)
+}
+
+// Send a batch of requests of a single kind, see SendBatch().
+func (engine *GameEngine) SendGetChanceExpansionBatch(
+	concreteRequests []*GetChanceExpansionRequest,
+) []*GetChanceExpansionResponse {
+	return sendConcreteBatch[*GetChanceExpansionResponse](engine, concreteRequests)
+}
+
+// Send a batch of requests of a single kind, see SendBatch().
+func (engine *GameEngine) SendChooseMoveBatch(concreteRequests []*ChooseMoveRequest) []*ChooseMoveResponse {
+	return sendConcreteBatch[*ChooseMoveResponse](engine, concreteRequests)
 }
 
 // API for handling the sent requests using background workers.
@@ -400,61 +642,104 @@ func (engine *GameEngine) SendGetMidGameScoreBatch(concreteRequests []*GetMidGam
 // Concurrent calls to this function can be made but no more than
 // one request for a *single* game (ID) can be performed at the same time
 // to avoid concurrent writes by the workers on different threads.
-// You will receive `ErrGameNotFound` error, if you try doing so.
+// You will receive `ErrLockAlreadyAcquired` error, if you try doing so,
+// unless the ordered locking mode is enabled (see SetOrderedLocking()).
 func (engine *GameEngine) sendBatch(requests []Request) (responses []Response) {
-	batch := newRequestBatch(engine, requests)
+	batch := newRequestBatch(engine, requests, nil)
 	batch.Process()
 	return batch.responses
 }
 
 // Prepare the input that will be sent through engine's input buffer
-// to a worker. This mutates engine's games map (deleting the game from
-// the request) and updates the next free request ID.
-// If this function did not return an error, the caller needs to readd
-// the game from the request after the worker is done with it.
+// to a worker. This acquires the lock of the game from the request
+// and updates the next free request ID.
+// If this function did not return an error, the caller needs to release
+// the lock of the game after the worker is done with it.
+//
+// In the ordered locking mode, the input gets queued, if the lock cannot be acquired
+// right away, in which case false is returned. The queued input is sent by whoever
+// releases the lock for it, the caller only needs to account for it in the wait group.
+//
+// The caller has to hold the engine's mutex.
 func (engine *GameEngine) prepareWorkerInput(
+	ctx context.Context,
+	deadline time.Time,
 	waitGroup *sync.WaitGroup,
 	outputBuffer chan workerOutput,
 	req Request,
-) (workerInput, error) {
+) (*workerInput, bool, error) {
 	if engine.comm.closed {
-		return workerInput{}, ErrCommunicatorClosed
+		return nil, false, ErrCommunicatorClosed
 	}
 	gameID := req.gameID()
 	game, ok := engine.games[gameID]
 	if !ok {
-		return workerInput{}, fmt.Errorf("%w: %#v", ErrGameNotFound, gameID)
-	}
-	mutex := engine.gameMutexes[gameID]
-	canWrite := req.requiresWrite()
-	if canWrite {
-		if !mutex.TryLock() {
-			return workerInput{}, ErrLockAlreadyAcquired
-		}
-	} else if !mutex.TryRLock() {
-		return workerInput{}, ErrLockAlreadyAcquired
+		return nil, false, fmt.Errorf("%w: %#v", ErrGameNotFound, gameID)
 	}
 
-	requestID := engine.nextRequestID
-	engine.nextRequestID++
-	return workerInput{
-		requestID:    requestID,
+	input := &workerInput{
+		ctx:          ctx,
+		deadline:     deadline,
 		waitGroup:    waitGroup,
 		outputBuffer: outputBuffer,
 		game:         game,
+		gameLock:     engine.gameLocks[gameID],
+		stateCache:   engine.stateCache,
+		helpers:      engine.helpers,
 		request:      req,
-		canWrite:     canWrite,
-	}, nil
+		canWrite:     req.requiresWrite(),
+	}
+	acquired := true
+	if engine.orderedLocking {
+		acquired = input.gameLock.acquireOrEnqueue(input)
+	} else if !input.gameLock.tryAcquire(input) {
+		return nil, false, ErrLockAlreadyAcquired
+	}
+
+	input.requestID = engine.nextRequestID
+	engine.nextRequestID++
+	return input, acquired, nil
 }
 
-func (engine *GameEngine) send(input workerInput) {
+func (engine *GameEngine) send(input *workerInput) {
 	input.waitGroup.Add(1)
 	engine.comm.inputBuffer <- input
 }
 
+// Release the game lock held by the request with the given output
+// and send the queued inputs that acquired it as the result.
+func (engine *GameEngine) releaseGameLock(outputInfo outputItemInfo) {
+	acquired := outputInfo.GameLock.release(outputInfo.AcquiredWrite)
+	if len(acquired) == 0 {
+		return
+	}
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	for _, queuedInput := range acquired {
+		if engine.comm.closed {
+			// the lock gets released once the batch of the input receives this output
+			queuedInput.outputBuffer <- workerOutput{
+				requestID: queuedInput.requestID,
+				resp: &SyncResponse{BaseResponse{
+					gameID: queuedInput.request.gameID(), err: ErrCommunicatorClosed,
+				}},
+			}
+			queuedInput.waitGroup.Done()
+			continue
+		}
+		engine.comm.inputBuffer <- queuedInput
+	}
+}
+
 type requestBatch struct {
-	engine                       *GameEngine
-	requests                     []Request
+	engine   *GameEngine
+	requests []Request
+	// maximum time each of the requests can wait for a worker, nil or 0 means no limit
+	timeouts []time.Duration
+	// cancelled by cancel() or once all of the requests are done
+	ctx                          context.Context
+	cancelCtx                    context.CancelFunc
 	games                        map[int]*game.Game
 	removableGames               map[int]struct{}
 	parentsWithRemovableChildren map[int]struct{}
@@ -463,12 +748,18 @@ type requestBatch struct {
 	waitGroup                    sync.WaitGroup
 	outputWaitGroup              sync.WaitGroup
 	panicErr                     ExecutionPanicError
+	// panics can be recovered concurrently in the caller's goroutine and the output goroutine
+	panicMutex sync.Mutex
 }
 
-func newRequestBatch(engine *GameEngine, requests []Request) requestBatch {
-	return requestBatch{
+func newRequestBatch(engine *GameEngine, requests []Request, timeouts []time.Duration) *requestBatch {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &requestBatch{
 		engine:                       engine,
 		requests:                     requests,
+		timeouts:                     timeouts,
+		ctx:                          ctx,
+		cancelCtx:                    cancel,
 		games:                        map[int]*game.Game{},
 		removableGames:               map[int]struct{}{},
 		parentsWithRemovableChildren: map[int]struct{}{},
@@ -477,11 +768,22 @@ func newRequestBatch(engine *GameEngine, requests []Request) requestBatch {
 	}
 }
 
+// Send the requests and wait for them to finish.
 func (batch *requestBatch) Process() {
+	batch.start()
+	batch.wait()
+	batch.cleanupGames()
+}
+
+// Send the requests to the workers without waiting for them to finish.
+func (batch *requestBatch) start() {
 	outputItems := map[int]outputItemInfo{}
 	outputItemsLock := sync.RWMutex{}
+	submitTime := time.Now()
 
-	defer batch.recoverWithCleanup()
+	defer func() {
+		batch.recover(recover())
+	}()
 
 	batch.outputWaitGroup.Add(1)
 	go func() {
@@ -498,11 +800,9 @@ func (batch *requestBatch) Process() {
 			outputItemsLock.RUnlock()
 			batch.responses[outputInfo.RequestIndex] = output.resp
 
-			if outputInfo.AcquiredWrite {
-				batch.engine.gameMutexes[outputInfo.GameID].Unlock()
-			} else {
-				batch.engine.gameMutexes[outputInfo.GameID].RUnlock()
-			}
+			// the lock is not looked up in the engine's map as the caller
+			// can modify it, if it does not wait for the batch
+			batch.engine.releaseGameLock(outputInfo)
 
 			if respGameRemovable, ok := output.resp.(ResponseGameRemovable); ok {
 				if respGameRemovable.canRemoveGame() {
@@ -518,9 +818,19 @@ func (batch *requestBatch) Process() {
 		}
 	}()
 
+	// the engine's mutex is held while sending to ensure that the engine
+	// does not get closed in the meantime
+	batch.engine.mutex.Lock()
+	defer batch.engine.mutex.Unlock()
 	for i, req := range batch.requests {
 		gameID := req.gameID()
-		input, err := batch.engine.prepareWorkerInput(&batch.waitGroup, batch.outputBuffer, req)
+		var deadline time.Time
+		if i < len(batch.timeouts) && batch.timeouts[i] > 0 {
+			deadline = submitTime.Add(batch.timeouts[i])
+		}
+		input, acquired, err := batch.engine.prepareWorkerInput(
+			batch.ctx, deadline, &batch.waitGroup, batch.outputBuffer, req,
+		)
 		if err != nil {
 			batch.responses[i] = &SyncResponse{BaseResponse{gameID: gameID, err: err}}
 			continue
@@ -528,22 +838,59 @@ func (batch *requestBatch) Process() {
 		batch.games[gameID] = input.game
 		outputItemsLock.Lock()
 		outputItems[input.requestID] = outputItemInfo{
-			GameID: gameID, RequestIndex: i, AcquiredWrite: input.canWrite,
+			GameID:        gameID,
+			GameLock:      input.gameLock,
+			RequestIndex:  i,
+			AcquiredWrite: input.canWrite,
 		}
 		outputItemsLock.Unlock()
-		batch.engine.send(input)
+		if acquired {
+			batch.engine.send(input)
+		} else {
+			// queued input is sent once it acquires the lock
+			batch.waitGroup.Add(1)
+		}
+	}
+}
+
+// Cancel the requests that did not reach a worker yet.
+func (batch *requestBatch) cancel() {
+	batch.cancelCtx()
+}
+
+// Wait for all of the sent requests to finish.
+func (batch *requestBatch) wait() {
+	// Wait for all workers request to finish running on the workers.
+	batch.waitGroup.Wait()
+	// Close the output buffer to let the response-handling goroutine know
+	// that there will be no more requests and wait for it to finish.
+	close(batch.outputBuffer)
+	batch.outputWaitGroup.Wait()
+
+	batch.cancelCtx()
+
+	if len(batch.panicErr.panicValues) != 0 {
+		for i, req := range batch.requests {
+			gameID := req.gameID()
+			batch.responses[i] = &SyncResponse{
+				BaseResponse{gameID: gameID, err: &batch.panicErr},
+			}
+		}
 	}
 }
 
 func (batch *requestBatch) cleanupGames() {
+	batch.engine.mutex.Lock()
+	defer batch.engine.mutex.Unlock()
 	// remove games for which we got information that we can remove them
 	for gameID := range batch.games {
 		_, canRemove := batch.removableGames[gameID]
 		_, canRemoveChildren := batch.parentsWithRemovableChildren[gameID]
 
 		if canRemove {
+			batch.engine.stateCache.removeGame(gameID)
 			delete(batch.engine.games, gameID)
-			delete(batch.engine.gameMutexes, gameID)
+			delete(batch.engine.gameLocks, gameID)
 			delete(batch.engine.childGames, gameID)
 			parentID := batch.engine.parentGames[gameID]
 			if parentID != 0 {
@@ -557,28 +904,9 @@ func (batch *requestBatch) cleanupGames() {
 
 func (batch *requestBatch) recover(panicValue any) {
 	if panicValue != nil {
+		batch.panicMutex.Lock()
+		defer batch.panicMutex.Unlock()
 		batch.panicErr.panicValues = append(batch.panicErr.panicValues, panicValue)
 		batch.panicErr.stacks = append(batch.panicErr.stacks, debug.Stack())
 	}
 }
-
-func (batch *requestBatch) recoverWithCleanup() {
-	// Wait for all workers request to finish running on the workers.
-	batch.waitGroup.Wait()
-	// Close the output buffer to let the response-handling goroutine know
-	// that there will be no more requests and wait for it to finish.
-	close(batch.outputBuffer)
-	batch.outputWaitGroup.Wait()
-
-	batch.recover(recover())
-
-	if len(batch.panicErr.panicValues) != 0 {
-		for i, req := range batch.requests {
-			gameID := req.gameID()
-			batch.responses[i] = &SyncResponse{
-				BaseResponse{gameID: gameID, err: &batch.panicErr},
-			}
-		}
-	}
-	batch.cleanupGames()
-}
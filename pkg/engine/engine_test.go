@@ -3,11 +3,18 @@ package engine
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/binarytiles"
@@ -58,7 +65,7 @@ func TestFullGame(t *testing.T) {
 	}
 	tileSet := tilesets.StandardTileSet()
 
-	gameWithID, err := engine.GenerateOrderedGame(tileSet)
+	gameWithID, err := engine.GenerateOrderedGame(tileSet, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -120,7 +127,7 @@ func TestConcurrentReadRequests(t *testing.T) {
 	}
 	tileSet := tilesets.StandardTileSet()
 
-	gameWithID, err := engine.GenerateGame(tileSet)
+	gameWithID, err := engine.GenerateGame(tileSet, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -152,6 +159,232 @@ func TestConcurrentReadRequests(t *testing.T) {
 	}
 }
 
+// Uses the engine from many goroutines at once, meant to be run with the race detector.
+func TestGameEngineIsSafeForConcurrentUse(t *testing.T) {
+	engine, err := StartGameEngine(4, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+	tileSet := tilesets.StandardTileSet()
+
+	waitGroup := sync.WaitGroup{}
+	for range 8 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for range 3 {
+				gameWithID, err := engine.GenerateGame(tileSet, 2, rules.Standard())
+				if err != nil {
+					t.Error(err.Error())
+					return
+				}
+				gameID := gameWithID.ID
+
+				cloneIDs, err := engine.CloneGame(gameID, 2)
+				if err != nil {
+					t.Error(err.Error())
+					return
+				}
+				childIDs, err := engine.SubCloneGame(gameID, 2)
+				if err != nil {
+					t.Error(err.Error())
+					return
+				}
+
+				batchRequests := []*BatchRequest{}
+				for _, id := range append(cloneIDs, childIDs...) {
+					batchRequests = append(batchRequests, &BatchRequest{
+						GetLegalMoves: &GetLegalMovesRequest{
+							BaseGameID: id, TileToPlace: gameWithID.Game.CurrentTile,
+						},
+					})
+				}
+				// read-locked requests executed concurrently on the same game
+				batchRequests = append(
+					batchRequests,
+					&BatchRequest{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: gameID}},
+					&BatchRequest{GetChanceExpansion: &GetChanceExpansionRequest{BaseGameID: gameID}},
+					&BatchRequest{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: gameID}},
+				)
+				responses := engine.SendBatch(batchRequests)
+				for _, resp := range responses {
+					if resp.Err() != nil {
+						t.Error(resp.Err().Error())
+						return
+					}
+				}
+
+				engine.DeleteGames(childIDs)
+				move := responses[0].GetLegalMoves.Moves[0].Move
+				pending := engine.SubmitBatch([]*BatchRequest{
+					{PlayTurn: &PlayTurnRequest{GameID: gameID, Move: move}},
+					{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: cloneIDs[0]}},
+				})
+				for _, resp := range pending.Responses() {
+					if resp.Err() != nil {
+						t.Error(resp.Err().Error())
+						return
+					}
+				}
+
+				engine.DeleteGames(append(cloneIDs, gameID))
+			}
+		}()
+	}
+
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+		for range 100 {
+			if engine.IsClosed() {
+				t.Error("expected engine to be open")
+				return
+			}
+		}
+	}()
+	waitGroup.Wait()
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	if len(engine.games) != 0 {
+		t.Fatalf("expected %#v, got %#v instead", 0, len(engine.games))
+	}
+}
+
+// Returns a request recording its name in the given slice once it gets executed.
+func newRecordingRequest(
+	gameID int, requiresWrite bool, name string, mutex *sync.Mutex, executed *[]string,
+) *testRequest {
+	return &testRequest{
+		GameID:        gameID,
+		RequiresWrite: requiresWrite,
+		executeFunc: func(req *testRequest, _ *game.Game) Response {
+			mutex.Lock()
+			defer mutex.Unlock()
+			*executed = append(*executed, name)
+			return &testResponse{BaseResponse{gameID: req.gameID()}}
+		},
+	}
+}
+
+func TestGameEngineOrderedLockingExecutesRequestsInSubmissionOrder(t *testing.T) {
+	engine, err := StartGameEngine(4, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+	engine.SetOrderedLocking(true)
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	mutex := sync.Mutex{}
+	executed := []string{}
+	responses := engine.sendBatch([]Request{
+		newRecordingRequest(g.ID, false, "read1", &mutex, &executed),
+		newRecordingRequest(g.ID, false, "read2", &mutex, &executed),
+		newRecordingRequest(g.ID, true, "write1", &mutex, &executed),
+		newRecordingRequest(g.ID, false, "read3", &mutex, &executed),
+		newRecordingRequest(g.ID, true, "write2", &mutex, &executed),
+	})
+
+	for _, resp := range responses {
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+	}
+	// the reads that are not separated by a write can be executed in any order
+	if executed[0] == "read2" {
+		executed[0], executed[1] = executed[1], executed[0]
+	}
+	expected := []string{"read1", "read2", "write1", "read3", "write2"}
+	if !reflect.DeepEqual(executed, expected) {
+		t.Fatalf("expected %#v, got %#v instead", expected, executed)
+	}
+}
+
+func TestGameEngineOrderedLockingQueuesRequestsAcrossBatches(t *testing.T) {
+	engine, err := StartGameEngine(2, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+	engine.SetOrderedLocking(true)
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	blockingBatch := newRequestBatch(engine, []Request{&testRequest{
+		GameID:        g.ID,
+		RequiresWrite: true,
+		executeFunc: func(req *testRequest, _ *game.Game) Response {
+			close(started)
+			<-unblock
+			return &testResponse{BaseResponse{gameID: req.gameID()}}
+		},
+	}}, nil)
+	blockingBatch.start()
+	<-started
+
+	pending := engine.SubmitBatch([]*BatchRequest{
+		{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: g.ID}},
+	})
+	if pending.Wait(50 * time.Millisecond) {
+		t.Fatal("expected request to wait for the lock")
+	}
+
+	close(unblock)
+	blockingBatch.wait()
+	blockingBatch.cleanupGames()
+	if blockingBatch.responses[0].Err() != nil {
+		t.Fatal(blockingBatch.responses[0].Err().Error())
+	}
+	resp := pending.Responses()[0]
+	if resp.Err() != nil {
+		t.Fatal(resp.Err().Error())
+	}
+}
+
+func TestGameEngineSendBatchReturnsLockErrorWithoutOrderedLocking(t *testing.T) {
+	engine, err := StartGameEngine(2, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	unblock := make(chan struct{})
+	batch := newRequestBatch(engine, []Request{
+		&testRequest{
+			GameID: g.ID,
+			executeFunc: func(req *testRequest, _ *game.Game) Response {
+				<-unblock
+				return &testResponse{BaseResponse{gameID: req.gameID()}}
+			},
+		},
+		&testRequest{GameID: g.ID, RequiresWrite: true},
+	}, nil)
+	batch.start()
+	close(unblock)
+	batch.wait()
+	batch.cleanupGames()
+	responses := batch.responses
+	if responses[0].Err() != nil {
+		t.Fatal(responses[0].Err().Error())
+	}
+	if !errors.Is(responses[1].Err(), ErrLockAlreadyAcquired) {
+		t.Fatalf("expected %#v, got %#v instead", ErrLockAlreadyAcquired, responses[1].Err())
+	}
+}
+
 func TestGameEngineDoubleCloseDoesNotPanic(t *testing.T) {
 	engine, err := StartGameEngine(4, t.TempDir())
 	if err != nil {
@@ -170,7 +403,7 @@ func TestGameEngineCloneGameReturnsIndependentGames(t *testing.T) {
 	buf := bytes.Buffer{}
 	engine.appLogger.SetOutput(&buf)
 
-	g, err := engine.GenerateGame(tilesets.StandardTileSet())
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -200,7 +433,7 @@ func TestGameEngineSendPlayTurnBatchDoesNotWarnAboutRemovedChildren(t *testing.T
 	buf := bytes.Buffer{}
 	engine.appLogger.SetOutput(&buf)
 
-	g, err := engine.GenerateGame(tilesets.StandardTileSet())
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -240,6 +473,8 @@ func TestGameEngineSendPlayTurnBatchRemovesFinishedGames(t *testing.T) {
 			StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
 			Tiles:        []tiles.Tile{},
 		},
+		2,
+		rules.Standard(),
 	)
 	if err != nil {
 		t.Fatal(err.Error())
@@ -278,7 +513,7 @@ func TestGameEngineSendBatchReceivesCorrectResponsesAfterWorkerRequests(t *testi
 	requestCount := 100
 	requests := make([]Request, 0, requestCount)
 	for range requestCount {
-		g, err := engine.GenerateGame(tilesets.StandardTileSet())
+		g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 		if err != nil {
 			t.Fatal(err.Error())
 		}
@@ -309,7 +544,7 @@ func TestGameEngineSendBatchReturnsFailureWhenGameIDNotFound(t *testing.T) {
 	}
 
 	requests := make([]Request, 0, 2)
-	g, err := engine.GenerateGame(tilesets.StandardTileSet())
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -358,7 +593,7 @@ func TestGameEngineSendBatchReturnsFailuresWhenCommunicatorClosed(t *testing.T)
 	requestCount := 5
 	requests := make([]Request, 0, requestCount)
 	for range requestCount {
-		g, err := engine.GenerateGame(tilesets.StandardTileSet())
+		g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 		if err != nil {
 			t.Fatal(err.Error())
 		}
@@ -394,7 +629,7 @@ func TestGameEngineSendBatchReturnsExecutionPanicErrorOnPanicInWorker(t *testing
 	requestCount := 5
 	requests := make([]Request, 0, requestCount)
 	for range requestCount {
-		g, err := engine.GenerateGame(tilesets.StandardTileSet())
+		g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 		if err != nil {
 			t.Fatal(err.Error())
 		}
@@ -439,7 +674,7 @@ func TestGameEngineSendBatchReturnsExecutionPanicErrorOnPanicInSendBatchChildGor
 	requestCount := 5
 	requests := make([]Request, 0, requestCount)
 	for range requestCount {
-		g, err := engine.GenerateGame(tilesets.StandardTileSet())
+		g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 		if err != nil {
 			t.Fatal(err.Error())
 		}
@@ -501,7 +736,7 @@ func TestGameEngineSendBatchReturnsExecutionPanicErrorOnPanicInMainThread(t *tes
 	requestCount := 5
 	requests := make([]Request, 0, requestCount)
 	for range requestCount {
-		g, err := engine.GenerateGame(tilesets.StandardTileSet())
+		g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 		if err != nil {
 			t.Fatal(err.Error())
 		}
@@ -539,7 +774,7 @@ func TestGameEngineSendBatchReturnsExecutionPanicErrorOnPanicEverywhere(t *testi
 	requestCount := 5
 	requests := make([]Request, 0, requestCount)
 	for range requestCount {
-		g, err := engine.GenerateGame(tilesets.StandardTileSet())
+		g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 		if err != nil {
 			t.Fatal(err.Error())
 		}
@@ -601,7 +836,7 @@ func TestGenerateSeededGame(t *testing.T) {
 
 	deckStack := stack.NewSeeded(tilesets.StandardTileSet().Tiles, seed)
 
-	serializedGameWithID, err := eng.GenerateSeededGame(tilesets.StandardTileSet(), seed)
+	serializedGameWithID, err := eng.GenerateSeededGame(tilesets.StandardTileSet(), seed, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -638,3 +873,293 @@ func TestGenerateSeededGame(t *testing.T) {
 		serializedGame = playTurnResp.Game
 	}
 }
+
+func TestGameEngineGenerateGameWithSixPlayers(t *testing.T) {
+	engine, err := StartGameEngine(4, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	tileSet := tilesets.StandardTileSet()
+	gameWithID, err := engine.GenerateOrderedGame(tileSet, 6, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	game, gameID := gameWithID.Game, gameWithID.ID
+
+	if game.PlayerCount != 6 {
+		t.Fatalf("expected 6 players, got %v instead", game.PlayerCount)
+	}
+
+	// play a couple of rounds with meeples to ensure that binary tiles
+	// can be serialized for all of the players
+	for range 12 {
+		legalMovesReq := &GetLegalMovesRequest{
+			BaseGameID: gameID, TileToPlace: game.CurrentTile,
+		}
+		legalMovesResp := engine.SendGetLegalMovesBatch(
+			[]*GetLegalMovesRequest{legalMovesReq},
+		)[0]
+		if legalMovesResp.Err() != nil {
+			t.Fatal(legalMovesResp.Err().Error())
+		}
+
+		move := legalMovesResp.Moves[len(legalMovesResp.Moves)-1].Move
+		playTurnReq := &PlayTurnRequest{GameID: gameID, Move: move}
+		playTurnResp := engine.SendPlayTurnBatch([]*PlayTurnRequest{playTurnReq})[0]
+		if playTurnResp.Err() != nil {
+			t.Fatal(playTurnResp.Err().Error())
+		}
+		game = playTurnResp.Game
+	}
+
+	if game.CurrentPlayerID != 1 {
+		t.Fatalf("expected player 1 to move after two rounds, got %v instead", game.CurrentPlayerID)
+	}
+}
+
+func TestGameEngineGenerateGameReturnsErrorForInvalidPlayerCount(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	for _, playerCount := range []int{0, 1, MaxPlayerCount + 1} {
+		_, err := engine.GenerateGame(tilesets.StandardTileSet(), playerCount, rules.Standard())
+		if !errors.Is(err, ErrInvalidPlayerCount) {
+			t.Fatalf("expected ErrInvalidPlayerCount for %v players, got %#v", playerCount, err)
+		}
+	}
+}
+
+func TestGameEngineLoadGameFromLogRestoresPlayedGame(t *testing.T) {
+	logDir := t.TempDir()
+	engine, err := StartGameEngine(1, logDir)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateSeededGame(tilesets.StandardTileSet(), 7, 3, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := g.Game
+	for range 5 {
+		req := &PlayTurnRequest{GameID: g.ID, Move: expected.ValidTilePlacements[0]}
+		resp := engine.SendPlayTurnBatch([]*PlayTurnRequest{req})[0]
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+		expected = resp.Game
+	}
+
+	loaded, err := engine.LoadGameFromLog(path.Join(logDir, fmt.Sprintf("%v.jsonl", g.ID)))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if loaded.ID == g.ID {
+		t.Fatalf("expected the loaded game to get a new ID, got %v instead", loaded.ID)
+	}
+
+	actual := loaded.Game
+	if actual.CurrentPlayerID != expected.CurrentPlayerID {
+		t.Fatalf("expected %v, got %v instead", expected.CurrentPlayerID, actual.CurrentPlayerID)
+	}
+	if !reflect.DeepEqual(actual.Players, expected.Players) {
+		t.Fatalf("expected %#v, got %#v instead", expected.Players, actual.Players)
+	}
+	if !actual.CurrentTile.ExactEquals(expected.CurrentTile) {
+		t.Fatalf("expected %#v, got %#v instead", expected.CurrentTile, actual.CurrentTile)
+	}
+	if len(actual.Tiles) != len(expected.Tiles) {
+		t.Fatalf("expected %v placed tiles, got %v instead", len(expected.Tiles), len(actual.Tiles))
+	}
+
+	// the loaded game's log holds the replayed events
+	expectedLog, err := os.ReadFile(path.Join(logDir, fmt.Sprintf("%v.jsonl", g.ID)))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	actualLog, err := os.ReadFile(path.Join(logDir, fmt.Sprintf("%v.jsonl", loaded.ID)))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !bytes.Equal(actualLog, expectedLog) {
+		t.Fatalf("expected %#v, got %#v instead", string(expectedLog), string(actualLog))
+	}
+}
+
+func TestGameEngineLoadGameFromLogReturnsErrorForMalformedLog(t *testing.T) {
+	logDir := t.TempDir()
+	engine, err := StartGameEngine(1, logDir)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	// the first one cannot be parsed, the second one cannot be replayed
+	for i, content := range []string{"not a log", ""} {
+		logPath := path.Join(logDir, fmt.Sprintf("malformed-%v.jsonl", i))
+		if err := os.WriteFile(logPath, []byte(content), 0600); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		_, err = engine.LoadGameFromLog(logPath)
+		if !errors.Is(err, game.ErrInvalidLog) {
+			t.Fatalf("expected ErrInvalidLog, got %#v instead", err)
+		}
+	}
+
+	// no log files are created for the games that failed to load
+	logFiles, err := os.ReadDir(logDir)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(logFiles) != 2 {
+		t.Fatalf("expected only the malformed logs, got %#v instead", logFiles)
+	}
+}
+
+func TestGameEngineLoadGameRestoresSavedGame(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateSeededGame(tilesets.StandardTileSet(), 7, 3, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := g.Game
+	for range 5 {
+		req := &PlayTurnRequest{GameID: g.ID, Move: expected.ValidTilePlacements[0]}
+		resp := engine.SendPlayTurnBatch([]*PlayTurnRequest{req})[0]
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+		expected = resp.Game
+	}
+
+	savePath := path.Join(t.TempDir(), "game.bin")
+	if err = engine.SaveGame(g.ID, savePath); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	loaded, err := engine.LoadGame(savePath)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if loaded.ID == g.ID {
+		t.Fatalf("expected the loaded game to get a new ID, got %v instead", loaded.ID)
+	}
+	if !reflect.DeepEqual(loaded.Game, expected) {
+		t.Fatalf("expected %#v, got %#v instead", expected, loaded.Game)
+	}
+}
+
+func TestGameEngineLoadGameReturnsErrorForInvalidSnapshot(t *testing.T) {
+	logDir := t.TempDir()
+	engine, err := StartGameEngine(1, logDir)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	savePath := path.Join(t.TempDir(), "game.bin")
+	if err := os.WriteFile(savePath, []byte("not a snapshot"), 0600); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	_, err = engine.LoadGame(savePath)
+	if !errors.Is(err, game.ErrInvalidSnapshot) {
+		t.Fatalf("expected ErrInvalidSnapshot, got %#v instead", err)
+	}
+
+	// no log file is created for the game that failed to load
+	logFiles, err := os.ReadDir(logDir)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(logFiles) != 0 {
+		t.Fatalf("expected no log files, got %#v instead", logFiles)
+	}
+}
+
+func TestGameEngineSaveGameReturnsErrorWhenGameNotFound(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	err = engine.SaveGame(1, path.Join(t.TempDir(), "game.bin"))
+	if !errors.Is(err, ErrGameNotFound) {
+		t.Fatalf("expected ErrGameNotFound, got %#v instead", err)
+	}
+}
+
+type countingEvaluator struct {
+	batchSizes []int
+}
+
+func (evaluator *countingEvaluator) Evaluate(leaves []agents.SearchLeaf) ([]agents.SearchEvaluation, error) {
+	evaluator.batchSizes = append(evaluator.batchSizes, len(leaves))
+	evaluations := make([]agents.SearchEvaluation, len(leaves))
+	for i, leaf := range leaves {
+		evaluations[i] = agents.SearchEvaluation{
+			Priors: make([]float32, len(leaf.LegalMoves)),
+			Values: []float32{0.5, 0.5},
+		}
+	}
+	return evaluations, nil
+}
+
+func TestGameEnginePUCTSearchBatchesLeavesOfAllGames(t *testing.T) {
+	engine, err := StartGameEngine(4, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	gameIDs := []int{}
+	for seed := range int64(2) {
+		game, err := engine.GenerateSeededGame(tilesets.StandardTileSet(), seed, 2, rules.Standard())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		gameIDs = append(gameIDs, game.ID)
+	}
+
+	config := agents.DefaultPUCTConfig()
+	config.Simulations = 10
+	evaluator := &countingEvaluator{}
+	results, err := engine.PUCTSearch(gameIDs, evaluator, config, 1)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(evaluator.batchSizes) == 0 || evaluator.batchSizes[0] != len(gameIDs) {
+		t.Fatalf("expected first batch with %#v leaves, got %#v instead", len(gameIDs), evaluator.batchSizes)
+	}
+
+	for i, result := range results {
+		bestMove := 0
+		for moveIndex, visits := range result.Visits {
+			if visits > result.Visits[bestMove] {
+				bestMove = moveIndex
+			}
+		}
+		req := &PlayTurnRequest{GameID: gameIDs[i], Move: result.LegalMoves[bestMove]}
+		if resp := engine.SendPlayTurnBatch([]*PlayTurnRequest{req})[0]; resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+	}
+
+	if _, err := engine.StartPUCTSearch([]int{123}, config, 1); err == nil {
+		t.Fatal("expected error to occur")
+	}
+}
@@ -0,0 +1,76 @@
+package engine
+
+import "sync"
+
+// Lock of a single game held by the requests sent to the workers,
+// allowing either any number of reading requests or a single writing request.
+//
+// The requests that cannot acquire the lock right away either fail or,
+// in the ordered locking mode, wait in a queue and acquire the lock
+// in the order of their submission.
+type gameLock struct {
+	mutex   sync.Mutex
+	readers int
+	writing bool
+	// inputs waiting for the lock, in the order of their submission
+	queue []*workerInput
+}
+
+// Acquire the lock for the given input. Returns false, if it cannot be acquired right away.
+//
+// The lock is never acquired, if there are queued inputs,
+// so that they do not wait for the inputs submitted after them.
+func (lock *gameLock) tryAcquire(input *workerInput) bool {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+	return len(lock.queue) == 0 && lock.acquireLocked(input.canWrite)
+}
+
+// Acquire the lock for the given input or add the input to the queue, if it cannot
+// be acquired right away. Returns false, if the input has been queued.
+func (lock *gameLock) acquireOrEnqueue(input *workerInput) bool {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+	if len(lock.queue) == 0 && lock.acquireLocked(input.canWrite) {
+		return true
+	}
+	lock.queue = append(lock.queue, input)
+	return false
+}
+
+// Release the lock acquired by an input with the given access.
+//
+// Returns the queued inputs that acquired the lock as the result,
+// these need to be sent to the workers by the caller.
+func (lock *gameLock) release(write bool) []*workerInput {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+	if write {
+		lock.writing = false
+	} else {
+		lock.readers--
+	}
+
+	acquired := []*workerInput{}
+	for len(lock.queue) != 0 && lock.acquireLocked(lock.queue[0].canWrite) {
+		acquired = append(acquired, lock.queue[0])
+		lock.queue = lock.queue[1:]
+	}
+	return acquired
+}
+
+// The caller has to hold the lock's mutex.
+func (lock *gameLock) acquireLocked(write bool) bool {
+	if lock.writing {
+		return false
+	}
+	if write {
+		if lock.readers != 0 {
+			return false
+		}
+		lock.writing = true
+		return true
+	}
+	lock.readers++
+	return true
+}
@@ -0,0 +1,37 @@
+package engine
+
+import "sync"
+
+// Limits the number of the additional goroutines started by the requests
+// that split their work (see parallelRequest), shared by all of the engine's workers.
+//
+// Only the helpers that are free at the time are used, the rest of the work
+// is done by the worker itself so that the requests never wait for each other.
+// A nil pool does not have any helpers.
+type helperPool chan struct{}
+
+func newHelperPool(size int) helperPool {
+	return make(helperPool, size)
+}
+
+// Run the tasks, using the free helpers for some of them and running the rest
+// on the calling goroutine. Returns once all of the tasks are done.
+//
+// The tasks run by the helpers must not panic, as there is nothing to recover them.
+func (pool helperPool) run(tasks []func()) {
+	var waitGroup sync.WaitGroup
+	for _, task := range tasks {
+		select {
+		case pool <- struct{}{}:
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				defer func() { <-pool }()
+				task()
+			}()
+		default:
+			task()
+		}
+	}
+	waitGroup.Wait()
+}
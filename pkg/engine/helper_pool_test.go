@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestHelperPoolRunsTasksOnCallerWithoutHelpers(t *testing.T) {
+	done := 0
+	tasks := make([]func(), 10)
+	for i := range tasks {
+		// not synchronised, all of the tasks have to run on the caller
+		tasks[i] = func() { done++ }
+	}
+
+	var pool helperPool
+	pool.run(tasks)
+	if done != len(tasks) {
+		t.Fatalf("expected %#v, got %#v instead", len(tasks), done)
+	}
+}
+
+func TestHelperPoolDoesNotExceedItsSize(t *testing.T) {
+	pool := newHelperPool(2)
+	mutex := sync.Mutex{}
+	running := 0
+	maxRunning := 0
+	done := 0
+	tasks := make([]func(), 50)
+	for i := range tasks {
+		tasks[i] = func() {
+			mutex.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mutex.Unlock()
+
+			mutex.Lock()
+			running--
+			done++
+			mutex.Unlock()
+		}
+	}
+
+	waitGroup := sync.WaitGroup{}
+	for range 4 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			pool.run(tasks)
+		}()
+	}
+	waitGroup.Wait()
+
+	// each of the callers and each of the helpers runs at most one task at a time
+	if maxRunning > 4+cap(pool) {
+		t.Fatalf("expected at most %#v running tasks, got %#v instead", 4+cap(pool), maxRunning)
+	}
+	if done != 4*len(tasks) {
+		t.Fatalf("expected %#v, got %#v instead", 4*len(tasks), done)
+	}
+	if len(pool) != 0 {
+		t.Fatalf("expected all of the helpers to be released, got %#v instead", len(pool))
+	}
+}
@@ -0,0 +1,98 @@
+package engine
+
+import "time"
+
+// Handle to a batch of requests submitted with GameEngine.SubmitBatch().
+// It is safe to use the handle from multiple goroutines.
+type PendingBatch struct {
+	batch *requestBatch
+	// the valid requests (sent in the batch) and their indices in the submitted slice
+	requests  []batchableRequest
+	indices   []int
+	responses []*BatchResponse
+	done      chan struct{}
+}
+
+// Submit a batch of requests of any of the kinds supported by BatchRequest
+// without waiting for the responses, allowing the caller to do other work
+// while the requests are executed by the workers.
+//
+// See SendBatch() for the blocking variant.
+func (engine *GameEngine) SubmitBatch(batchRequests []*BatchRequest) *PendingBatch {
+	pending := &PendingBatch{
+		requests:  make([]batchableRequest, 0, len(batchRequests)),
+		indices:   make([]int, 0, len(batchRequests)),
+		responses: make([]*BatchResponse, len(batchRequests)),
+		done:      make(chan struct{}),
+	}
+	requests := make([]Request, 0, len(batchRequests))
+	timeouts := make([]time.Duration, 0, len(batchRequests))
+	for i, batchReq := range batchRequests {
+		req, err := batchReq.request()
+		if err != nil {
+			pending.responses[i] = &BatchResponse{BaseResponse: BaseResponse{err: err}}
+			continue
+		}
+		requests = append(requests, req)
+		pending.requests = append(pending.requests, req)
+		timeouts = append(timeouts, batchReq.Timeout)
+		pending.indices = append(pending.indices, i)
+	}
+
+	pending.batch = newRequestBatch(engine, requests, timeouts)
+	pending.batch.start()
+	go func() {
+		pending.batch.wait()
+		pending.batch.cleanupGames()
+		for i, resp := range pending.batch.responses {
+			index := pending.indices[i]
+			pending.responses[index] = newBatchResponse(pending.requests[i], resp)
+		}
+		close(pending.done)
+	}()
+	return pending
+}
+
+// Returns true, if all of the requests in the batch are done.
+func (pending *PendingBatch) Done() bool {
+	select {
+	case <-pending.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait for all of the requests in the batch to be done, for at most the given time.
+// Waits without a time limit, if the timeout is not positive.
+//
+// Returns true, if all of the requests are done.
+func (pending *PendingBatch) Wait(timeout time.Duration) bool {
+	if timeout <= 0 {
+		<-pending.done
+		return true
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-pending.done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// Cancel the requests in the batch that did not reach a worker yet,
+// these requests finish with ErrRequestCancelled.
+// The requests that are already being executed are not interrupted.
+func (pending *PendingBatch) Cancel() {
+	pending.batch.cancel()
+}
+
+// Wait for all of the requests in the batch to be done and return their responses.
+// The order of the responses corresponds to the submitted requests.
+func (pending *PendingBatch) Responses() []*BatchResponse {
+	pending.Wait(0)
+	return pending.responses
+}
@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+// agent blocking the worker until the channel is closed
+type blockingAgent struct {
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func newBlockingAgent() *blockingAgent {
+	return &blockingAgent{started: make(chan struct{}), unblock: make(chan struct{})}
+}
+
+func (agent *blockingAgent) ChooseMove(g *game.Game) elements.PlacedTile {
+	close(agent.started)
+	<-agent.unblock
+	return elements.PlacedTile{}
+}
+
+// Starts an engine with a single worker that is blocked by the returned agent
+// and returns the ID of another game that can be used in the requests.
+func startBlockedEngine(t *testing.T) (*GameEngine, *blockingAgent, *PendingBatch, int) {
+	engine, err := StartGameEngine(1, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(engine.Close)
+
+	gameIDs := []int{}
+	for range 2 {
+		game, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		gameIDs = append(gameIDs, game.ID)
+	}
+
+	agent := newBlockingAgent()
+	blocking := engine.SubmitBatch([]*BatchRequest{
+		{ChooseMove: &ChooseMoveRequest{GameID: gameIDs[0], Agent: agent}},
+	})
+	<-agent.started
+	return engine, agent, blocking, gameIDs[1]
+}
+
+func TestGameEngineSubmitBatchReturnsResponsesOnceDone(t *testing.T) {
+	engine, err := StartGameEngine(2, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+	game, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	pending := engine.SubmitBatch([]*BatchRequest{
+		{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: game.ID}},
+		{GetRemainingTiles: &GetRemainingTilesRequest{BaseGameID: game.ID}},
+	})
+	if !pending.Wait(time.Minute) {
+		t.Fatal("expected batch to be done")
+	}
+	if !pending.Done() {
+		t.Fatal("expected batch to be done")
+	}
+
+	for _, resp := range pending.Responses() {
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+	}
+}
+
+func TestGameEngineSubmitBatchCanBeWaitedOnWithTimeout(t *testing.T) {
+	engine, agent, blocking, gameID := startBlockedEngine(t)
+
+	pending := engine.SubmitBatch([]*BatchRequest{
+		{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: gameID}},
+	})
+	if pending.Done() {
+		t.Fatal("expected batch to not be done while the worker is blocked")
+	}
+	if pending.Wait(10 * time.Millisecond) {
+		t.Fatal("expected wait to time out while the worker is blocked")
+	}
+
+	close(agent.unblock)
+	if !pending.Wait(time.Minute) {
+		t.Fatal("expected batch to be done")
+	}
+	if err := pending.Responses()[0].Err(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := blocking.Responses()[0].Err(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestGameEngineSubmitBatchCancelSkipsRequestsNotStartedYet(t *testing.T) {
+	engine, agent, blocking, gameID := startBlockedEngine(t)
+
+	pending := engine.SubmitBatch([]*BatchRequest{
+		{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: gameID}},
+	})
+	pending.Cancel()
+	// cancelling a running request does not interrupt it
+	blocking.Cancel()
+	close(agent.unblock)
+
+	resp := pending.Responses()[0]
+	if !errors.Is(resp.Err(), ErrRequestCancelled) {
+		t.Fatalf("expected %#v, got %#v instead", ErrRequestCancelled, resp.Err())
+	}
+	if resp.GetMidGameScore == nil || !errors.Is(resp.GetMidGameScore.Err(), ErrRequestCancelled) {
+		t.Fatalf("expected mid game score response with error, got %#v instead", resp)
+	}
+	if err := blocking.Responses()[0].Err(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestGameEngineSubmitBatchReturnsDeadlineErrorForRequestsPastTheirTimeout(t *testing.T) {
+	engine, agent, blocking, gameID := startBlockedEngine(t)
+
+	pending := engine.SubmitBatch([]*BatchRequest{
+		{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: gameID}, Timeout: time.Millisecond},
+		{GetRemainingTiles: &GetRemainingTilesRequest{BaseGameID: gameID}},
+	})
+	time.Sleep(20 * time.Millisecond)
+	close(agent.unblock)
+
+	responses := pending.Responses()
+	if !errors.Is(responses[0].Err(), ErrRequestDeadlineExceeded) {
+		t.Fatalf("expected %#v, got %#v instead", ErrRequestDeadlineExceeded, responses[0].Err())
+	}
+	if err := responses[1].Err(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := blocking.Responses()[0].Err(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
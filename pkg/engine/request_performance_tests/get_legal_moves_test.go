@@ -24,3 +24,24 @@ func SendGetLegalMovesRequest(games *[]engine.SerializedGameWithID, eng *engine.
 func BenchmarkGetLegalMovesTest(b *testing.B) {
 	PlayGame(100, SendGetLegalMovesRequest, b)
 }
+
+func SendGetLegalMovesWithoutStatesRequest(games *[]engine.SerializedGameWithID, eng *engine.GameEngine, b *testing.B) {
+	requests := []*engine.GetLegalMovesRequest{}
+	for _, game := range *games {
+		requests = append(requests,
+			&engine.GetLegalMovesRequest{
+				BaseGameID:      game.ID,
+				TileToPlace:     game.Game.CurrentTile,
+				SkipStates:      true,
+				WithScoreDeltas: true,
+			},
+		)
+	}
+	b.StartTimer()
+	eng.SendGetLegalMovesBatch(requests)
+	b.StopTimer()
+}
+
+func BenchmarkGetLegalMovesWithoutStatesTest(b *testing.B) {
+	PlayGame(100, SendGetLegalMovesWithoutStatesRequest, b)
+}
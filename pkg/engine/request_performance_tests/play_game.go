@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
 )
 
@@ -19,7 +20,7 @@ func PlayGame(gameCount int, testedRequest TestedRequest, b *testing.B) {
 
 	var games = []engine.SerializedGameWithID{}
 	for seed := range gameCount {
-		game, err := eng.GenerateSeededGame(tilesets.StandardTileSet(), int64(seed+1000))
+		game, err := eng.GenerateSeededGame(tilesets.StandardTileSet(), int64(seed+1000), 2, rules.Standard())
 		if err != nil {
 			b.Fatal()
 		}
@@ -1,12 +1,18 @@
 package engine
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"path"
+	"runtime/debug"
 	"slices"
 	"sort"
+	"time"
 
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/encoding"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/logger"
@@ -76,10 +82,67 @@ func (req *cloneGameRequest) execute(g *game.Game) Response {
 	return resp
 }
 
+// internal worker request used by GameEngine.SaveGame()
+type saveGameResponse struct {
+	BaseResponse
+}
+type saveGameRequest struct {
+	GameID int
+	Path   string
+}
+
+func (req *saveGameRequest) gameID() int {
+	return req.GameID
+}
+
+func (req *saveGameRequest) requiresWrite() bool {
+	return false
+}
+
+func (req *saveGameRequest) execute(g *game.Game) Response {
+	resp := &saveGameResponse{BaseResponse: BaseResponse{gameID: req.GameID}}
+	data, err := g.MarshalBinary()
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+	resp.err = os.WriteFile(req.Path, data, 0600)
+	return resp
+}
+
+// internal worker request used by GameEngine.StartPUCTSearch()
+type snapshotGameResponse struct {
+	BaseResponse
+	Game *game.Game
+}
+type snapshotGameRequest struct {
+	GameID int
+}
+
+func (req *snapshotGameRequest) gameID() int {
+	return req.GameID
+}
+
+func (req *snapshotGameRequest) requiresWrite() bool {
+	return false
+}
+
+func (req *snapshotGameRequest) execute(g *game.Game) Response {
+	return &snapshotGameResponse{
+		BaseResponse: BaseResponse{gameID: req.GameID},
+		Game:         g.DeepCloneWithSwappableTiles(),
+	}
+}
+
 type PlayTurnResponse struct {
 	BaseResponse
 	Game        game.SerializedGame
 	FinalScores map[elements.ID]uint32
+	// full report of the final scoring (with the points received over the whole game),
+	// only set when FinalScores is
+	FinalScoreReport *elements.ScoreReport
+	// true if the same player moves again (extra turn granted by their builder)
+	ExtraTurn bool
 }
 type PlayTurnRequest struct {
 	GameID int
@@ -103,6 +166,14 @@ func (req *PlayTurnRequest) requiresWrite() bool {
 	return true
 }
 
+func (req *PlayTurnRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{PlayTurn: req}
+}
+
+func (req *PlayTurnRequest) emptyResponse(base BaseResponse) Response {
+	return &PlayTurnResponse{BaseResponse: base}
+}
+
 func (req *PlayTurnRequest) execute(game *game.Game) Response {
 	var err error
 	if game.CanSwapTiles() {
@@ -122,6 +193,7 @@ func (req *PlayTurnRequest) execute(game *game.Game) Response {
 	}
 
 	resp.Game = game.Serialized()
+	resp.ExtraTurn = game.IsExtraTurn()
 
 	scoreReport, err := game.Finalize()
 	if err != nil {
@@ -131,15 +203,60 @@ func (req *PlayTurnRequest) execute(game *game.Game) Response {
 		}
 	} else {
 		resp.FinalScores = scoreReport.ReceivedPoints
+		resp.FinalScoreReport = &scoreReport
 	}
 
 	return resp
 }
 
+type UndoTurnResponse struct {
+	BaseResponse
+	Game game.SerializedGame
+}
+type UndoTurnRequest struct {
+	GameID int
+}
+
+func (resp *UndoTurnResponse) canRemoveChildGames() bool {
+	return resp.Err() == nil
+}
+
+func (req *UndoTurnRequest) gameID() int {
+	return req.GameID
+}
+
+func (req *UndoTurnRequest) requiresWrite() bool {
+	return true
+}
+
+func (req *UndoTurnRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{UndoTurn: req}
+}
+
+func (req *UndoTurnRequest) emptyResponse(base BaseResponse) Response {
+	return &UndoTurnResponse{BaseResponse: base}
+}
+
+func (req *UndoTurnRequest) execute(game *game.Game) Response {
+	resp := &UndoTurnResponse{
+		BaseResponse: BaseResponse{
+			gameID: req.gameID(),
+			err:    game.UndoTurn(),
+		},
+	}
+	if resp.err != nil {
+		return resp
+	}
+
+	resp.Game = game.Serialized()
+	return resp
+}
+
 // State of the game the request is made for.
-// Currently, this is internally represented as a slice of moves to make on the base game
-// which can be resolved to a Game by passing it that game.
-// Eventually this should probably move to some kind of handles to cached state.
+// This is internally represented as a slice of moves to make on the base game
+// and works as a handle to the game resolved from it, which is kept in the engine's
+// cache of states (see GameEngine.ReleaseStates()). If the state is not cached,
+// it is resolved by replaying the moves on the base game.
 type GameState struct {
 	serializedGame game.SerializedGame
 	simulatedMoves []elements.PlacedTile
@@ -149,20 +266,9 @@ func (state *GameState) Serialized() game.SerializedGame {
 	return state.serializedGame
 }
 
-func (state *GameState) resolve(baseGame *game.Game) (*game.Game, error) {
-	if state == nil {
-		return baseGame, nil
-	}
-	game := baseGame.DeepCloneWithSwappableTiles()
-	for _, move := range state.simulatedMoves {
-		if err := game.SwapCurrentTile(elements.ToTile(move)); err != nil {
-			return nil, err
-		}
-		if err := game.PlayTurn(move); err != nil {
-			return nil, err
-		}
-	}
-	return game, nil
+// Returns the moves simulated on the base game to get to this state.
+func (state *GameState) SimulatedMoves() []elements.PlacedTile {
+	return state.simulatedMoves
 }
 
 func (state *GameState) with(
@@ -188,6 +294,32 @@ func (state *GameState) with(
 	}
 }
 
+// JSON representation of GameState, see GameState.MarshalJSON()
+type serializedGameState struct {
+	Game  game.SerializedGame   `json:"game"`
+	Moves []elements.PlacedTile `json:"moves"`
+}
+
+// Encodes the state as a JSON object with the serialized game
+// and the moves simulated on the base game. Only the moves are needed
+// to decode a state that can be used in a request.
+func (state *GameState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(serializedGameState{
+		Game:  state.serializedGame,
+		Moves: state.simulatedMoves,
+	})
+}
+
+func (state *GameState) UnmarshalJSON(data []byte) error {
+	var serialized serializedGameState
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return err
+	}
+	state.serializedGame = serialized.Game
+	state.simulatedMoves = serialized.Moves
+	return nil
+}
+
 // represents a tile and its probability to be drawn from the deck
 type TileProbability struct {
 	Tile        tiles.Tile
@@ -212,14 +344,32 @@ func (req *GetRemainingTilesRequest) requiresWrite() bool {
 	return false
 }
 
+func (req *GetRemainingTilesRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{GetRemainingTiles: req}
+}
+
+func (req *GetRemainingTilesRequest) emptyResponse(base BaseResponse) Response {
+	return &GetRemainingTilesResponse{BaseResponse: base}
+}
+
 func (req *GetRemainingTilesRequest) execute(baseGame *game.Game) Response {
+	return req.executeWithStateCache(baseGame, nil)
+}
+
+func (req *GetRemainingTilesRequest) executeWithStateCache(baseGame *game.Game, cache *stateCache) Response {
 	resp := &GetRemainingTilesResponse{BaseResponse: BaseResponse{gameID: req.gameID()}}
-	game, err := req.StateToCheck.resolve(baseGame)
+	game, err := cache.resolve(req.gameID(), baseGame, req.StateToCheck)
 	if err != nil {
 		resp.err = err
 		return resp
 	}
 
+	resp.TileProbabilities = tileProbabilities(game)
+	return resp
+}
+
+// Returns the distinct tiles that can be drawn in the game with their probabilities.
+func tileProbabilities(game *game.Game) []TileProbability {
 	remaining := game.GetRemainingTiles()
 	total := float32(len(remaining))
 	probabilities := []TileProbability{}
@@ -258,14 +408,23 @@ func (req *GetRemainingTilesRequest) execute(baseGame *game.Game) Response {
 		probabilities[i].Probability /= total
 	}
 
-	resp.TileProbabilities = probabilities
-
-	return resp
+	return probabilities
 }
 
 type MoveWithState struct {
-	Move  elements.PlacedTile
+	Move elements.PlacedTile
+	// nil, if the states were skipped (see GetLegalMovesRequest.SkipStates)
 	State *GameState
+	// points received by each of the players when the move is played,
+	// only set if requested (see GetLegalMovesRequest.WithScoreDeltas)
+	ScoreDelta map[elements.ID]uint32
+}
+
+// Returns true, if the state resulting from the move is set.
+//
+// Python bindings generator cannot represent nil pointers as None, hence this method.
+func (move MoveWithState) HasState() bool {
+	return move.State != nil
 }
 
 type GetLegalMovesResponse struct {
@@ -276,6 +435,12 @@ type GetLegalMovesRequest struct {
 	BaseGameID   int
 	StateToCheck *GameState
 	TileToPlace  tiles.Tile
+	// Skip creating the states resulting from the moves, which requires playing
+	// each of them on a separate copy of the game. The state of the chosen move
+	// can be created later with GetSuccessorStateRequest.
+	SkipStates bool
+	// Return the points received by the players when each of the moves is played.
+	WithScoreDeltas bool
 }
 
 func (req *GetLegalMovesRequest) gameID() int {
@@ -286,35 +451,263 @@ func (req *GetLegalMovesRequest) requiresWrite() bool {
 	return false
 }
 
+func (req *GetLegalMovesRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{GetLegalMoves: req}
+}
+
+func (req *GetLegalMovesRequest) emptyResponse(base BaseResponse) Response {
+	return &GetLegalMovesResponse{BaseResponse: base}
+}
+
 func (req *GetLegalMovesRequest) execute(baseGame *game.Game) Response {
+	return req.executeWithStateCache(baseGame, nil)
+}
+
+func (req *GetLegalMovesRequest) executeWithStateCache(baseGame *game.Game, cache *stateCache) Response {
 	resp := &GetLegalMovesResponse{BaseResponse: BaseResponse{gameID: req.gameID()}}
-	baseGame, err := req.StateToCheck.resolve(baseGame)
+	baseGame, err := cache.resolve(req.gameID(), baseGame, req.StateToCheck)
 	if err != nil {
 		resp.err = err
 		return resp
 	}
 
+	resp.Moves, resp.err = req.legalMoves(baseGame, cache)
+	return resp
+}
+
+// Returns the legal moves in the given game, which has to be already resolved
+// from the request's state.
+func (req *GetLegalMovesRequest) legalMoves(baseGame *game.Game, cache *stateCache) ([]MoveWithState, error) {
 	placements := baseGame.GetTilePlacementsFor(req.TileToPlace)
-	resp.Moves = []MoveWithState{}
+	moves := []MoveWithState{}
+	if req.SkipStates {
+		for _, placement := range placements {
+			for _, move := range baseGame.GetLegalMovesFor(placement) {
+				moves = append(moves, MoveWithState{Move: move})
+			}
+		}
+		if req.WithScoreDeltas {
+			return moves, addScoreDeltas(baseGame, moves)
+		}
+		return moves, nil
+	}
+
+	scores := playerScores(baseGame)
 	for _, placement := range placements {
 		for _, move := range baseGame.GetLegalMovesFor(placement) {
-			game := baseGame.DeepCloneWithSwappableTiles()
-			if err := game.SwapCurrentTile(elements.ToTile(move)); err != nil {
-				resp.err = err
-				return resp
-			}
-			if err := game.PlayTurn(move); err != nil {
-				resp.err = err
-				return resp
+			game, err := playMove(baseGame, move)
+			if err != nil {
+				return moves, err
 			}
+			cache.add(req.gameID(), req.StateToCheck, move, game)
 			moveState := MoveWithState{
 				Move:  move,
 				State: req.StateToCheck.with(game.Serialized(), move),
 			}
-			resp.Moves = append(resp.Moves, moveState)
+			if req.WithScoreDeltas {
+				moveState.ScoreDelta = receivedPoints(game, scores)
+			}
+			moves = append(moves, moveState)
+		}
+	}
+
+	return moves, nil
+}
+
+// Play the move on a clone of the given game, swapping its current tile for the move's.
+func playMove(baseGame *game.Game, move elements.PlacedTile) (*game.Game, error) {
+	game := baseGame.DeepCloneWithSwappableTiles()
+	if err := game.SwapCurrentTile(elements.ToTile(move)); err != nil {
+		return nil, err
+	}
+	if err := game.PlayTurn(move); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// Set the score deltas of the moves by playing and undoing each of them
+// on a single clone of the given game.
+func addScoreDeltas(baseGame *game.Game, moves []MoveWithState) error {
+	game := baseGame.DeepCloneWithSwappableTiles()
+	game.EnableUndo()
+	scores := playerScores(game)
+	for i := range moves {
+		if err := game.SwapCurrentTile(elements.ToTile(moves[i].Move)); err != nil {
+			return err
+		}
+		if err := game.PlayTurn(moves[i].Move); err != nil {
+			return err
+		}
+		moves[i].ScoreDelta = receivedPoints(game, scores)
+		if err := game.UndoTurn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the scores of the players, indexed by player ID - 1.
+func playerScores(game *game.Game) []uint32 {
+	scores := make([]uint32, game.PlayerCount())
+	for i := range scores {
+		scores[i] = game.GetPlayerByID(elements.ID(i + 1)).Score()
+	}
+	return scores
+}
+
+// Returns the points received by each of the players since the given scores were taken.
+func receivedPoints(game *game.Game, previousScores []uint32) map[elements.ID]uint32 {
+	points := make(map[elements.ID]uint32, len(previousScores))
+	for i, previousScore := range previousScores {
+		playerID := elements.ID(i + 1)
+		points[playerID] = game.GetPlayerByID(playerID).Score() - previousScore
+	}
+	return points
+}
+
+type GetSuccessorStateResponse struct {
+	BaseResponse
+	State *GameState
+}
+
+// Request for the state resulting from playing the move in the given state,
+// e.g. the move returned by GetLegalMovesRequest with SkipStates set.
+type GetSuccessorStateRequest struct {
+	BaseGameID   int
+	StateToCheck *GameState
+	Move         elements.PlacedTile
+}
+
+func (req *GetSuccessorStateRequest) gameID() int {
+	return req.BaseGameID
+}
+
+func (req *GetSuccessorStateRequest) requiresWrite() bool {
+	return false
+}
+
+func (req *GetSuccessorStateRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{GetSuccessorState: req}
+}
+
+func (req *GetSuccessorStateRequest) emptyResponse(base BaseResponse) Response {
+	return &GetSuccessorStateResponse{BaseResponse: base}
+}
+
+func (req *GetSuccessorStateRequest) execute(baseGame *game.Game) Response {
+	return req.executeWithStateCache(baseGame, nil)
+}
+
+func (req *GetSuccessorStateRequest) executeWithStateCache(baseGame *game.Game, cache *stateCache) Response {
+	resp := &GetSuccessorStateResponse{BaseResponse: BaseResponse{gameID: req.gameID()}}
+	baseGame, err := cache.resolve(req.gameID(), baseGame, req.StateToCheck)
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+
+	game, err := playMove(baseGame, req.Move)
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+	cache.add(req.gameID(), req.StateToCheck, req.Move, game)
+	resp.State = req.StateToCheck.with(game.Serialized(), req.Move)
+	return resp
+}
+
+// A tile that can be drawn in the game state, its probability
+// and the legal moves that can be made with it.
+type ChanceOutcome struct {
+	Tile        tiles.Tile
+	Probability float32
+	Moves       []MoveWithState
+}
+
+type GetChanceExpansionResponse struct {
+	BaseResponse
+	// outcomes are sorted the same way as GetRemainingTilesResponse.TileProbabilities
+	Outcomes []ChanceOutcome
+}
+
+// Request for the expansion of the chance node of the game state - each distinct tile
+// that can be drawn, along with its probability and legal moves.
+//
+// The state is resolved once and the legal moves for each of the tiles
+// are then found on separate clones of the state, in parallel on the free helpers
+// of the engine (there is one per worker, shared by all of the requests).
+type GetChanceExpansionRequest struct {
+	BaseGameID   int
+	StateToCheck *GameState
+}
+
+func (req *GetChanceExpansionRequest) gameID() int {
+	return req.BaseGameID
+}
+
+func (req *GetChanceExpansionRequest) requiresWrite() bool {
+	return false
+}
+
+func (req *GetChanceExpansionRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{GetChanceExpansion: req}
+}
+
+func (req *GetChanceExpansionRequest) emptyResponse(base BaseResponse) Response {
+	return &GetChanceExpansionResponse{BaseResponse: base}
+}
+
+func (req *GetChanceExpansionRequest) execute(baseGame *game.Game) Response {
+	return req.executeWithStateCache(baseGame, nil)
+}
+
+func (req *GetChanceExpansionRequest) executeWithStateCache(baseGame *game.Game, cache *stateCache) Response {
+	return req.executeWithHelpers(baseGame, cache, nil)
+}
+
+func (req *GetChanceExpansionRequest) executeWithHelpers(
+	baseGame *game.Game, cache *stateCache, helpers helperPool,
+) Response {
+	resp := &GetChanceExpansionResponse{BaseResponse: BaseResponse{gameID: req.gameID()}}
+	game, err := cache.resolve(req.gameID(), baseGame, req.StateToCheck)
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+
+	probabilities := tileProbabilities(game)
+	outcomes := make([]ChanceOutcome, len(probabilities))
+	errs := make([]error, len(probabilities))
+	tasks := make([]func(), len(probabilities))
+	for i, probability := range probabilities {
+		outcomes[i] = ChanceOutcome{Tile: probability.Tile, Probability: probability.Probability}
+		tileReq := &GetLegalMovesRequest{
+			BaseGameID:   req.BaseGameID,
+			StateToCheck: req.StateToCheck,
+			TileToPlace:  probability.Tile,
+		}
+		// the clones are made here, so that the game is only read by this goroutine
+		clone := game.DeepCloneWithSwappableTiles()
+		tasks[i] = func() {
+			defer func() {
+				if panicValue := recover(); panicValue != nil {
+					errs[i] = &ExecutionPanicError{
+						panicValues: []any{panicValue},
+						stacks:      [][]byte{debug.Stack()},
+					}
+				}
+			}()
+			outcomes[i].Moves, errs[i] = tileReq.legalMoves(clone, cache)
 		}
 	}
+	helpers.run(tasks)
 
+	if err := errors.Join(errs...); err != nil {
+		resp.err = err
+		return resp
+	}
+	resp.Outcomes = outcomes
 	return resp
 }
 
@@ -335,9 +728,21 @@ func (req *GetMidGameScoreRequest) requiresWrite() bool {
 	return false
 }
 
+func (req *GetMidGameScoreRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{GetMidGameScore: req}
+}
+
+func (req *GetMidGameScoreRequest) emptyResponse(base BaseResponse) Response {
+	return &GetMidGameScoreResponse{BaseResponse: base}
+}
+
 func (req *GetMidGameScoreRequest) execute(baseGame *game.Game) Response {
+	return req.executeWithStateCache(baseGame, nil)
+}
+
+func (req *GetMidGameScoreRequest) executeWithStateCache(baseGame *game.Game, cache *stateCache) Response {
 	resp := &GetMidGameScoreResponse{BaseResponse: BaseResponse{gameID: req.gameID()}}
-	baseGame, err := req.StateToCheck.resolve(baseGame)
+	baseGame, err := cache.resolve(req.gameID(), baseGame, req.StateToCheck)
 	if err != nil {
 		resp.err = err
 		return resp
@@ -349,3 +754,282 @@ func (req *GetMidGameScoreRequest) execute(baseGame *game.Game) Response {
 
 	return resp
 }
+
+type GetStateEncodingResponse struct {
+	BaseResponse
+	Encoding encoding.Encoding
+}
+
+// Request for the game state rendered into planes for the neural networks,
+// see the encoding package.
+type GetStateEncodingRequest struct {
+	BaseGameID   int
+	StateToCheck *GameState
+	// tile for which the placeable positions are rendered, none are rendered, if empty
+	TileToPlace tiles.Tile
+	// encoding.DefaultOptions() are used, if not set
+	Options encoding.Options
+}
+
+func (req *GetStateEncodingRequest) gameID() int {
+	return req.BaseGameID
+}
+
+func (req *GetStateEncodingRequest) requiresWrite() bool {
+	return false
+}
+
+func (req *GetStateEncodingRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{GetStateEncoding: req}
+}
+
+func (req *GetStateEncodingRequest) emptyResponse(base BaseResponse) Response {
+	return &GetStateEncodingResponse{BaseResponse: base}
+}
+
+func (req *GetStateEncodingRequest) execute(baseGame *game.Game) Response {
+	return req.executeWithStateCache(baseGame, nil)
+}
+
+func (req *GetStateEncodingRequest) executeWithStateCache(baseGame *game.Game, cache *stateCache) Response {
+	resp := &GetStateEncodingResponse{BaseResponse: BaseResponse{gameID: req.gameID()}}
+	baseGame, err := cache.resolve(req.gameID(), baseGame, req.StateToCheck)
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+
+	options := req.Options
+	if options == (encoding.Options{}) {
+		options = encoding.DefaultOptions()
+	}
+	resp.Encoding, resp.err = encoding.Encode(baseGame, req.TileToPlace, options)
+	return resp
+}
+
+type GetLegalActionMaskResponse struct {
+	BaseResponse
+	// 1 for each of the legal actions, see encoding.ActionSpace
+	Mask        []uint8
+	ActionSpace encoding.ActionSpace
+}
+
+// Request for the mask of the legal actions of the current player placing the given tile,
+// with the actions indexed by the action space of the board window that
+// GetStateEncodingRequest renders the state of.
+type GetLegalActionMaskRequest struct {
+	BaseGameID   int
+	StateToCheck *GameState
+	TileToPlace  tiles.Tile
+	// encoding.DefaultOptions() are used, if not set
+	Options encoding.Options
+}
+
+func (req *GetLegalActionMaskRequest) gameID() int {
+	return req.BaseGameID
+}
+
+func (req *GetLegalActionMaskRequest) requiresWrite() bool {
+	return false
+}
+
+func (req *GetLegalActionMaskRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{GetLegalActionMask: req}
+}
+
+func (req *GetLegalActionMaskRequest) emptyResponse(base BaseResponse) Response {
+	return &GetLegalActionMaskResponse{BaseResponse: base}
+}
+
+func (req *GetLegalActionMaskRequest) execute(baseGame *game.Game) Response {
+	return req.executeWithStateCache(baseGame, nil)
+}
+
+func (req *GetLegalActionMaskRequest) executeWithStateCache(baseGame *game.Game, cache *stateCache) Response {
+	resp := &GetLegalActionMaskResponse{BaseResponse: BaseResponse{gameID: req.gameID()}}
+	baseGame, err := cache.resolve(req.gameID(), baseGame, req.StateToCheck)
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+
+	options := req.Options
+	if options == (encoding.Options{}) {
+		options = encoding.DefaultOptions()
+	}
+	resp.ActionSpace, err = encoding.NewActionSpace(baseGame, options)
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+	resp.Mask = resp.ActionSpace.LegalActionMask(baseGame, req.TileToPlace)
+	return resp
+}
+
+type ChooseMoveResponse struct {
+	BaseResponse
+	Move elements.PlacedTile
+}
+
+// Request for the move that the agent would play in the current turn of the game.
+//
+// The agents are not safe for concurrent use so each request in a batch
+// has to use a different agent.
+type ChooseMoveRequest struct {
+	GameID int
+	Agent  agents.Agent
+}
+
+func (req *ChooseMoveRequest) gameID() int {
+	return req.GameID
+}
+
+func (req *ChooseMoveRequest) requiresWrite() bool {
+	return false
+}
+
+func (req *ChooseMoveRequest) batchRequest() *BatchRequest {
+	return &BatchRequest{ChooseMove: req}
+}
+
+func (req *ChooseMoveRequest) emptyResponse(base BaseResponse) Response {
+	return &ChooseMoveResponse{BaseResponse: base}
+}
+
+func (req *ChooseMoveRequest) execute(game *game.Game) Response {
+	resp := &ChooseMoveResponse{BaseResponse: BaseResponse{gameID: req.gameID()}}
+	if _, err := game.GetCurrentTile(); err != nil {
+		resp.err = err
+		return resp
+	}
+
+	resp.Move = req.Agent.ChooseMove(game)
+	return resp
+}
+
+// Envelope holding exactly one of the requests supported by GameEngine.SendBatch(),
+// allowing different kinds of requests to be sent in a single batch.
+type BatchRequest struct {
+	PlayTurn           *PlayTurnRequest
+	UndoTurn           *UndoTurnRequest
+	GetRemainingTiles  *GetRemainingTilesRequest
+	GetLegalMoves      *GetLegalMovesRequest
+	GetMidGameScore    *GetMidGameScoreRequest
+	ChooseMove         *ChooseMoveRequest
+	GetSuccessorState  *GetSuccessorStateRequest
+	GetStateEncoding   *GetStateEncodingRequest
+	GetLegalActionMask *GetLegalActionMaskRequest
+	GetChanceExpansion *GetChanceExpansionRequest
+	// Maximum time the request can wait for a worker after the batch is submitted,
+	// ErrRequestDeadlineExceeded is returned without executing the request past it.
+	// 0 means no limit.
+	Timeout time.Duration
+}
+
+// Request that can be sent in the BatchRequest envelope.
+type batchableRequest interface {
+	Request
+	// Returns the envelope holding the request. Safe to call on a nil request.
+	batchRequest() *BatchRequest
+	// Returns the response of the request's type with only the given base set,
+	// used in place of the responses that did not come from a worker.
+	// Safe to call on a nil request.
+	emptyResponse(base BaseResponse) Response
+}
+
+// Returns the request held by the envelope.
+func (batchReq *BatchRequest) request() (batchableRequest, error) {
+	var requests []batchableRequest
+	if batchReq.PlayTurn != nil {
+		requests = append(requests, batchReq.PlayTurn)
+	}
+	if batchReq.UndoTurn != nil {
+		requests = append(requests, batchReq.UndoTurn)
+	}
+	if batchReq.GetRemainingTiles != nil {
+		requests = append(requests, batchReq.GetRemainingTiles)
+	}
+	if batchReq.GetLegalMoves != nil {
+		requests = append(requests, batchReq.GetLegalMoves)
+	}
+	if batchReq.GetMidGameScore != nil {
+		requests = append(requests, batchReq.GetMidGameScore)
+	}
+	if batchReq.ChooseMove != nil {
+		requests = append(requests, batchReq.ChooseMove)
+	}
+	if batchReq.GetSuccessorState != nil {
+		requests = append(requests, batchReq.GetSuccessorState)
+	}
+	if batchReq.GetStateEncoding != nil {
+		requests = append(requests, batchReq.GetStateEncoding)
+	}
+	if batchReq.GetLegalActionMask != nil {
+		requests = append(requests, batchReq.GetLegalActionMask)
+	}
+	if batchReq.GetChanceExpansion != nil {
+		requests = append(requests, batchReq.GetChanceExpansion)
+	}
+	if len(requests) != 1 {
+		return nil, fmt.Errorf("%w: got %v requests", ErrInvalidBatchRequest, len(requests))
+	}
+	return requests[0], nil
+}
+
+// Envelope holding the response to the request held by the BatchRequest
+// at the same index. Only the field corresponding to the request's field is set,
+// unless the envelope of the request was invalid (see ErrInvalidBatchRequest),
+// in which case only the embedded BaseResponse carries the error.
+type BatchResponse struct {
+	BaseResponse
+	PlayTurn           *PlayTurnResponse
+	UndoTurn           *UndoTurnResponse
+	GetRemainingTiles  *GetRemainingTilesResponse
+	GetLegalMoves      *GetLegalMovesResponse
+	GetMidGameScore    *GetMidGameScoreResponse
+	ChooseMove         *ChooseMoveResponse
+	GetSuccessorState  *GetSuccessorStateResponse
+	GetStateEncoding   *GetStateEncodingResponse
+	GetLegalActionMask *GetLegalActionMaskResponse
+	GetChanceExpansion *GetChanceExpansionResponse
+	// the response held by the envelope, nil if the envelope of the request was invalid
+	resp Response
+}
+
+// Wraps the response to the given request in an envelope.
+//
+// If the request didn't reach a worker (e.g. due to failure during prepareWorkerInput),
+// a SyncResponse is received here instead of the concrete response type.
+// It is converted to the concrete type so that the response field
+// is always set for a valid request.
+func newBatchResponse(req batchableRequest, resp Response) *BatchResponse {
+	base := BaseResponse{gameID: resp.GameID(), err: resp.Err()}
+	if _, ok := resp.(*SyncResponse); ok {
+		resp = req.emptyResponse(base)
+	}
+
+	batchResp := &BatchResponse{BaseResponse: base, resp: resp}
+	switch concreteResp := resp.(type) {
+	case *PlayTurnResponse:
+		batchResp.PlayTurn = concreteResp
+	case *UndoTurnResponse:
+		batchResp.UndoTurn = concreteResp
+	case *GetRemainingTilesResponse:
+		batchResp.GetRemainingTiles = concreteResp
+	case *GetLegalMovesResponse:
+		batchResp.GetLegalMoves = concreteResp
+	case *GetMidGameScoreResponse:
+		batchResp.GetMidGameScore = concreteResp
+	case *ChooseMoveResponse:
+		batchResp.ChooseMove = concreteResp
+	case *GetSuccessorStateResponse:
+		batchResp.GetSuccessorState = concreteResp
+	case *GetStateEncodingResponse:
+		batchResp.GetStateEncoding = concreteResp
+	case *GetLegalActionMaskResponse:
+		batchResp.GetLegalActionMask = concreteResp
+	case *GetChanceExpansionResponse:
+		batchResp.GetChanceExpansion = concreteResp
+	}
+	return batchResp
+}
@@ -5,9 +5,12 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/encoding"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
@@ -57,7 +60,7 @@ func TestGameEngineSendGetRemainingTilesBatchReturnsFailureWhenInvalidGameStateI
 		t.Fatal(err.Error())
 	}
 
-	game, err := engine.GenerateGame(tilesets.StandardTileSet())
+	game, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -104,7 +107,7 @@ func TestGameEngineSendGetLegalMovesBatchReturnsFailureWhenInvalidGameStateIsPas
 		t.Fatal(err.Error())
 	}
 
-	game, err := engine.GenerateGame(tilesets.StandardTileSet())
+	game, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -145,6 +148,40 @@ func TestGameEngineSendGetMidGameScoreBatchReturnsFailureWhenCommunicatorClosed(
 	}
 }
 
+func TestGameEngineSendChooseMoveBatchReturnsFailureWhenCommunicatorClosed(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	engine.Close()
+
+	requests := []*ChooseMoveRequest{{GameID: 123, Agent: agents.NewRandomAgent(1)}}
+	resp := engine.SendChooseMoveBatch(requests)[0]
+	if resp.Err() == nil {
+		t.Fatal("expected error to occur")
+	}
+	if !errors.Is(resp.Err(), ErrCommunicatorClosed) {
+		t.Fatal(resp.Err().Error())
+	}
+}
+
+func TestGameEngineSendUndoTurnBatchReturnsFailureWhenCommunicatorClosed(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	engine.Close()
+
+	requests := []*UndoTurnRequest{{GameID: 123}}
+	resp := engine.SendUndoTurnBatch(requests)[0]
+	if resp.Err() == nil {
+		t.Fatal("expected error to occur")
+	}
+	if !errors.Is(resp.Err(), ErrCommunicatorClosed) {
+		t.Fatal(resp.Err().Error())
+	}
+}
+
 // --- logic tests ---
 
 func TestGameEngineSendPlayTurnBatchReceivesCorrectResponsesAfterWorkerRequests(t *testing.T) {
@@ -157,7 +194,7 @@ func TestGameEngineSendPlayTurnBatchReceivesCorrectResponsesAfterWorkerRequests(
 	requests := make([]*PlayTurnRequest, 0, requestCount)
 	games := make([]*game.Game, 0, requestCount)
 	for range requestCount {
-		g, err := engine.GenerateGame(tilesets.StandardTileSet())
+		g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 		if err != nil {
 			t.Fatal(err.Error())
 		}
@@ -203,7 +240,7 @@ func TestGameEngineSendGetRemainingTilesBatchReturnsRemainingTiles(t *testing.T)
 		t.Fatal(err.Error())
 	}
 
-	g, err := engine.GenerateGame(tileSet)
+	g, err := engine.GenerateGame(tileSet, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -249,7 +286,7 @@ func TestGameEngineSendGetLegalMovesBatchReturnsNoDuplicates(t *testing.T) {
 		t.Fatal(err.Error())
 	}
 
-	g, err := engine.GenerateGame(tileSet)
+	g, err := engine.GenerateGame(tileSet, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -295,6 +332,127 @@ func TestGameEngineSendGetLegalMovesBatchReturnsNoDuplicates(t *testing.T) {
 	engine.Close()
 }
 
+func TestGameEngineSendGetLegalMovesBatchWithSkippedStatesMatchesFullMode(t *testing.T) {
+	engine, err := StartGameEngine(2, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateSeededGame(tilesets.StandardTileSet(), 7, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	responses := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{
+		{BaseGameID: g.ID, TileToPlace: g.Game.CurrentTile, WithScoreDeltas: true},
+		{BaseGameID: g.ID, TileToPlace: g.Game.CurrentTile, WithScoreDeltas: true, SkipStates: true},
+	})
+	full, light := responses[0], responses[1]
+	if full.Err() != nil {
+		t.Fatal(full.Err().Error())
+	}
+	if light.Err() != nil {
+		t.Fatal(light.Err().Error())
+	}
+	if len(full.Moves) != len(light.Moves) {
+		t.Fatalf("expected %#v, got %#v instead", len(full.Moves), len(light.Moves))
+	}
+	for i, move := range light.Moves {
+		if move.HasState() {
+			t.Fatalf("expected no state, got %#v instead", move.State)
+		}
+		if !reflect.DeepEqual(move.Move, full.Moves[i].Move) {
+			t.Fatalf("expected %#v, got %#v instead", full.Moves[i].Move, move.Move)
+		}
+		if !reflect.DeepEqual(move.ScoreDelta, full.Moves[i].ScoreDelta) {
+			t.Fatalf("expected %#v, got %#v instead", full.Moves[i].ScoreDelta, move.ScoreDelta)
+		}
+	}
+
+	// the state materialised later is the same as the one returned in the full mode
+	move := full.Moves[len(full.Moves)-1]
+	successor := engine.SendGetSuccessorStateBatch([]*GetSuccessorStateRequest{
+		{BaseGameID: g.ID, Move: move.Move},
+	})[0]
+	if successor.Err() != nil {
+		t.Fatal(successor.Err().Error())
+	}
+	if !reflect.DeepEqual(successor.State, move.State) {
+		t.Fatalf("expected %#v, got %#v instead", move.State, successor.State)
+	}
+}
+
+func TestGameEngineSendGetLegalMovesBatchReturnsScoreDeltas(t *testing.T) {
+	tile := tiletemplates.SingleCityEdgeNoRoads()
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles:        []tiles.Tile{tile},
+	}
+
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateGame(tileSet, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	request := &GetLegalMovesRequest{
+		BaseGameID: g.ID, TileToPlace: tile, SkipStates: true, WithScoreDeltas: true,
+	}
+	resp := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{request})[0]
+	if resp.Err() != nil {
+		t.Fatal(resp.Err().Error())
+	}
+
+	// closing the city of the starting tile above it is the only way to score points
+	// and it needs a meeple of the current player in it
+	scoringMoves := 0
+	for _, move := range resp.Moves {
+		if move.ScoreDelta[2] != 0 {
+			t.Fatalf("expected %#v, got %#v instead", 0, move.ScoreDelta[2])
+		}
+		if move.ScoreDelta[1] != 0 {
+			scoringMoves++
+			if move.ScoreDelta[1] != 4 {
+				t.Fatalf("expected %#v, got %#v instead", 4, move.ScoreDelta[1])
+			}
+		}
+	}
+	if scoringMoves != 1 {
+		t.Fatalf("expected %#v, got %#v instead", 1, scoringMoves)
+	}
+}
+
+func TestGameEngineSendGetSuccessorStateBatchReturnsFailureForIllegalMove(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// the starting position is already taken
+	move := elements.ToPlacedTile(g.Game.CurrentTile)
+	resp := engine.SendGetSuccessorStateBatch([]*GetSuccessorStateRequest{
+		{BaseGameID: g.ID, Move: move},
+	})[0]
+	if resp.Err() == nil {
+		t.Fatal("expected error to occur")
+	}
+	if resp.State != nil {
+		t.Fatalf("expected no state, got %#v instead", resp.State)
+	}
+}
+
 func TestGameEngineSendGetLegalMovesBatchReturnsAllLegalRotations(t *testing.T) {
 	tile := tiletemplates.MonasteryWithSingleRoad()
 	tileSet := tilesets.TileSet{
@@ -308,7 +466,7 @@ func TestGameEngineSendGetLegalMovesBatchReturnsAllLegalRotations(t *testing.T)
 		t.Fatal(err.Error())
 	}
 
-	g, err := engine.GenerateGame(tileSet)
+	g, err := engine.GenerateGame(tileSet, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -367,6 +525,204 @@ func TestGameEngineSendGetLegalMovesBatchReturnsAllLegalRotations(t *testing.T)
 	engine.Close()
 }
 
+func TestGameEngineSendGetChanceExpansionBatchReturnsMovesForEachRemainingTile(t *testing.T) {
+	engine, err := StartGameEngine(4, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateSeededGame(tilesets.StandardTileSet(), 3, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	legalMoves := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{
+		{BaseGameID: g.ID, TileToPlace: g.Game.CurrentTile},
+	})[0]
+	if legalMoves.Err() != nil {
+		t.Fatal(legalMoves.Err().Error())
+	}
+	state := legalMoves.Moves[0].State
+
+	resp := engine.SendGetChanceExpansionBatch([]*GetChanceExpansionRequest{
+		{BaseGameID: g.ID, StateToCheck: state},
+	})[0]
+	if resp.Err() != nil {
+		t.Fatal(resp.Err().Error())
+	}
+
+	remainingTiles := engine.SendGetRemainingTilesBatch([]*GetRemainingTilesRequest{
+		{BaseGameID: g.ID, StateToCheck: state},
+	})[0]
+	if len(resp.Outcomes) != len(remainingTiles.TileProbabilities) {
+		t.Fatalf("expected %#v, got %#v instead", len(remainingTiles.TileProbabilities), len(resp.Outcomes))
+	}
+	for i, outcome := range resp.Outcomes {
+		expected := remainingTiles.TileProbabilities[i]
+		if !outcome.Tile.ExactEquals(expected.Tile) || outcome.Probability != expected.Probability {
+			t.Fatalf("expected %#v, got %#v instead", expected, outcome)
+		}
+
+		moves := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{
+			{BaseGameID: g.ID, StateToCheck: state, TileToPlace: outcome.Tile},
+		})[0]
+		if len(outcome.Moves) != len(moves.Moves) {
+			t.Fatalf("expected %#v, got %#v instead", len(moves.Moves), len(outcome.Moves))
+		}
+		for j, move := range outcome.Moves {
+			if !reflect.DeepEqual(move.Move, moves.Moves[j].Move) {
+				t.Fatalf("expected %#v, got %#v instead", moves.Moves[j].Move, move.Move)
+			}
+		}
+	}
+}
+
+func TestGameEngineSendGetChanceExpansionBatchReturnsFailureWhenGameIDNotFound(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	responses := engine.SendGetChanceExpansionBatch([]*GetChanceExpansionRequest{
+		{BaseGameID: g.ID + 1}, nil, {BaseGameID: g.ID},
+	})
+	if !errors.Is(responses[0].Err(), ErrGameNotFound) {
+		t.Fatalf("expected %#v, got %#v instead", ErrGameNotFound, responses[0].Err())
+	}
+	if !errors.Is(responses[1].Err(), ErrInvalidBatchRequest) {
+		t.Fatalf("expected %#v, got %#v instead", ErrInvalidBatchRequest, responses[1].Err())
+	}
+	if responses[2].Err() != nil {
+		t.Fatal(responses[2].Err().Error())
+	}
+	if responses[0].Outcomes != nil || len(responses[2].Outcomes) == 0 {
+		t.Fatalf("expected outcomes only for the valid request, got %#v instead", responses)
+	}
+}
+
+func TestGameEngineSendBatchExpandsChanceNodeAlongOtherRequests(t *testing.T) {
+	engine, err := StartGameEngine(2, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	responses := engine.SendBatch([]*BatchRequest{
+		{GetChanceExpansion: &GetChanceExpansionRequest{BaseGameID: g.ID}},
+		{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: g.ID}},
+	})
+	for _, resp := range responses {
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+	}
+	if responses[0].GetChanceExpansion == nil || len(responses[0].GetChanceExpansion.Outcomes) == 0 {
+		t.Fatalf("expected the outcomes of the chance node, got %#v instead", responses[0])
+	}
+	for _, outcome := range responses[0].GetChanceExpansion.Outcomes {
+		if len(outcome.Moves) == 0 {
+			t.Fatalf("expected legal moves for each of the tiles, got %#v instead", outcome)
+		}
+	}
+}
+
+func TestGameEngineSendGetStateEncodingBatchEncodesLegalMoveStates(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	legalMoves := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{
+		{BaseGameID: g.ID, TileToPlace: g.Game.CurrentTile},
+	})[0]
+	if legalMoves.Err() != nil {
+		t.Fatal(legalMoves.Err().Error())
+	}
+
+	responses := engine.SendGetStateEncodingBatch([]*GetStateEncodingRequest{
+		{BaseGameID: g.ID, TileToPlace: g.Game.CurrentTile},
+		{BaseGameID: g.ID, StateToCheck: legalMoves.Moves[0].State},
+		{BaseGameID: g.ID, Options: encoding.Options{Width: -1, Height: 1}},
+	})
+	for _, resp := range responses[:2] {
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+		expectedShape := []int{encoding.MeeplePlanesStart + 2, 63, 63}
+		if !reflect.DeepEqual(resp.Encoding.Shape, expectedShape) {
+			t.Fatalf("expected %#v, got %#v instead", expectedShape, resp.Encoding.Shape)
+		}
+	}
+	if reflect.DeepEqual(responses[0].Encoding.Planes, responses[1].Encoding.Planes) {
+		t.Fatal("expected the state after the move to be encoded differently")
+	}
+	if !errors.Is(responses[2].Err(), encoding.ErrInvalidOptions) {
+		t.Fatalf("expected %#v, got %#v instead", encoding.ErrInvalidOptions, responses[2].Err())
+	}
+}
+
+func TestGameEngineSendGetLegalActionMaskBatchMarksLegalMoves(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	legalMoves := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{
+		{BaseGameID: g.ID, TileToPlace: g.Game.CurrentTile, SkipStates: true},
+	})[0]
+	if legalMoves.Err() != nil {
+		t.Fatal(legalMoves.Err().Error())
+	}
+
+	resp := engine.SendGetLegalActionMaskBatch([]*GetLegalActionMaskRequest{
+		{BaseGameID: g.ID, TileToPlace: g.Game.CurrentTile},
+	})[0]
+	if resp.Err() != nil {
+		t.Fatal(resp.Err().Error())
+	}
+	if len(resp.Mask) != resp.ActionSpace.Size() {
+		t.Fatalf("expected %#v, got %#v instead", resp.ActionSpace.Size(), len(resp.Mask))
+	}
+
+	legalCount := 0
+	for _, value := range resp.Mask {
+		legalCount += int(value)
+	}
+	if legalCount != len(legalMoves.Moves) {
+		t.Fatalf("expected %#v, got %#v instead", len(legalMoves.Moves), legalCount)
+	}
+	for _, move := range legalMoves.Moves {
+		action, err := resp.ActionSpace.EncodeMove(g.Game.CurrentTile, move.Move)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.Mask[action] != 1 {
+			t.Fatalf("expected action %#v to be legal", action)
+		}
+	}
+}
+
 func TestGameEngineSendGetMidGameScoreBatchAtGameStartReturnsZeroScores(t *testing.T) {
 
 	engine, err := StartGameEngine(4, t.TempDir())
@@ -375,7 +731,7 @@ func TestGameEngineSendGetMidGameScoreBatchAtGameStartReturnsZeroScores(t *testi
 	}
 	tileSet := tilesets.StandardTileSet()
 
-	gameWithID, err := engine.GenerateGame(tileSet)
+	gameWithID, err := engine.GenerateGame(tileSet, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -417,7 +773,7 @@ func TestGameEngineSendGetMidGameScoreBatchAtMidGameReturnsExpectedScores(t *tes
 		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
 	}
 
-	gameWithID, err := engine.GenerateOrderedGame(tileSet)
+	gameWithID, err := engine.GenerateOrderedGame(tileSet, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -483,7 +839,7 @@ func TestPlaySingleTurnGameWithNoLogger(t *testing.T) {
 		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
 	}
 
-	game, err := engine.GenerateGame(tileset)
+	game, err := engine.GenerateGame(tileset, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -505,6 +861,13 @@ func TestPlaySingleTurnGameWithNoLogger(t *testing.T) {
 	if playTurnResp.FinalScores[elements.ID(1)] != expectedScore {
 		t.Fatalf("Expected score of player1: %#v, got: %#v", expectedScore, playTurnResp.FinalScores[elements.ID(1)])
 	}
+
+	expectedMeeples := []elements.MeepleWithPosition{
+		elements.NewMeepleWithPosition(ptile.GetPlacedFeatureAtSide(side.Right, feature.Road).Meeple, ptile.Position),
+	}
+	if !reflect.DeepEqual(playTurnResp.FinalScoreReport.ReturnedMeeples[elements.ID(1)], expectedMeeples) {
+		t.Fatalf("expected %#v, got %#v instead", expectedMeeples, playTurnResp.FinalScoreReport.ReturnedMeeples)
+	}
 }
 
 func TestGetLegalMovesAndPlayItWithPlayTurnRequestWithNoLogger(t *testing.T) {
@@ -513,7 +876,7 @@ func TestGetLegalMovesAndPlayItWithPlayTurnRequestWithNoLogger(t *testing.T) {
 		t.Fatal(err.Error())
 	}
 
-	game, err := engine.GenerateGame(tilesets.StandardTileSet()) // TODO: change for GenerateSeededGame when benchmarks are merged
+	game, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard()) // TODO: change for GenerateSeededGame when benchmarks are merged
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -541,7 +904,7 @@ func TestGetRemainingTilesNoLogger(t *testing.T) {
 		t.Fatal(err.Error())
 	}
 
-	game, err := engine.GenerateGame(tilesets.StandardTileSet()) // TODO: Change for GenerateSeededGame when benchmarks are merged
+	game, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard()) // TODO: Change for GenerateSeededGame when benchmarks are merged
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -569,7 +932,7 @@ func TestGetMidGameScoreRequestWithNoLogger(t *testing.T) {
 		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
 	}
 
-	game, err := engine.GenerateGame(tileset)
+	game, err := engine.GenerateGame(tileset, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -608,7 +971,7 @@ func TestCloneGameFullWithNoLogger(t *testing.T) {
 		t.Fatal(err.Error())
 	}
 
-	game, err := engine.GenerateGame(tilesets.StandardTileSet())
+	game, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -629,7 +992,7 @@ func TestCloneGameNotFullWithNoLogger(t *testing.T) {
 		t.Fatal(err.Error())
 	}
 
-	game, err := engine.GenerateGame(tilesets.StandardTileSet())
+	game, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -643,3 +1006,287 @@ func TestCloneGameNotFullWithNoLogger(t *testing.T) {
 		t.Fatal(err.Error())
 	}
 }
+
+func TestGameEngineSendUndoTurnBatchRestoresPreviousState(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+	engine.SetUndoEnabled(true)
+
+	g, err := engine.GenerateSeededGame(tilesets.StandardTileSet(), 7, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	playTurnReq := &PlayTurnRequest{GameID: g.ID, Move: g.Game.ValidTilePlacements[0]}
+	playTurnResp := engine.SendPlayTurnBatch([]*PlayTurnRequest{playTurnReq})[0]
+	if playTurnResp.Err() != nil {
+		t.Fatal(playTurnResp.Err().Error())
+	}
+
+	undoTurnReq := &UndoTurnRequest{GameID: g.ID}
+	undoTurnResp := engine.SendUndoTurnBatch([]*UndoTurnRequest{undoTurnReq})[0]
+	if undoTurnResp.Err() != nil {
+		t.Fatal(undoTurnResp.Err().Error())
+	}
+
+	if !reflect.DeepEqual(g.Game, undoTurnResp.Game) {
+		t.Fatalf("expected %#v serialized game, got %#v instead", g.Game, undoTurnResp.Game)
+	}
+
+	undoTurnResp = engine.SendUndoTurnBatch([]*UndoTurnRequest{undoTurnReq})[0]
+	if !errors.Is(undoTurnResp.Err(), elements.ErrNoTurnToUndo) {
+		t.Fatalf("expected ErrNoTurnToUndo, got %#v instead", undoTurnResp.Err())
+	}
+}
+
+func TestGameEngineSendGetLegalMovesBatchIncludesAbbotRecall(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	tileSet := tilesets.StandardTileSet()
+	tileSet.Tiles = []tiles.Tile{
+		tiletemplates.StraightRoadsGarden(),
+		tiletemplates.StraightRoads(),
+		tiletemplates.StraightRoads(),
+	}
+	g, err := engine.GenerateOrderedGame(tileSet, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	gameID, serializedGame := g.ID, g.Game
+
+	// the first player places the abbot, the second player doesn't place any meeple
+	var abbotPosition position.Position
+	for turn := range 2 {
+		legalMovesReq := &GetLegalMovesRequest{BaseGameID: gameID, TileToPlace: serializedGame.CurrentTile}
+		legalMovesResp := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{legalMovesReq})[0]
+		if legalMovesResp.Err() != nil {
+			t.Fatal(legalMovesResp.Err().Error())
+		}
+		move := legalMovesResp.Moves[0].Move
+		if turn == 0 {
+			for _, moveWithState := range legalMovesResp.Moves {
+				garden := moveWithState.Move.Garden()
+				if garden != nil && garden.Meeple.Type == elements.AbbotMeeple {
+					move = moveWithState.Move
+				}
+			}
+			abbotPosition = move.Position
+		}
+
+		playTurnReq := &PlayTurnRequest{GameID: gameID, Move: move}
+		playTurnResp := engine.SendPlayTurnBatch([]*PlayTurnRequest{playTurnReq})[0]
+		if playTurnResp.Err() != nil {
+			t.Fatal(playTurnResp.Err().Error())
+		}
+		gameID, serializedGame = playTurnResp.GameID(), playTurnResp.Game
+	}
+
+	legalMovesReq := &GetLegalMovesRequest{BaseGameID: gameID, TileToPlace: serializedGame.CurrentTile}
+	legalMovesResp := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{legalMovesReq})[0]
+	if legalMovesResp.Err() != nil {
+		t.Fatal(legalMovesResp.Err().Error())
+	}
+
+	recallCount := 0
+	for _, moveWithState := range legalMovesResp.Moves {
+		if moveWithState.Move.RecallAbbot {
+			recallCount++
+			if moveWithState.Move.RecalledAbbotPosition != abbotPosition {
+				t.Fatalf(
+					"expected %#v, got %#v instead",
+					abbotPosition, moveWithState.Move.RecalledAbbotPosition,
+				)
+			}
+		}
+	}
+	if recallCount == 0 {
+		t.Fatalf("expected abbot recall moves, got %#v instead", legalMovesResp.Moves)
+	}
+}
+
+func TestGameEngineSendChooseMoveBatchReturnsMovesThatCanBePlayed(t *testing.T) {
+	engine, err := StartGameEngine(4, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	tileset := tilesets.TileSet{
+		Tiles:        []tiles.Tile{tiletemplates.StraightRoads()},
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+	}
+	games := []SerializedGameWithID{}
+	for range 2 {
+		game, err := engine.GenerateGame(tileset, 2, rules.Standard())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		games = append(games, game)
+	}
+
+	chooseMoveRequests := []*ChooseMoveRequest{
+		{GameID: games[0].ID, Agent: agents.NewRandomAgent(1)},
+		{GameID: games[1].ID, Agent: agents.NewGreedyAgent(1)},
+	}
+	for _, chooseMoveResp := range engine.SendChooseMoveBatch(chooseMoveRequests) {
+		if chooseMoveResp.Err() != nil {
+			t.Fatal(chooseMoveResp.Err().Error())
+		}
+
+		playTurnRequest := &PlayTurnRequest{GameID: chooseMoveResp.GameID(), Move: chooseMoveResp.Move}
+		playTurnResp := engine.SendPlayTurnBatch([]*PlayTurnRequest{playTurnRequest})[0]
+		if playTurnResp.Err() != nil {
+			t.Fatal(playTurnResp.Err().Error())
+		}
+	}
+
+	// there are no more turns to choose a move for
+	chooseMoveResp := engine.SendChooseMoveBatch(chooseMoveRequests[:1])[0]
+	if chooseMoveResp.Err() == nil {
+		t.Fatal("expected error to occur")
+	}
+}
+
+func TestGameEngineSendBatchReturnsResponsesForMixedRequests(t *testing.T) {
+	engine, err := StartGameEngine(4, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	games := []SerializedGameWithID{}
+	for range 3 {
+		game, err := engine.GenerateSeededGame(tilesets.StandardTileSet(), 1, 2, rules.Standard())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		games = append(games, game)
+	}
+	move := games[0].Game.ValidTilePlacements[0]
+
+	requests := []*BatchRequest{
+		{PlayTurn: &PlayTurnRequest{GameID: games[0].ID, Move: move}},
+		{GetLegalMoves: &GetLegalMovesRequest{BaseGameID: games[1].ID, TileToPlace: games[1].Game.CurrentTile}},
+		{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: games[2].ID}},
+		{GetRemainingTiles: &GetRemainingTilesRequest{BaseGameID: games[2].ID}},
+	}
+	responses := engine.SendBatch(requests)
+
+	for i, resp := range responses {
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+		if resp.GameID() != []int{games[0].ID, games[1].ID, games[2].ID, games[2].ID}[i] {
+			t.Fatalf("expected game ID of the request, got %#v instead", resp.GameID())
+		}
+	}
+	if responses[0].PlayTurn == nil || responses[0].PlayTurn.Game.CurrentPlayerID != 2 {
+		t.Fatalf("expected play turn response, got %#v instead", responses[0])
+	}
+	if responses[1].GetLegalMoves == nil || len(responses[1].GetLegalMoves.Moves) == 0 {
+		t.Fatalf("expected legal moves response, got %#v instead", responses[1])
+	}
+	if responses[2].GetMidGameScore == nil || responses[2].PlayTurn != nil {
+		t.Fatalf("expected mid game score response, got %#v instead", responses[2])
+	}
+	if responses[3].GetRemainingTiles == nil || len(responses[3].GetRemainingTiles.TileProbabilities) == 0 {
+		t.Fatalf("expected remaining tiles response, got %#v instead", responses[3])
+	}
+}
+
+func TestGameEngineSendUndoTurnBatchReturnsErrorWhenUndoIsDisabled(t *testing.T) {
+	engine, err := StartGameEngine(1, t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	g, err := engine.GenerateSeededGame(tilesets.StandardTileSet(), 7, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	playTurnReq := &PlayTurnRequest{GameID: g.ID, Move: g.Game.ValidTilePlacements[0]}
+	playTurnResp := engine.SendPlayTurnBatch([]*PlayTurnRequest{playTurnReq})[0]
+	if playTurnResp.Err() != nil {
+		t.Fatal(playTurnResp.Err().Error())
+	}
+
+	undoTurnResp := engine.SendUndoTurnBatch([]*UndoTurnRequest{{GameID: g.ID}})[0]
+	if !errors.Is(undoTurnResp.Err(), elements.ErrNoTurnToUndo) {
+		t.Fatalf("expected ErrNoTurnToUndo, got %#v instead", undoTurnResp.Err())
+	}
+}
+
+func TestGameEngineSendBatchReturnsFailureForInvalidEnvelope(t *testing.T) {
+	engine, err := StartGameEngine(1, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+	game, err := engine.GenerateGame(tilesets.StandardTileSet(), 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	requests := []*BatchRequest{
+		{},
+		{
+			UndoTurn:        &UndoTurnRequest{GameID: game.ID},
+			GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: game.ID},
+		},
+		{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: game.ID}},
+	}
+	responses := engine.SendBatch(requests)
+
+	for _, resp := range responses[:2] {
+		if !errors.Is(resp.Err(), ErrInvalidBatchRequest) {
+			t.Fatalf("expected %#v, got %#v instead", ErrInvalidBatchRequest, resp.Err())
+		}
+	}
+	if responses[2].Err() != nil {
+		t.Fatal(responses[2].Err().Error())
+	}
+}
+
+func TestGameEngineSendConcreteBatchReturnsErrorForNilRequest(t *testing.T) {
+	engine, err := StartGameEngine(1, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	playTurnResp := engine.SendPlayTurnBatch([]*PlayTurnRequest{nil})[0]
+	if !errors.Is(playTurnResp.Err(), ErrInvalidBatchRequest) {
+		t.Fatalf("expected %#v, got %#v instead", ErrInvalidBatchRequest, playTurnResp.Err())
+	}
+	legalMovesResp := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{nil})[0]
+	if !errors.Is(legalMovesResp.Err(), ErrInvalidBatchRequest) {
+		t.Fatalf("expected %#v, got %#v instead", ErrInvalidBatchRequest, legalMovesResp.Err())
+	}
+}
+
+func TestGameEngineSendBatchConvertsEarlyFailureToConcreteResponse(t *testing.T) {
+	engine, err := StartGameEngine(1, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer engine.Close()
+
+	requests := []*BatchRequest{{UndoTurn: &UndoTurnRequest{GameID: 123}}}
+	resp := engine.SendBatch(requests)[0]
+
+	if resp.UndoTurn == nil {
+		t.Fatalf("expected undo turn response, got %#v instead", resp)
+	}
+	if !errors.Is(resp.UndoTurn.Err(), ErrGameNotFound) {
+		t.Fatalf("expected %#v, got %#v instead", ErrGameNotFound, resp.UndoTurn.Err())
+	}
+}
@@ -0,0 +1,291 @@
+package engine
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+)
+
+// Default maximum number of the resolved game states kept by the engine,
+// see GameEngine.SetStateCacheSize().
+const DefaultStateCacheSize = 4096
+
+// Statistics of the engine's cache of resolved game states.
+type StateCacheStats struct {
+	// number of the states that were found in the cache
+	Hits int
+	// number of the states that had to be (at least partially) resolved by replaying moves
+	Misses int
+	// number of the states removed from the cache to make room for the new ones
+	Evictions int
+	// number of the states currently in the cache
+	Size int
+	// maximum number of the states in the cache
+	Capacity int
+}
+
+// Returns the ratio of the cache hits to all of the cache lookups.
+func (stats StateCacheStats) HitRate() float64 {
+	lookups := stats.Hits + stats.Misses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(stats.Hits) / float64(lookups)
+}
+
+type stateCacheKey struct {
+	gameID int
+	// moves simulated on the base game, see appendMoveKey()
+	moves string
+}
+
+type stateCacheEntry struct {
+	key  stateCacheKey
+	game *game.Game
+}
+
+// LRU cache of the games resolved from the GameStates, keyed by the base game
+// and the sequence of the moves simulated on it.
+//
+// The cached games are only ever read by the requests so they can be shared
+// between the requests executed concurrently, just like the base games.
+// The requests that need to modify the resolved game (e.g. to play a move on it)
+// have to clone it first.
+// A nil cache does not cache anything.
+type stateCache struct {
+	mutex    sync.Mutex
+	capacity int
+	// front of the list is the most recently used entry
+	order   *list.List
+	entries map[stateCacheKey]*list.Element
+	// keys of the entries of each of the base games
+	gameKeys map[int]map[stateCacheKey]struct{}
+	stats    StateCacheStats
+}
+
+func newStateCache(capacity int) *stateCache {
+	return &stateCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[stateCacheKey]*list.Element{},
+		gameKeys: map[int]map[stateCacheKey]struct{}{},
+	}
+}
+
+// Returns the game resolved from the given state of the base game. The returned game
+// must not be modified - it needs to be cloned first.
+//
+// If the state is not cached, it is resolved from the longest cached sequence
+// of its first moves (or the base game) and added to the cache.
+func (cache *stateCache) resolve(
+	baseGameID int, baseGame *game.Game, state *GameState,
+) (*game.Game, error) {
+	if state == nil {
+		return baseGame, nil
+	}
+	if cache == nil {
+		return replayMoves(baseGame, state.simulatedMoves)
+	}
+
+	// the key of each move sequence is a prefix of the key of the full sequence
+	key := []byte{}
+	prefixLengths := make([]int, len(state.simulatedMoves))
+	for i, move := range state.simulatedMoves {
+		key = appendMoveKey(key, move)
+		prefixLengths[i] = len(key)
+	}
+	fullKey := stateCacheKey{gameID: baseGameID, moves: string(key)}
+
+	cache.mutex.Lock()
+	if game, ok := cache.getLocked(fullKey); ok {
+		cache.stats.Hits++
+		cache.mutex.Unlock()
+		return game, nil
+	}
+	cache.stats.Misses++
+	start := baseGame
+	replayedMoves := state.simulatedMoves
+	for i := len(prefixLengths) - 2; i >= 0; i-- {
+		prefixKey := stateCacheKey{gameID: baseGameID, moves: string(key[:prefixLengths[i]])}
+		if game, ok := cache.getLocked(prefixKey); ok {
+			start = game
+			replayedMoves = state.simulatedMoves[i+1:]
+			break
+		}
+	}
+	cache.mutex.Unlock()
+
+	game, err := replayMoves(start, replayedMoves)
+	if err != nil {
+		return nil, err
+	}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.putLocked(fullKey, game)
+	return game, nil
+}
+
+// Add the game resolved from the state of the base game
+// that is created by playing the given move in the parent state.
+func (cache *stateCache) add(baseGameID int, parent *GameState, move elements.PlacedTile, game *game.Game) {
+	if cache == nil {
+		return
+	}
+	key := []byte{}
+	if parent != nil {
+		for _, parentMove := range parent.simulatedMoves {
+			key = appendMoveKey(key, parentMove)
+		}
+	}
+	key = appendMoveKey(key, move)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.putLocked(stateCacheKey{gameID: baseGameID, moves: string(key)}, game)
+}
+
+// Remove the given state of the base game from the cache.
+func (cache *stateCache) release(baseGameID int, state *GameState) {
+	if cache == nil || state == nil {
+		return
+	}
+	key := []byte{}
+	for _, move := range state.simulatedMoves {
+		key = appendMoveKey(key, move)
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.entries[stateCacheKey{gameID: baseGameID, moves: string(key)}]; ok {
+		cache.removeLocked(element)
+	}
+}
+
+// Remove all of the states of the given base game from the cache. This needs to be done
+// whenever the base game changes (or gets deleted) as its states are no longer valid.
+func (cache *stateCache) removeGame(baseGameID int) {
+	if cache == nil {
+		return
+	}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	for key := range cache.gameKeys[baseGameID] {
+		cache.removeLocked(cache.entries[key])
+	}
+}
+
+// Change the maximum number of the cached states, evicting the least recently used ones.
+func (cache *stateCache) setCapacity(capacity int) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.capacity = max(capacity, 0)
+	cache.evictLocked()
+}
+
+func (cache *stateCache) getStats() StateCacheStats {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	stats := cache.stats
+	stats.Size = cache.order.Len()
+	stats.Capacity = cache.capacity
+	return stats
+}
+
+// The caller has to hold the cache's mutex.
+func (cache *stateCache) getLocked(key stateCacheKey) (*game.Game, bool) {
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(*stateCacheEntry).game, true
+}
+
+// The caller has to hold the cache's mutex.
+func (cache *stateCache) putLocked(key stateCacheKey, game *game.Game) {
+	if cache.capacity == 0 {
+		return
+	}
+	if element, ok := cache.entries[key]; ok {
+		element.Value.(*stateCacheEntry).game = game
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.entries[key] = cache.order.PushFront(&stateCacheEntry{key: key, game: game})
+	keys, ok := cache.gameKeys[key.gameID]
+	if !ok {
+		keys = map[stateCacheKey]struct{}{}
+		cache.gameKeys[key.gameID] = keys
+	}
+	keys[key] = struct{}{}
+	cache.evictLocked()
+}
+
+// The caller has to hold the cache's mutex.
+func (cache *stateCache) evictLocked() {
+	for cache.order.Len() > cache.capacity {
+		cache.removeLocked(cache.order.Back())
+		cache.stats.Evictions++
+	}
+}
+
+// The caller has to hold the cache's mutex.
+func (cache *stateCache) removeLocked(element *list.Element) {
+	key := cache.order.Remove(element).(*stateCacheEntry).key
+	delete(cache.entries, key)
+	keys := cache.gameKeys[key.gameID]
+	delete(keys, key)
+	if len(keys) == 0 {
+		delete(cache.gameKeys, key.gameID)
+	}
+}
+
+// Play the given moves on a clone of the given game, swapping the current tile
+// for the tile of each move.
+func replayMoves(baseGame *game.Game, moves []elements.PlacedTile) (*game.Game, error) {
+	game := baseGame.DeepCloneWithSwappableTiles()
+	for _, move := range moves {
+		if err := game.SwapCurrentTile(elements.ToTile(move)); err != nil {
+			return nil, err
+		}
+		if err := game.PlayTurn(move); err != nil {
+			return nil, err
+		}
+	}
+	return game, nil
+}
+
+// Append the unique binary representation of the move to the key.
+func appendMoveKey(key []byte, move elements.PlacedTile) []byte {
+	key = appendPositionKey(key, move.Position)
+	if move.RecallAbbot {
+		key = append(key, 1)
+		key = appendPositionKey(key, move.RecalledAbbotPosition)
+	} else {
+		key = append(key, 0)
+	}
+	key = append(key, byte(len(move.Features)))
+	for _, feature := range move.Features {
+		key = append(
+			key,
+			byte(feature.FeatureType),
+			byte(feature.ModifierType),
+			byte(feature.Sides),
+			byte(feature.Meeple.Type),
+			byte(feature.Meeple.PlayerID),
+		)
+	}
+	return key
+}
+
+func appendPositionKey(key []byte, pos position.Position) []byte {
+	return append(
+		key,
+		byte(uint16(pos.X())>>8), byte(pos.X()),
+		byte(uint16(pos.Y())>>8), byte(pos.Y()),
+	)
+}
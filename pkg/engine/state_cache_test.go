@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+// Starts an engine with a seeded game and returns the states after its legal moves.
+func startEngineWithStates(t *testing.T, cacheSize int) (*GameEngine, int, []MoveWithState) {
+	engine, err := StartGameEngine(2, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(engine.Close)
+	engine.SetStateCacheSize(cacheSize)
+
+	g, err := engine.GenerateSeededGame(tilesets.StandardTileSet(), 5, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	resp := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{
+		{BaseGameID: g.ID, TileToPlace: g.Game.CurrentTile},
+	})[0]
+	if resp.Err() != nil {
+		t.Fatal(resp.Err().Error())
+	}
+	return engine, g.ID, resp.Moves
+}
+
+func TestGameEngineStateCacheReturnsLegalMoveStates(t *testing.T) {
+	engine, gameID, moves := startEngineWithStates(t, 100)
+
+	stats := engine.StateCacheStats()
+	if stats.Size != len(moves) {
+		t.Fatalf("expected %#v, got %#v instead", len(moves), stats.Size)
+	}
+
+	resp := engine.SendGetMidGameScoreBatch([]*GetMidGameScoreRequest{
+		{BaseGameID: gameID, StateToCheck: moves[0].State},
+	})[0]
+	if resp.Err() != nil {
+		t.Fatal(resp.Err().Error())
+	}
+
+	stats = engine.StateCacheStats()
+	if stats.Hits != 1 || stats.Misses != 0 {
+		t.Fatalf("expected 1 hit and 0 misses, got %#v instead", stats)
+	}
+	if stats.HitRate() != 1 {
+		t.Fatalf("expected %#v, got %#v instead", 1.0, stats.HitRate())
+	}
+}
+
+func TestGameEngineStateCacheResolvesSameGamesAsMoveReplay(t *testing.T) {
+	cachedEngine, cachedGameID, cachedMoves := startEngineWithStates(t, 100)
+	engine, gameID, moves := startEngineWithStates(t, 0)
+
+	// states two moves deep are resolved from the cached states of their first move
+	getNextMoves := func(engine *GameEngine, gameID int, move MoveWithState) []MoveWithState {
+		tiles := engine.SendGetRemainingTilesBatch([]*GetRemainingTilesRequest{
+			{BaseGameID: gameID, StateToCheck: move.State},
+		})[0]
+		if tiles.Err() != nil {
+			t.Fatal(tiles.Err().Error())
+		}
+		resp := engine.SendGetLegalMovesBatch([]*GetLegalMovesRequest{{
+			BaseGameID:   gameID,
+			StateToCheck: move.State,
+			TileToPlace:  tiles.TileProbabilities[0].Tile,
+		}})[0]
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+		return resp.Moves
+	}
+	expectedMoves := getNextMoves(engine, gameID, moves[0])
+	actualMoves := getNextMoves(cachedEngine, cachedGameID, cachedMoves[0])
+	cachedEngine.ReleaseStates(cachedGameID, []*GameState{actualMoves[0].State})
+
+	requests := []*GetMidGameScoreRequest{
+		{BaseGameID: gameID, StateToCheck: expectedMoves[0].State},
+	}
+	cachedRequests := []*GetMidGameScoreRequest{
+		{BaseGameID: cachedGameID, StateToCheck: actualMoves[0].State},
+	}
+	expected := engine.SendGetMidGameScoreBatch(requests)[0]
+	actual := cachedEngine.SendGetMidGameScoreBatch(cachedRequests)[0]
+	if actual.Err() != nil {
+		t.Fatal(actual.Err().Error())
+	}
+	if !reflect.DeepEqual(actual.Scores, expected.Scores) {
+		t.Fatalf("expected %#v, got %#v instead", expected.Scores, actual.Scores)
+	}
+	if !reflect.DeepEqual(actualMoves[0].State.Serialized(), expectedMoves[0].State.Serialized()) {
+		t.Fatalf(
+			"expected %#v, got %#v instead",
+			expectedMoves[0].State.Serialized(),
+			actualMoves[0].State.Serialized(),
+		)
+	}
+
+	stats := cachedEngine.StateCacheStats()
+	if stats.Misses != 1 {
+		t.Fatalf("expected %#v, got %#v instead", 1, stats.Misses)
+	}
+}
+
+// The requests only read the cached games, which is checked by the race detector.
+func TestGameEngineStateCacheIsSharedByConcurrentRequests(t *testing.T) {
+	engine, gameID, moves := startEngineWithStates(t, 100)
+	state := moves[0].State
+	expected := engine.SendGetMidGameScoreBatch([]*GetMidGameScoreRequest{
+		{BaseGameID: gameID, StateToCheck: state},
+	})[0]
+	if expected.Err() != nil {
+		t.Fatal(expected.Err().Error())
+	}
+
+	requests := []*BatchRequest{}
+	for range 4 {
+		requests = append(
+			requests,
+			&BatchRequest{GetMidGameScore: &GetMidGameScoreRequest{BaseGameID: gameID, StateToCheck: state}},
+			&BatchRequest{GetChanceExpansion: &GetChanceExpansionRequest{BaseGameID: gameID, StateToCheck: state}},
+		)
+	}
+	for _, resp := range engine.SendBatch(requests) {
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+		if resp.GetMidGameScore != nil && !reflect.DeepEqual(resp.GetMidGameScore.Scores, expected.Scores) {
+			t.Fatalf("expected %#v, got %#v instead", expected.Scores, resp.GetMidGameScore.Scores)
+		}
+	}
+}
+
+func TestGameEngineStateCacheIsClearedWhenGameChanges(t *testing.T) {
+	engine, gameID, moves := startEngineWithStates(t, 100)
+
+	resp := engine.SendPlayTurnBatch([]*PlayTurnRequest{{GameID: gameID, Move: moves[0].Move}})[0]
+	if resp.Err() != nil {
+		t.Fatal(resp.Err().Error())
+	}
+
+	stats := engine.StateCacheStats()
+	if stats.Size != 0 {
+		t.Fatalf("expected %#v, got %#v instead", 0, stats.Size)
+	}
+}
+
+func TestGameEngineStateCacheEvictsLeastRecentlyUsedStates(t *testing.T) {
+	engine, gameID, moves := startEngineWithStates(t, 2)
+
+	stats := engine.StateCacheStats()
+	if stats.Size != 2 {
+		t.Fatalf("expected %#v, got %#v instead", 2, stats.Size)
+	}
+	if stats.Evictions != len(moves)-2 {
+		t.Fatalf("expected %#v, got %#v instead", len(moves)-2, stats.Evictions)
+	}
+
+	// only the last two states are still cached
+	requests := []*GetMidGameScoreRequest{
+		{BaseGameID: gameID, StateToCheck: moves[len(moves)-1].State},
+		{BaseGameID: gameID, StateToCheck: moves[0].State},
+	}
+	for _, req := range requests {
+		resp := engine.SendGetMidGameScoreBatch([]*GetMidGameScoreRequest{req})[0]
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+	}
+
+	stats = engine.StateCacheStats()
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Fatalf("expected 1 hit and 1 miss, got %#v instead", stats)
+	}
+}
+
+func TestGameEngineReleaseStatesRemovesStatesFromCache(t *testing.T) {
+	engine, gameID, moves := startEngineWithStates(t, 100)
+
+	engine.ReleaseStates(gameID, []*GameState{moves[0].State, moves[1].State})
+
+	stats := engine.StateCacheStats()
+	if stats.Size != len(moves)-2 {
+		t.Fatalf("expected %#v, got %#v instead", len(moves)-2, stats.Size)
+	}
+}
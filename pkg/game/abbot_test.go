@@ -0,0 +1,125 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func TestBoardGetLegalMovesForPlacesAbbotOnlyOnGarden(t *testing.T) {
+	board := NewBoard(tilesets.GardensTileSet(), rules.Standard())
+	placement := elements.ToPlacedTile(tiletemplates.StraightRoadsGarden())
+	placement.Position = position.New(1, 0)
+
+	abbotMoveCount := 0
+	for _, move := range board.GetLegalMovesFor(placement) {
+		for _, feat := range move.Features {
+			if feat.Meeple.Type == elements.AbbotMeeple {
+				abbotMoveCount++
+				if feat.FeatureType != feature.Garden {
+					t.Fatalf("expected abbot on garden, got %#v instead", move)
+				}
+			} else if feat.Meeple.Type != elements.NoneMeeple && feat.FeatureType == feature.Garden {
+				t.Fatalf("expected only abbot on garden, got %#v instead", move)
+			}
+		}
+	}
+
+	if abbotMoveCount != 1 {
+		t.Fatalf("expected %#v, got %#v instead", 1, abbotMoveCount)
+	}
+}
+
+func TestGamePlayTurnRecallsAbbotAndScoresIncompleteGarden(t *testing.T) {
+	tileList := []tiles.Tile{
+		tiletemplates.StraightRoadsGarden(),
+		tiletemplates.StraightRoads(),
+		tiletemplates.StraightRoads(),
+	}
+	deckStack := stack.NewOrdered(tileList)
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tiletemplates.SingleCityEdgeStraightRoads()}
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	game.EnableUndo()
+	abbotPosition := position.New(1, 0)
+
+	// player 1 places the abbot in the garden
+	gardenMove := elements.ToPlacedTile(tiletemplates.StraightRoadsGarden())
+	gardenMove.Position = abbotPosition
+	gardenMove.Garden().Meeple = elements.Meeple{Type: elements.AbbotMeeple, PlayerID: 1}
+	if err = game.PlayTurn(gardenMove); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// garden is surrounded by two tiles (including itself)
+	expectedScore := uint32(2)
+	if game.GetMidGameScore().ReceivedPoints[1] != expectedScore {
+		t.Fatalf("expected %#v, got %#v instead", expectedScore, game.GetMidGameScore().ReceivedPoints[1])
+	}
+
+	// player 2 cannot recall abbot of player 1
+	recallMove := elements.ToPlacedTile(tiletemplates.StraightRoads())
+	recallMove.Position = position.New(-1, 0)
+	recallMove.RecallAbbot = true
+	recallMove.RecalledAbbotPosition = abbotPosition
+	if err = game.PlayTurn(recallMove); !errors.Is(err, elements.ErrNoAbbotToRecall) {
+		t.Fatalf("expected %#v, got %#v instead", elements.ErrNoAbbotToRecall, err)
+	}
+	recallMove.RecallAbbot = false
+	if err = game.PlayTurn(recallMove); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// player 1 recalls the abbot instead of placing a meeple
+	placement := elements.ToPlacedTile(tiletemplates.StraightRoads())
+	placement.Position = position.New(2, 0)
+	found := false
+	for _, move := range game.GetLegalMovesFor(placement) {
+		if move.RecallAbbot && move.RecalledAbbotPosition == abbotPosition {
+			found = true
+			recallMove = move
+		}
+	}
+	if !found {
+		t.Fatalf("expected abbot recall in legal moves")
+	}
+	if err = game.PlayTurn(recallMove); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	player := game.GetPlayerByID(1)
+	expectedScore = uint32(3)
+	if player.Score() != expectedScore {
+		t.Fatalf("expected %#v, got %#v instead", expectedScore, player.Score())
+	}
+	if player.MeepleCount(elements.AbbotMeeple) != 1 {
+		t.Fatalf("expected %#v, got %#v instead", 1, player.MeepleCount(elements.AbbotMeeple))
+	}
+	if _, ok := game.board.GetAbbotPosition(1); ok {
+		t.Fatalf("expected abbot to be removed from the board")
+	}
+
+	if err = game.UndoTurn(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if player.Score() != 0 {
+		t.Fatalf("expected %#v, got %#v instead", 0, player.Score())
+	}
+	if player.MeepleCount(elements.AbbotMeeple) != 0 {
+		t.Fatalf("expected %#v, got %#v instead", 0, player.MeepleCount(elements.AbbotMeeple))
+	}
+	if actual, ok := game.board.GetAbbotPosition(1); !ok || actual != abbotPosition {
+		t.Fatalf("expected %#v, got %#v instead", abbotPosition, actual)
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/field"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature/modifier"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
 )
@@ -21,8 +23,10 @@ var (
 		feature.City:      (*board).cityCanBePlaced,
 		feature.Field:     (*board).fieldCanBePlaced,
 		feature.Monastery: (*board).monasteryCanBePlaced,
+		feature.River:     (*board).riverCanBePlaced,
+		feature.Garden:    (*board).gardenCanBePlaced,
 	}
-	meepleTypes = []elements.MeepleType{elements.NormalMeeple}
+	meepleTypes = []elements.MeepleType{elements.NormalMeeple, elements.BigMeeple, elements.AbbotMeeple}
 )
 
 // mutable type
@@ -34,6 +38,7 @@ var (
 // Starting tile is placed at (+0, +0) position.
 type board struct {
 	tileSet tilesets.TileSet
+	ruleset rules.Ruleset
 	// The information about the tile and its placement is stored sparsely
 	// in a slice of size equal to the number of tiles in the set.
 	// `tiles[0]` is always the starting tile.
@@ -45,9 +50,28 @@ type board struct {
 
 	placeablePositions []position.Position
 	cityManager        city.Manager
+
+	// Changes made by each of the PlaceTile() calls, used by UndoPlaceTile().
+	// Only recorded after EnableUndo() has been called.
+	history     []boardUpdate
+	keepHistory bool
+}
+
+// Information needed to revert a single PlaceTile() call.
+type boardUpdate struct {
+	tileIndex          int
+	placeablePositions []position.Position
+	// meeples removed from the board after the tile was placed, in order of removal
+	removedMeeples []removedMeeple
 }
 
-func NewBoard(tileSet tilesets.TileSet) elements.Board {
+type removedMeeple struct {
+	position     position.Position
+	featureIndex int
+	meeple       elements.Meeple
+}
+
+func NewBoard(tileSet tilesets.TileSet, ruleset rules.Ruleset) elements.Board {
 	tiles := make([]elements.PlacedTile, len(tileSet.Tiles)+1)
 	startingTile := elements.NewStartingTile(tileSet)
 	tiles[0] = startingTile
@@ -55,6 +79,7 @@ func NewBoard(tileSet tilesets.TileSet) elements.Board {
 	cityManager.UpdateCities(startingTile)
 	return &board{
 		tileSet: tileSet,
+		ruleset: ruleset,
 		tiles:   tiles,
 		tilesMap: map[position.Position]elements.PlacedTile{
 			position.New(0, 0): startingTile,
@@ -70,7 +95,7 @@ func NewBoard(tileSet tilesets.TileSet) elements.Board {
 }
 
 func (board board) DeepClone() elements.Board {
-	// note: skipped board.tileSet because TileSet is immutable
+	// note: skipped board.tileSet and board.ruleset because they are immutable
 
 	tilesMap := map[position.Position]elements.PlacedTile{}
 	tiles := make([]elements.PlacedTile, len(board.tileSet.Tiles)+1)
@@ -98,6 +123,10 @@ func (board board) DeepClone() elements.Board {
 
 	board.cityManager = board.cityManager.DeepClone()
 
+	// turns played before cloning cannot be undone on the clone
+	board.history = nil
+	board.keepHistory = false
+
 	return &board
 }
 
@@ -163,7 +192,7 @@ func (board *board) GetLegalMovesFor(basePlacement elements.PlacedTile) []elemen
 
 			// Doing this for every meeple type may be suboptimal, if more meeple types
 			// are added but that's not very likely at current time.
-			if canBePlacedFunctions[feat.FeatureType](board, placement, feat) {
+			if board.meepleCanBePlaced(placement, feat) {
 				moves = append(moves, placement)
 			}
 		}
@@ -180,6 +209,8 @@ Only checks the validity of the tile placement based on the tile features and th
 - Whether the tile is being placed on an already occupied position
 - Whether the tile is not neighboring any tiles
 - Whether the tile has already been placed somewhere else on the board
+
+River tiles additionally have to continue the river, see isRiverPlacementValid().
 */
 func (board *board) isPositionValid(tile elements.PlacedTile) bool {
 	// Phase 1:
@@ -202,22 +233,107 @@ func (board *board) isPositionValid(tile elements.PlacedTile) bool {
 	// Since some features may overlap other features, it is also necessary to check
 	// that none of the neighbours have an (overlapping) feature that doesn't have
 	// a matching counterpart on the given tile.
-	// Currently, overlap can only occur between roads and fields. Since roads
-	// are always accompanied by fields, we only need to check roads.
-	//
-	// TODO: rivers will probably have the same problems as roads, if they are implemented
+	// Currently, overlap can only occur between fields and roads or rivers.
+	// Since roads and rivers are always accompanied by fields,
+	// we only need to check roads and rivers.
 	for _, side := range side.PrimarySides {
 		neighbourPosition := position.FromSide(side).Add(tile.Position)
 		neighbouringTile, exists := board.GetTileAt(neighbourPosition)
-		if exists && neighbouringTile.GetPlacedFeatureAtSide(side.Mirror(), feature.Road) != nil {
-			if tile.GetPlacedFeatureAtSide(side, feature.Road) == nil {
-				return false
+		if !exists {
+			continue
+		}
+		for _, featureType := range []feature.Type{feature.Road, feature.River} {
+			if neighbouringTile.GetPlacedFeatureAtSide(side.Mirror(), featureType) != nil {
+				if tile.GetPlacedFeatureAtSide(side, featureType) == nil {
+					return false
+				}
 			}
 		}
 	}
+	return board.isRiverPlacementValid(tile)
+}
+
+/*
+Returns true if the tile doesn't have a river or if its river continues the river
+already placed on the board without turning in the same direction
+as the previous turn of the river did (which could make the river turn back on itself).
+*/
+func (board *board) isRiverPlacementValid(tile elements.PlacedTile) bool {
+	var river *elements.PlacedFeature
+	for i, feat := range tile.Features {
+		if feat.FeatureType == feature.River {
+			river = &tile.Features[i]
+			break
+		}
+	}
+	if river == nil {
+		return true
+	}
+
+	// the river has to flow in from one of the neighbouring tiles
+	inSide := side.NoSide
+	for _, side := range side.PrimarySides {
+		if !river.Sides.HasSide(side) {
+			continue
+		}
+		if _, exists := board.GetTileAt(position.FromSide(side).Add(tile.Position)); exists {
+			inSide = side
+			break
+		}
+	}
+	if inSide == side.NoSide {
+		return false
+	}
+
+	outSide := river.Sides &^ inSide
+	if outSide == side.NoSide {
+		// the river ends on this tile
+		return true
+	}
+	turn := getRiverTurn(inSide, outSide)
+	if turn == 0 {
+		return true
+	}
+
+	// follow the river upstream to find its previous turn
+	currentPosition := tile.Position
+	for range board.TileCount() {
+		currentPosition = currentPosition.Add(position.FromSide(inSide))
+		upstreamTile, exists := board.GetTileAt(currentPosition)
+		if !exists {
+			return true
+		}
+		outSide = inSide.Mirror()
+		upstreamRiver := upstreamTile.GetPlacedFeatureAtSide(outSide, feature.River)
+		if upstreamRiver == nil {
+			return true
+		}
+		inSide = upstreamRiver.Sides &^ outSide
+		if inSide == side.NoSide {
+			// reached the spring
+			return true
+		}
+		if previousTurn := getRiverTurn(inSide, outSide); previousTurn != 0 {
+			return previousTurn != turn
+		}
+	}
 	return true
 }
 
+// Returns 0 if the river flowing in from inSide and out through outSide goes straight,
+// 1 if it turns right, and -1 if it turns left.
+func getRiverTurn(inSide side.Side, outSide side.Side) int {
+	heading := inSide.Rotate(2)
+	switch outSide {
+	case heading:
+		return 0
+	case heading.Rotate(1):
+		return 1
+	default:
+		return -1
+	}
+}
+
 func (board *board) CanBePlaced(tile elements.PlacedTile) bool {
 	if !board.isPositionValid(tile) {
 		return false
@@ -243,8 +359,18 @@ func (board *board) CanBePlaced(tile elements.PlacedTile) bool {
 		}
 	}
 
-	for featureType, feat := range featuresWithMeeples {
-		if !canBePlacedFunctions[featureType](board, tile, feat) {
+	for _, feat := range featuresWithMeeples {
+		if !board.meepleCanBePlaced(tile, feat) {
+			return false
+		}
+	}
+
+	if tile.RecallAbbot {
+		// the abbot is recalled instead of placing a meeple
+		if meepleCount != 0 {
+			return false
+		}
+		if _, ok := board.getAbbotAt(tile.RecalledAbbotPosition); !ok {
 			return false
 		}
 	}
@@ -252,6 +378,81 @@ func (board *board) CanBePlaced(tile elements.PlacedTile) bool {
 	return true
 }
 
+// Returns the abbot placed on the monastery or garden of the tile at the given position.
+func (board *board) getAbbotAt(pos position.Position) (elements.Meeple, bool) {
+	tile, ok := board.GetTileAt(pos)
+	if !ok {
+		return elements.Meeple{}, false
+	}
+	for _, feat := range tile.Features {
+		if feat.Meeple.Type == elements.AbbotMeeple {
+			return feat.Meeple, true
+		}
+	}
+	return elements.Meeple{}, false
+}
+
+func (board *board) GetAbbotPosition(playerID elements.ID) (position.Position, bool) {
+	for _, tile := range board.Tiles() {
+		for _, feat := range tile.Features {
+			if feat.Meeple.Type == elements.AbbotMeeple && feat.Meeple.PlayerID == playerID {
+				return tile.Position, true
+			}
+		}
+	}
+	return position.Position{}, false
+}
+
+// Checks whether the meeple of the given feature can be placed on it.
+//
+// Followers can only be placed on unoccupied features while builder and pig
+// can only join their owner's follower (on a road or city and a field respectively).
+// Abbot can only be placed on a monastery or a garden.
+func (board *board) meepleCanBePlaced(tile elements.PlacedTile, feat elements.PlacedFeature) bool {
+	switch feat.Meeple.Type {
+	case elements.AbbotMeeple:
+		return feat.FeatureType == feature.Monastery || feat.FeatureType == feature.Garden
+	case elements.BuilderMeeple:
+		if feat.FeatureType != feature.Road && feat.FeatureType != feature.City {
+			return false
+		}
+	case elements.PigMeeple:
+		if feat.FeatureType != feature.Field {
+			return false
+		}
+	default:
+		return canBePlacedFunctions[feat.FeatureType](board, tile, feat)
+	}
+
+	for _, meeple := range board.GetMeeplesOnConnectedFeature(tile, feat) {
+		if meeple.PlayerID == feat.Meeple.PlayerID && meeple.Type.Strength() != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (board *board) GetMeeplesOnConnectedFeature(
+	tile elements.PlacedTile, feat elements.PlacedFeature,
+) []elements.MeepleWithPosition {
+	switch feat.FeatureType {
+	case feature.Road:
+		return board.getMeeplesOnConnectedRoads(tile, feat)
+	case feature.City:
+		return board.cityManager.GetMeeplesOnCitiesToJoin(tile, feat)
+	case feature.Field:
+		meeples := []elements.MeepleWithPosition{}
+		for _, meeple := range field.New(feat, tile).FindMeeples(board) {
+			if meeple.Position != tile.Position {
+				meeples = append(meeples, meeple)
+			}
+		}
+		return meeples
+	default:
+		return []elements.MeepleWithPosition{}
+	}
+}
+
 func (board *board) cityCanBePlaced(tile elements.PlacedTile, feat elements.PlacedFeature) bool {
 	return board.cityManager.CanBePlaced(tile, feat)
 }
@@ -271,7 +472,22 @@ func (board *board) monasteryCanBePlaced(_ elements.PlacedTile, _ elements.Place
 	return true
 }
 
+func (board *board) gardenCanBePlaced(_ elements.PlacedTile, _ elements.PlacedFeature) bool {
+	// only the abbot can be placed on a garden
+	return false
+}
+
+func (board *board) riverCanBePlaced(_ elements.PlacedTile, _ elements.PlacedFeature) bool {
+	// meeple can never be placed on a river
+	return false
+}
+
 func (board *board) roadCanBePlaced(checkedTile elements.PlacedTile, checkedRoad elements.PlacedFeature) bool {
+	return len(board.getMeeplesOnConnectedRoads(checkedTile, checkedRoad)) == 0
+}
+
+func (board *board) getMeeplesOnConnectedRoads(checkedTile elements.PlacedTile, checkedRoad elements.PlacedFeature) []elements.MeepleWithPosition {
+	meeples := []elements.MeepleWithPosition{}
 	// get the two sides connected by the road which we will use to
 	// score roads on the neighbouring tiles (but not the tile itself)
 	sides := []side.Side{
@@ -287,8 +503,8 @@ func (board *board) roadCanBePlaced(checkedTile elements.PlacedTile, checkedRoad
 			continue
 		}
 
-		// an existing tile found on this side of the road - we need to check,
-		// if they have *any* meeple placed
+		// an existing tile found on this side of the road - we need to find
+		// *all* meeples placed on it
 		neighbourRoadSide := checkedRoadSide.Mirror()
 		neighbourRoad := neighbourTile.GetPlacedFeatureAtSide(neighbourRoadSide, feature.Road)
 		// score function checks the whole road for placed meeples
@@ -298,12 +514,12 @@ func (board *board) roadCanBePlaced(checkedTile elements.PlacedTile, checkedRoad
 			neighbourRoad.Feature,
 			true,
 		)
-		if len(scoreReport.ReturnedMeeples) != 0 {
-			return false
+		for _, returnedMeeples := range scoreReport.ReturnedMeeples {
+			meeples = append(meeples, returnedMeeples...)
 		}
 	}
 
-	return true
+	return meeples
 }
 
 // Add a tile to the board and propagate feature completion
@@ -319,7 +535,28 @@ func (board *board) PlaceTile(tile elements.PlacedTile) (elements.ScoreReport, e
 	if err != nil {
 		return elements.ScoreReport{}, err
 	}
-	return board.checkCompleted(tile), nil
+	scoreReport := board.checkCompleted(tile)
+	if tile.RecallAbbot {
+		scoreReport.Join(board.recallAbbot(tile.RecalledAbbotPosition))
+	}
+	return scoreReport, nil
+}
+
+// Removes the abbot from the tile at the given position and scores its
+// (most likely incomplete) monastery or garden.
+// The report is empty, if the abbot was already removed when its feature got completed.
+func (board *board) recallAbbot(pos position.Position) elements.ScoreReport {
+	if _, ok := board.getAbbotAt(pos); !ok {
+		return elements.NewScoreReport()
+	}
+	tile, _ := board.GetTileAt(pos)
+	scoreReport, err := board.scoreSingleMonastery(tile, true)
+	if err != nil {
+		// getAbbotAt() guarantees that there's a meeple on the monastery or garden
+		panic(err)
+	}
+	board.removeMeeple(pos)
+	return scoreReport
 }
 
 // Add a tile to the board without propagating feature completion to
@@ -349,6 +586,12 @@ func (board *board) addTileToBoard(tile elements.PlacedTile) error {
 		setTiles = setTiles[index+1:]
 	}
 
+	if board.keepHistory {
+		board.history = append(board.history, boardUpdate{
+			tileIndex:          actualIndex,
+			placeablePositions: slices.Clone(board.placeablePositions),
+		})
+	}
 	board.updateValidPlacements(tile)
 	board.tiles[actualIndex] = tile
 	board.tilesMap[tile.Position] = tile
@@ -356,10 +599,54 @@ func (board *board) addTileToBoard(tile elements.PlacedTile) error {
 	return nil
 }
 
+func (board *board) EnableUndo() {
+	board.keepHistory = true
+	board.cityManager.EnableUndo()
+}
+
+// Remove the tile placed by the last PlaceTile() call from the board,
+// restoring the meeples removed by the completion of features (or final scoring)
+// and the cities that the tile joined.
+//
+// Anything not managed by the board, such as players, will need to be updated
+// by the caller.
+func (board *board) UndoPlaceTile() error {
+	if len(board.history) == 0 {
+		return elements.ErrNoTurnToUndo
+	}
+	if err := board.cityManager.UndoUpdate(); err != nil {
+		return err
+	}
+
+	last := len(board.history) - 1
+	update := board.history[last]
+	board.history = board.history[:last]
+
+	for i := len(update.removedMeeples) - 1; i >= 0; i-- {
+		removed := update.removedMeeples[i]
+		board.tilesMap[removed.position].Features[removed.featureIndex].Meeple = removed.meeple
+	}
+
+	tile := board.tiles[update.tileIndex]
+	delete(board.tilesMap, tile.Position)
+	board.tiles[update.tileIndex] = elements.PlacedTile{}
+	board.placeablePositions = update.placeablePositions
+
+	return nil
+}
+
 func (board *board) removeMeeple(pos position.Position) {
 	placedTile := board.tilesMap[pos]
 	for featureIndex, feature := range placedTile.Features {
 		if feature.Meeple.Type != elements.NoneMeeple {
+			if len(board.history) != 0 {
+				update := &board.history[len(board.history)-1]
+				update.removedMeeples = append(update.removedMeeples, removedMeeple{
+					position:     pos,
+					featureIndex: featureIndex,
+					meeple:       feature.Meeple,
+				})
+			}
 			placedTile.Features[featureIndex].Meeple = elements.Meeple{Type: elements.NoneMeeple, PlayerID: elements.ID(0)}
 			break
 		}
@@ -389,7 +676,7 @@ func (board *board) updateValidPlacements(tile elements.PlacedTile) {
 func (board *board) checkCompleted(tile elements.PlacedTile) elements.ScoreReport {
 	scoreReport := elements.NewScoreReport()
 	board.cityManager.UpdateCities(tile)
-	scoreReport.Join(board.cityManager.ScoreCities(false))
+	scoreReport.Join(board.cityManager.ScoreCities(board.ruleset, false))
 	scoreReport.Join(board.scoreRoads(tile, false))
 	scoreReport.Join(board.scoreMonasteries(tile, false))
 
@@ -403,8 +690,8 @@ func (board *board) checkCompleted(tile elements.PlacedTile) elements.ScoreRepor
 }
 
 /*
-Calculates score for a single monastery.
-If the monastery is finished and has a meeple, returns a ScoreReport with 9 points and the meeple that was in the monastery.
+Calculates score for a single monastery (or garden which is scored the same way).
+If the monastery is finished and has a meeple, returns a ScoreReport with 9 tiles' worth of points and the meeple that was in the monastery.
 Otherwise, returns an empty ScoreReport.
 
 'forceScore' can be set to true to score unfinished monasteries at the end of the game.
@@ -415,7 +702,10 @@ returns: ScoreReport (with one player at most)
 func (board *board) scoreSingleMonastery(tile elements.PlacedTile, forceScore bool) (elements.ScoreReport, error) {
 	var monasteryFeature = tile.Monastery()
 	if monasteryFeature == nil {
-		return elements.ScoreReport{}, errors.New("scoreSingleMonastery() called on a tile without a monastery")
+		monasteryFeature = tile.Garden()
+	}
+	if monasteryFeature == nil {
+		return elements.ScoreReport{}, errors.New("scoreSingleMonastery() called on a tile without a monastery or garden")
 	}
 	if monasteryFeature.Meeple.Type == elements.NoneMeeple {
 		return elements.ScoreReport{}, errors.New("scoreSingleMonastery() called on a tile without a meeple")
@@ -432,6 +722,11 @@ func (board *board) scoreSingleMonastery(tile elements.PlacedTile, forceScore bo
 	}
 
 	if score == 9 || forceScore {
+		if score == 9 {
+			score *= board.ruleset.MonasteryTilePoints.Completed
+		} else {
+			score *= board.ruleset.MonasteryTilePoints.Incomplete
+		}
 		scoreReport := elements.NewScoreReport()
 		scoreReport.ReceivedPoints[monasteryFeature.Meeple.PlayerID] = score
 		scoreReport.ReturnedMeeples[monasteryFeature.Meeple.PlayerID] = []elements.MeepleWithPosition{
@@ -448,7 +743,7 @@ func (board *board) scoreSingleMonastery(tile elements.PlacedTile, forceScore bo
 }
 
 /*
-Finds all tiles with a monastery and a meeple in it adjacent to 'tile' (and 'tile' itself) and calls scoreSingleMonastery on each of them.
+Finds all tiles with a monastery (or garden) and a meeple in it adjacent to 'tile' (and 'tile' itself) and calls scoreSingleMonastery on each of them.
 This function should be called after the placement of each tile, in case it neighbours a monastery.
 
 returns: ScoreReport
@@ -475,11 +770,12 @@ func (board *board) scoreMonasteries(tile elements.PlacedTile, forceScore bool)
 It analyzes road directed by roadSide parameter.
 It doesn't analyze starting tile.
 param roadSide: always indicates only one cardinal direction!
-returns: road_finished, score, [meeples on road], loop, sideFinishedOn
+returns: road_finished, score, [meeples on road], loop, sideFinishedOn, finishedPosition, has_inn
 sideFinishedOn matters only if loop is True. Variable used to prevent checking the same road twice in scoreRoads function
 */
-func (board *board) checkRoadInDirection(roadSide side.Side, startTile elements.PlacedTile) (bool, int, []elements.MeepleWithPosition, bool, side.Side, position.Position) {
+func (board *board) checkRoadInDirection(roadSide side.Side, startTile elements.PlacedTile) (bool, int, []elements.MeepleWithPosition, bool, side.Side, position.Position, bool) {
 	var meeples = []elements.MeepleWithPosition{}
+	var hasInn bool
 	var tile = startTile
 	var tileExists bool
 	var score = 0
@@ -510,11 +806,14 @@ func (board *board) checkRoadInDirection(roadSide side.Side, startTile elements.
 			// a finished road may end up connecting them resulting in some sides being unchecked.
 			// Therefore, we need to add any meeple present on the other side of the road,
 			// if it is not part of the feature we started in.
-			if !road.Sides.HasSide(startRoadSide) && road.Meeple.Type != elements.NoneMeeple {
-				meeples = append(meeples, elements.NewMeepleWithPosition(
-					road.Meeple,
-					tile.Position),
-				)
+			if !road.Sides.HasSide(startRoadSide) {
+				if road.Meeple.Type != elements.NoneMeeple {
+					meeples = append(meeples, elements.NewMeepleWithPosition(
+						road.Meeple,
+						tile.Position),
+					)
+				}
+				hasInn = hasInn || road.ModifierType == modifier.Inn
 			}
 			// We're back at the start tile which means we reached a loop or a crossroad.
 			// Nothing more to do - the score for the start tile is counted by the caller
@@ -523,6 +822,7 @@ func (board *board) checkRoadInDirection(roadSide side.Side, startTile elements.
 		}
 
 		score++
+		hasInn = hasInn || road.ModifierType == modifier.Inn
 
 		// check if there is meeple on the feature
 		if road.Meeple.Type != elements.NoneMeeple {
@@ -541,21 +841,23 @@ func (board *board) checkRoadInDirection(roadSide side.Side, startTile elements.
 
 	}
 
-	looped := (tile.Position == startTile.Position)
+	// the missing tile is a zero value which is at the position of the starting tile
+	looped := tileExists && tile.Position == startTile.Position
 	finished = tileExists && (road.Sides.GetCardinalDirectionsLength() == 1 || looped)
 
-	return finished, score, meeples, looped, roadSide, pos
+	return finished, score, meeples, looped, roadSide, pos, hasInn
 }
 
 /*
-Calculates score for road.
+Calculates score for road (using the points from the ruleset).
+A road with an inn is worth double the points when finished but it is worth nothing when unfinished.
 
 returns: ScoreReport, checked sides of the start tile (also including loop)
 */
 func (board *board) scoreRoadCompletion(tile elements.PlacedTile, road feature.Feature, forceScore bool) (elements.ScoreReport, side.Side) {
 	var meeples = []elements.MeepleWithPosition{}
 	var leftSide, rightSide side.Side
-	var score = 1
+	var tileCount = 1
 	leftSide = road.Sides.GetNthCardinalDirection(0)  // first side
 	rightSide = road.Sides.GetNthCardinalDirection(1) // second side
 	var roadFinished = true
@@ -565,6 +867,7 @@ func (board *board) scoreRoadCompletion(tile elements.PlacedTile, road feature.F
 	var meeplesResult []elements.MeepleWithPosition
 	var loopResult bool
 	var loopSide side.Side
+	var innResult bool
 
 	// check meeples on start tile
 	var roadLeft = tile.GetPlacedFeatureAtSide(leftSide, feature.Road)
@@ -587,18 +890,22 @@ func (board *board) scoreRoadCompletion(tile elements.PlacedTile, road feature.F
 		)
 	}
 
+	hasInn := roadLeft.ModifierType == modifier.Inn || (roadRight != nil && roadRight.ModifierType == modifier.Inn)
+
 	// check road in "left" direction
-	roadFinishedResult, scoreResult, meeplesResult, loopResult, loopSide, finishedPosLeft := board.checkRoadInDirection(leftSide, tile)
-	score += scoreResult
+	roadFinishedResult, scoreResult, meeplesResult, loopResult, loopSide, finishedPosLeft, innResult := board.checkRoadInDirection(leftSide, tile)
+	tileCount += scoreResult
 	roadFinished = roadFinished && roadFinishedResult
 	meeples = append(meeples, meeplesResult...)
+	hasInn = hasInn || innResult
 
 	// check road in "right" direction
 	if !loopResult && rightSide != side.NoSide {
-		roadFinishedResult, scoreResult, meeplesResult, _, _, finishedPosRight := board.checkRoadInDirection(rightSide, tile)
-		score += scoreResult
+		roadFinishedResult, scoreResult, meeplesResult, _, _, finishedPosRight, innResult := board.checkRoadInDirection(rightSide, tile)
+		tileCount += scoreResult
 		roadFinished = roadFinished && roadFinishedResult
 		meeples = append(meeples, meeplesResult...)
+		hasInn = hasInn || innResult
 
 		// Decrement the score to prevent counting the tile twice
 		// when its road features (two different ones) are both the start
@@ -608,12 +915,23 @@ func (board *board) scoreRoadCompletion(tile elements.PlacedTile, road feature.F
 		// with no end or beginning) where we don't fall into this `if` branch at all
 		// due to `loopResult` being `true`.
 		if finishedPosLeft == finishedPosRight {
-			score--
+			tileCount--
 		}
 	}
 
 	// -------- start counting -------------
 	if roadFinished || forceScore {
+		var score int
+		switch {
+		case hasInn && roadFinished:
+			score = tileCount * int(board.ruleset.RoadTilePoints.Completed) * 2
+		case hasInn:
+			score = 0
+		case roadFinished:
+			score = tileCount * int(board.ruleset.RoadTilePoints.Completed)
+		default:
+			score = tileCount * int(board.ruleset.RoadTilePoints.Incomplete)
+		}
 		if loopResult {
 			return elements.CalculateScoreReportOnMeeples(score, meeples), leftSide | rightSide | loopSide
 		}
@@ -655,7 +973,7 @@ func (board *board) ScoreMeeples(final bool) elements.ScoreReport {
 	meeplesReport := elements.NewScoreReport()
 
 	// score cities first (because they have their own manager)
-	meeplesReport.Join(board.cityManager.ScoreCities(true))
+	meeplesReport.Join(board.cityManager.ScoreCities(board.ruleset, true))
 
 	if final {
 		// remove city meeples from board
@@ -666,6 +984,9 @@ func (board *board) ScoreMeeples(final bool) elements.ScoreReport {
 		}
 	}
 
+	// fields scored by cities can only be scored once all of them are found
+	fieldsToScoreByCities := []field.Field{}
+
 	// score meeples left on the board (fields, monasteries, roads)
 	for _, pTile := range board.Tiles() {
 		for _, feat := range pTile.Features {
@@ -678,8 +999,14 @@ func (board *board) ScoreMeeples(final bool) elements.ScoreReport {
 				case feature.Field:
 					field := field.New(feat, pTile)
 					field.Expand(board, board.cityManager)
-					miniReport.Join(field.GetScoreReport())
-				case feature.Monastery:
+					if board.ruleset.FarmScoring == rules.CityFarmScoring {
+						fieldsToScoreByCities = append(fieldsToScoreByCities, field)
+						// only return the farmers, the points are added below
+						miniReport.Join(elements.CalculateScoreReportOnMeeples(0, field.Meeples()))
+					} else {
+						miniReport.Join(field.GetScoreReport(board.ruleset))
+					}
+				case feature.Monastery, feature.Garden:
 					miniReport.Join(board.scoreMonasteries(pTile, true))
 				}
 			}
@@ -695,6 +1022,7 @@ func (board *board) ScoreMeeples(final bool) elements.ScoreReport {
 			meeplesReport.Join(miniReport)
 		}
 	}
+	meeplesReport.Join(field.GetCityScoreReport(fieldsToScoreByCities, board.ruleset))
 
 	return meeplesReport
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/test"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
@@ -28,7 +29,7 @@ func TestBoardDeepClone(t *testing.T) {
 		Type:     elements.NormalMeeple,
 	}
 
-	original := NewBoard(tilesets.StandardTileSet()).(*board)
+	original := NewBoard(tilesets.StandardTileSet(), rules.Standard()).(*board)
 	// add a tile with meeple to verify that it's still there on the original later
 	ptile := elements.ToPlacedTile(tiletemplates.TwoCityEdgesUpAndDownConnected())
 	ptile.Position = expectedMeeplePos
@@ -102,7 +103,7 @@ func TestBoardDeepClone(t *testing.T) {
 func TestBoardTileCountReturnsOnlyPlacedTiles(t *testing.T) {
 	// starting tile has a city on top, we want to close it with a single city tile
 	// and then try finding legal moves of a tile filled with a city terrain
-	board := NewBoard(tilesets.StandardTileSet())
+	board := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	_, err := board.PlaceTile(test.GetTestPlacedTile())
 	if err != nil {
 		t.Fatal(err.Error())
@@ -119,7 +120,7 @@ func TestBoardTileCountReturnsOnlyPlacedTiles(t *testing.T) {
 func TestBoardGetTilePlacementsForReturnsEmptySliceWhenCityCannotBePlaced(t *testing.T) {
 	// starting tile has a city on top, we want to close it with a single city tile
 	// and then try finding legal moves of a tile filled with a city terrain
-	board := NewBoard(tilesets.StandardTileSet())
+	board := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	ptile := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads().Rotate(2))
 	ptile.Position = position.New(0, 1)
 	_, err := board.PlaceTile(ptile)
@@ -136,7 +137,7 @@ func TestBoardGetTilePlacementsForReturnsEmptySliceWhenCityCannotBePlaced(t *tes
 }
 
 func TestBoardTileHasValidPlacementReturnsTrueWhenValidPlacementExists(t *testing.T) {
-	board := NewBoard(tilesets.StandardTileSet())
+	board := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 
 	expected := true
 	actual := board.TileHasValidPlacement(tiletemplates.SingleCityEdgeNoRoads())
@@ -149,7 +150,7 @@ func TestBoardTileHasValidPlacementReturnsTrueWhenValidPlacementExists(t *testin
 func TestBoardGetLegalMovesForDoesNotIncludeInvalidMeeplePlacements(t *testing.T) {
 	// starting tile has a city on top, we want to expand it with an unclosed city
 	// and then try finding legal moves for a tile with a city and some other feature.
-	board := NewBoard(tilesets.StandardTileSet())
+	board := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	ptile := elements.ToPlacedTile(tiletemplates.TwoCityEdgesUpAndDownConnected())
 	ptile.Position = position.New(0, 1)
 	ptile.GetPlacedFeatureAtSide(side.Top, feature.City).Meeple = elements.Meeple{
@@ -168,8 +169,12 @@ func TestBoardGetLegalMovesForDoesNotIncludeInvalidMeeplePlacements(t *testing.T
 	placementWithMeeple.GetPlacedFeatureAtSide(
 		side.Top, feature.Field,
 	).Meeple = elements.Meeple{Type: elements.NormalMeeple}
+	placementWithBigMeeple := basePlacement.DeepClone()
+	placementWithBigMeeple.GetPlacedFeatureAtSide(
+		side.Top, feature.Field,
+	).Meeple = elements.Meeple{Type: elements.BigMeeple}
 
-	expected := []elements.PlacedTile{basePlacement, placementWithMeeple}
+	expected := []elements.PlacedTile{basePlacement, placementWithMeeple, placementWithBigMeeple}
 	actual := board.GetLegalMovesFor(basePlacement)
 
 	if !reflect.DeepEqual(expected, actual) {
@@ -178,7 +183,7 @@ func TestBoardGetLegalMovesForDoesNotIncludeInvalidMeeplePlacements(t *testing.T
 }
 
 func TestBoardCanBePlacedReturnsTrueWhenPlacedTileCanBePlaced(t *testing.T) {
-	board := NewBoard(tilesets.StandardTileSet())
+	board := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 
 	expected := true
 	actual := board.CanBePlaced(test.GetTestPlacedTile())
@@ -189,7 +194,7 @@ func TestBoardCanBePlacedReturnsTrueWhenPlacedTileCanBePlaced(t *testing.T) {
 }
 
 func TestBoardCanBePlacedReturnsFalseWhenMultipleFeaturesHaveMeeples(t *testing.T) {
-	board := NewBoard(tilesets.StandardTileSet())
+	board := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	ptile := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads().Rotate(2))
 	ptile.Position = position.New(0, 1)
 	ptile.Features[0].Meeple = elements.Meeple{Type: elements.NormalMeeple, PlayerID: 1}
@@ -204,7 +209,7 @@ func TestBoardCanBePlacedReturnsFalseWhenMultipleFeaturesHaveMeeples(t *testing.
 }
 
 func TestBoardCanBePlacedReturnsFalseWhenPlacingAtInvalidPosition(t *testing.T) {
-	board := NewBoard(tilesets.StandardTileSet())
+	board := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	ptile := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads().Rotate(2))
 	ptile.Position = position.New(0, 2)
 
@@ -217,7 +222,7 @@ func TestBoardCanBePlacedReturnsFalseWhenPlacingAtInvalidPosition(t *testing.T)
 }
 
 func TestBoardFieldCanBePlacedReturnsFalseWhenExpandToFieldWithMeepleHappensOverAnotherField(t *testing.T) {
-	board := NewBoard(tilesets.StandardTileSet()).(*board)
+	board := NewBoard(tilesets.StandardTileSet(), rules.Standard()).(*board)
 
 	// prepare board layout (graphical representation can be found in issue GH-86)
 	tilesToPlace := []elements.PlacedTile{}
@@ -260,7 +265,7 @@ func TestBoardFieldCanBePlacedReturnsFalseWhenExpandToFieldWithMeepleHappensOver
 func TestBoardPlaceTileErrorsWhenCapacityIsExceeded(t *testing.T) {
 	tileSet := tilesets.StandardTileSet()
 	tileSet.Tiles = []tiles.Tile{}
-	board := NewBoard(tileSet)
+	board := NewBoard(tileSet, rules.Standard())
 
 	_, err := board.PlaceTile(test.GetTestPlacedTile())
 	if err == nil {
@@ -273,7 +278,7 @@ func TestBoardPlaceTileUpdatesBoardFields(t *testing.T) {
 	tileSet.Tiles = []tiles.Tile{
 		test.GetTestTile(), tiletemplates.FourCityEdgesConnectedShield(),
 	}
-	board := NewBoard(tileSet)
+	board := NewBoard(tileSet, rules.Standard())
 	expected := test.GetTestPlacedTile()
 
 	_, err := board.PlaceTile(expected)
@@ -299,7 +304,7 @@ func TestBoardPlaceTilePlacesTwoTilesOfSameTypeProperly(t *testing.T) {
 		tiletemplates.FourCityEdgesConnectedShield(),
 		test.GetTestTile(),
 	}
-	board := NewBoard(tileSet)
+	board := NewBoard(tileSet, rules.Standard())
 	startingPlacedTile := elements.NewStartingTile(tileSet)
 	expected := []elements.PlacedTile{
 		startingPlacedTile,
@@ -328,7 +333,7 @@ func TestBoardPlaceTilePlacesTwoTilesOfSameTypeProperly(t *testing.T) {
 }
 
 func TestIsPositionValidWhenPositionIsInvalid(t *testing.T) {
-	boardInterface := NewBoard(tilesets.StandardTileSet())
+	boardInterface := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -360,7 +365,7 @@ func TestIsPositionValidWhenPositionIsInvalid(t *testing.T) {
 }
 
 func TestIsPositionValidWhenPositionIsValid(t *testing.T) {
-	boardInterface := NewBoard(tilesets.StandardTileSet())
+	boardInterface := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -389,7 +394,7 @@ func TestBoardScoreIncompleteMonastery(t *testing.T) {
 	for range 3 {
 		extendedTileSet.Tiles = append(extendedTileSet.Tiles, tiletemplates.TestOnlyField())
 	}
-	boardInterface := NewBoard(extendedTileSet)
+	boardInterface := NewBoard(extendedTileSet, rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -463,7 +468,7 @@ func TestBoardCompleteTwoMonasteriesAtOnce(t *testing.T) {
 	for range 10 {
 		extendedTileSet.Tiles = append(extendedTileSet.Tiles, tiletemplates.TestOnlyField())
 	}
-	boardInterface := NewBoard(extendedTileSet)
+	boardInterface := NewBoard(extendedTileSet, rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -603,7 +608,7 @@ func TestScoreNotFinalMeeplesOnSameFeature(t *testing.T) {
 		tileSet.Tiles = append(tileSet.Tiles, elements.ToTile(tile))
 	}
 
-	boardInterface := NewBoard(tileSet)
+	boardInterface := NewBoard(tileSet, rules.Standard())
 	board := boardInterface.(*board)
 
 	// play all turns but one
@@ -630,7 +635,7 @@ func TestScoreNotFinalMeeplesOnIncompleteCity(t *testing.T) {
 	tileSet := tilesets.StandardTileSet()
 	tileSet.Tiles = []tiles.Tile{tiletemplates.TwoCityEdgesUpAndDownConnected()}
 
-	board := NewBoard(tileSet)
+	board := NewBoard(tileSet, rules.Standard())
 
 	ptile := elements.ToPlacedTile(tileSet.Tiles[0])
 	ptile.Position = position.New(0, 1)
@@ -656,7 +661,7 @@ func TestScoreNotFinalMeeplesOnFieldWithIncompleteCity(t *testing.T) {
 	tileSet := tilesets.StandardTileSet()
 	tileSet.Tiles = []tiles.Tile{tiletemplates.StraightRoads()}
 
-	board := NewBoard(tileSet)
+	board := NewBoard(tileSet, rules.Standard())
 
 	ptile := elements.ToPlacedTile(tileSet.Tiles[0])
 	ptile.Position = position.New(1, 0)
@@ -685,7 +690,7 @@ func TestScoreNotFinalMeeplesOnFieldWithCompleteCity(t *testing.T) {
 		tiletemplates.StraightRoads(),
 	}
 
-	board := NewBoard(tileSet)
+	board := NewBoard(tileSet, rules.Standard())
 
 	ptile := elements.ToPlacedTile(tileSet.Tiles[0])
 	ptile.Position = position.New(0, 1)
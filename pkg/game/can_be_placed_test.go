@@ -5,6 +5,7 @@ import (
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
@@ -22,7 +23,7 @@ func TestPlaceFieldDirectlyAdjacentToCity(t *testing.T) {
 
 		The top edge of the city directly neighbours the field (invalid placement)
 	*/
-	boardInterface := NewBoard(tilesets.StandardTileSet())
+	boardInterface := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -50,7 +51,7 @@ func TestPlaceTwoAdjacentFieldsWithMeeples(t *testing.T) {
 
 		The meeples are placed on both monasteries' fields
 	*/
-	boardInterface := NewBoard(tilesets.StandardTileSet())
+	boardInterface := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -94,7 +95,7 @@ func TestConnectTwoFieldsWithMeeplesWithAThirdMeeple(t *testing.T) {
 
 		The meeples are placed on the top field of the ─ tile and on both monasteries' fields
 	*/
-	boardInterface := NewBoard(tilesets.StandardTileSet())
+	boardInterface := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
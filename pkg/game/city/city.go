@@ -5,6 +5,7 @@ import (
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature/modifier"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
 )
@@ -70,30 +71,62 @@ func (city *City) SetScored(scored bool) {
 	city.scored = scored
 }
 
-// Calculates score value of the city and
-// determines players that should receive points.
-func (city *City) GetScoreReport() elements.ScoreReport {
-	var returnedMeeples = []elements.MeepleWithPosition{}
-	var totalScore uint32
-	// calculate total value of the city and get all meeples
+// Returns all meeples placed on the city.
+func (city City) GetMeeples() []elements.MeepleWithPosition {
+	meeples := []elements.MeepleWithPosition{}
 	for pos, features := range city.features {
 		for _, feature := range features {
 			if feature.Meeple.Type != elements.NoneMeeple {
-				returnedMeeples = append(returnedMeeples, elements.NewMeepleWithPosition(
-					feature.Meeple,
-					pos,
-				))
+				meeples = append(meeples, elements.NewMeepleWithPosition(feature.Meeple, pos))
 			}
 		}
-		totalScore += 2
 	}
-	totalScore += uint32(city.shields) * 2
+	return meeples
+}
 
-	if !city.completed {
-		totalScore /= 2
+// Calculates score value of the city (using the points from the ruleset) and
+// determines players that should receive points.
+//
+// A city with a cathedral is worth 1 more point per tile and shield
+// when completed but it is worth nothing when incomplete.
+// Trade goods of the city are only reported, if the city is completed.
+func (city *City) GetScoreReport(ruleset rules.Ruleset) elements.ScoreReport {
+	var tileCount uint32
+	hasCathedral := false
+	tradeGoods := map[elements.TradeGood]uint8{}
+	// calculate total value of the city
+	for _, features := range city.features {
+		for _, feature := range features {
+			if feature.ModifierType == modifier.Cathedral {
+				hasCathedral = true
+			}
+			if tradeGood, ok := elements.TradeGoodFromModifier(feature.ModifierType); ok {
+				tradeGoods[tradeGood]++
+			}
+		}
+		tileCount++
+	}
+
+	var totalScore uint32
+	switch {
+	case hasCathedral && city.completed:
+		totalScore = tileCount*(ruleset.CityTilePoints.Completed+1) +
+			uint32(city.shields)*(ruleset.CityShieldPoints.Completed+1)
+	case hasCathedral:
+		totalScore = 0
+	case city.completed:
+		totalScore = tileCount*ruleset.CityTilePoints.Completed +
+			uint32(city.shields)*ruleset.CityShieldPoints.Completed
+	default:
+		totalScore = tileCount*ruleset.CityTilePoints.Incomplete +
+			uint32(city.shields)*ruleset.CityShieldPoints.Incomplete
 	}
 
-	return elements.CalculateScoreReportOnMeeples(int(totalScore), returnedMeeples)
+	scoreReport := elements.CalculateScoreReportOnMeeples(int(totalScore), city.GetMeeples())
+	if city.completed && len(tradeGoods) != 0 {
+		scoreReport.ReceivedTradeGoods = tradeGoods
+	}
+	return scoreReport
 }
 
 // Returns all features from a tile at a given position that are part of a city
@@ -142,3 +175,33 @@ func (city *City) JoinCities(other City) {
 	city.shields += other.shields
 	city.checkCompleted()
 }
+
+// Exported state of the city, meant to be used for saving and restoring it.
+type CitySnapshot struct {
+	Completed bool
+	Scored    bool
+	Features  map[position.Position][]elements.PlacedFeature
+	Shields   uint8
+}
+
+func (city City) Snapshot() CitySnapshot {
+	return CitySnapshot{
+		Completed: city.completed,
+		Scored:    city.scored,
+		Features:  maps.Clone(city.features),
+		Shields:   city.shields,
+	}
+}
+
+func NewCityFromSnapshot(snapshot CitySnapshot) City {
+	features := maps.Clone(snapshot.Features)
+	if features == nil {
+		features = map[position.Position][]elements.PlacedFeature{}
+	}
+	return City{
+		completed: snapshot.Completed,
+		scored:    snapshot.Scored,
+		features:  features,
+		shields:   snapshot.Shields,
+	}
+}
@@ -1,17 +1,27 @@
 package city
 
 import (
+	"errors"
 	"slices"
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
 )
 
+var ErrNoUpdateToUndo = errors.New("there is no city update to undo")
+
 // Represents a manager responsible for organising cities
 type Manager struct {
 	cities []City
+	// State of `cities` before each of the UpdateCities() calls, used by UndoUpdate().
+	// The saved cities share their underlying features with `cities`
+	// which is why cities need to be copied before they get modified.
+	// Only recorded after EnableUndo() has been called.
+	history     [][]City
+	keepHistory bool
 }
 
 func NewCityManager() Manager {
@@ -20,15 +30,27 @@ func NewCityManager() Manager {
 	}
 }
 
+// Note: the clone does not contain the update history of the original manager
+// and, as such, the updates made before cloning cannot be undone on the clone.
+// The clone does not record its updates unless EnableUndo() is called on it.
 func (manager Manager) DeepClone() Manager {
 	cities := make([]City, len(manager.cities))
 	for i, city := range manager.cities {
 		cities[i] = city.DeepClone()
 	}
 	manager.cities = cities
+	manager.history = nil
+	manager.keepHistory = false
 	return manager
 }
 
+// Starts recording the state of cities before each of the UpdateCities() calls,
+// allowing them to be reverted with UndoUpdate().
+// Saving the state copies all of the cities so it is off by default.
+func (manager *Manager) EnableUndo() {
+	manager.keepHistory = true
+}
+
 // Returns a pointer to a City that has the given feature at the given position, and its index in the city manager
 // Returns nil if no such city exists
 func (manager Manager) GetCity(position position.Position, feature elements.PlacedFeature) (*City, int) {
@@ -108,34 +130,40 @@ func (manager Manager) findCitiesToJoin(foundCities map[side.Side]int, sides sid
 // the meeple placed on the given feature and the meeples that are already placed on
 // any city that the feature would join.
 func (manager *Manager) CanBePlaced(tile elements.PlacedTile, feat elements.PlacedFeature) bool {
+	return len(manager.GetMeeplesOnCitiesToJoin(tile, feat)) == 0
+}
+
+// Returns the meeples that are already placed on any city
+// that the given feature would join, if the tile was placed.
+func (manager *Manager) GetMeeplesOnCitiesToJoin(
+	tile elements.PlacedTile, feat elements.PlacedFeature,
+) []elements.MeepleWithPosition {
+	meeples := []elements.MeepleWithPosition{}
 	foundCities := manager.findCities(tile.Position)
 
 	if len(foundCities) == 0 {
 		// no existing cities found in tile's neighbourhood - the tile either has
 		// no City features or only has a completely new city
-		return true
+		return meeples
 	}
 
 	// this may return an empty list for features that have no cities to join with
 	citiesToJoin := manager.findCitiesToJoin(foundCities, feat.Sides)
 
 	// Check each of the existing cities (if any) found in feature's neighbourhood
-	// We need to check, if they have *any* meeple placed
 	for _, cityIndex := range citiesToJoin {
-		// score report function checks the whole city for placed meeples
-		// and reports any meeples that would be returned which we can use here
-		scoreReport := manager.cities[cityIndex].GetScoreReport()
-		if len(scoreReport.ReturnedMeeples) != 0 {
-			return false
-		}
+		meeples = append(meeples, manager.cities[cityIndex].GetMeeples()...)
 	}
 
-	return true
+	return meeples
 }
 
 // Performs required operations to add a new city feature.
 func (manager *Manager) UpdateCities(tile elements.PlacedTile) {
 	foundCities := manager.findCities(tile.Position)
+	if manager.keepHistory {
+		manager.saveHistory(foundCities)
+	}
 
 	if len(foundCities) > 0 {
 		for _, cityFeature := range tile.GetFeaturesOfType(feature.City) {
@@ -175,24 +203,66 @@ func (manager *Manager) UpdateCities(tile elements.PlacedTile) {
 	}
 }
 
-// Calculates ScoreReport. When forceScore = false calculates score only based on
-// closed cities and sets city.scored to true. Otherwise calculates score based on
-// every city in array and keeps closed ones.
-func (manager *Manager) ScoreCities(forceScore bool) elements.ScoreReport {
+// Saves the current state of cities so that it can be restored with UndoUpdate().
+// Cities found around the updated tile are the only ones that can get modified
+// so only these are replaced with their deep clones, leaving the saved ones intact.
+func (manager *Manager) saveHistory(foundCities map[side.Side]int) {
+	manager.history = append(manager.history, slices.Clone(manager.cities))
+	for _, cityIndex := range foundCities {
+		manager.cities[cityIndex] = manager.cities[cityIndex].DeepClone()
+	}
+}
+
+// Reverts the last UpdateCities() call (and any scoring done after it),
+// splitting the cities that were merged by it.
+func (manager *Manager) UndoUpdate() error {
+	if len(manager.history) == 0 {
+		return ErrNoUpdateToUndo
+	}
+	last := len(manager.history) - 1
+	manager.cities = manager.history[last]
+	manager.history = manager.history[:last]
+	return nil
+}
+
+// Calculates ScoreReport using the points from the ruleset. When forceScore = false
+// calculates score only based on closed cities and sets city.scored to true.
+// Otherwise calculates score based on every city in array without modifying
+// the manager, which allows it to be used by concurrent readers of the board.
+func (manager *Manager) ScoreCities(ruleset rules.Ruleset, forceScore bool) elements.ScoreReport {
 	scoreReport := elements.NewScoreReport()
-	newCities := make([]City, 0)
-	for _, city := range manager.cities {
+	for i, city := range manager.cities {
 		if !city.scored {
 			if forceScore {
-				scoreReport.Join(city.GetScoreReport())
+				scoreReport.Join(city.GetScoreReport(ruleset))
 			} else if city.IsCompleted() {
-				scoreReport.Join(city.GetScoreReport())
-				city.SetScored(true)
+				scoreReport.Join(city.GetScoreReport(ruleset))
+				manager.cities[i].SetScored(true)
 			}
 		}
-		newCities = append(newCities, city)
 	}
-	manager.cities = newCities
 
 	return scoreReport
 }
+
+// Exported state of the city manager, meant to be used for saving and restoring it.
+// The update history is not a part of the snapshot.
+type ManagerSnapshot struct {
+	Cities []CitySnapshot
+}
+
+func (manager Manager) Snapshot() ManagerSnapshot {
+	cities := make([]CitySnapshot, len(manager.cities))
+	for i, city := range manager.cities {
+		cities[i] = city.Snapshot()
+	}
+	return ManagerSnapshot{Cities: cities}
+}
+
+func NewCityManagerFromSnapshot(snapshot ManagerSnapshot) Manager {
+	cities := make([]City, len(snapshot.Cities))
+	for i, city := range snapshot.Cities {
+		cities[i] = NewCityFromSnapshot(city)
+	}
+	return Manager{cities: cities}
+}
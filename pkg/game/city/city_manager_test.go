@@ -6,6 +6,7 @@ import (
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
@@ -245,7 +246,7 @@ func TestForceScore(t *testing.T) {
 
 	manager := NewCityManager()
 	manager.UpdateCities(a)
-	report := manager.ScoreCities(true)
+	report := manager.ScoreCities(rules.Standard(), true)
 	meeples, ok := report.ReturnedMeeples[expectedPlayerID]
 	if !ok {
 		t.Fatalf("expected player id not in the map")
@@ -277,7 +278,7 @@ func TestScore(t *testing.T) {
 	b.Position = position.New(1, 2)
 	manager.UpdateCities(b)
 
-	report := manager.ScoreCities(false)
+	report := manager.ScoreCities(rules.Standard(), false)
 	meeples, ok := report.ReturnedMeeples[expectedPlayerID]
 	if !ok {
 		t.Fatalf("expected player id not in the map")
@@ -314,7 +315,7 @@ func TestScoreTwice(t *testing.T) {
 	b.Position = position.New(1, 2)
 	manager.UpdateCities(b)
 
-	report := manager.ScoreCities(false)
+	report := manager.ScoreCities(rules.Standard(), false)
 
 	score := report.ReceivedPoints[expectedPlayerID]
 	if score != expectedScore {
@@ -325,7 +326,7 @@ func TestScoreTwice(t *testing.T) {
 		t.Fatalf("expected %#v, got %#v instead", 1, len(manager.cities))
 	}
 
-	report2 := manager.ScoreCities(false)
+	report2 := manager.ScoreCities(rules.Standard(), false)
 	score2 := report2.ReceivedPoints[expectedPlayerID]
 	if score2 != expectedScore2 {
 		t.Fatalf("expected %#v, got %#v instead", expectedScore2, score2)
@@ -352,7 +353,7 @@ func TestScoreAfterJoin(t *testing.T) {
 	c.Position = position.New(1, 2)
 	manager.UpdateCities(c)
 
-	report := manager.ScoreCities(false)
+	report := manager.ScoreCities(rules.Standard(), false)
 
 	if report.ReceivedPoints[expectedPlayerID] != expectedScore {
 		t.Fatalf("expected %#v, got %#v instead", expectedScore, report.ReceivedPoints[expectedPlayerID])
@@ -383,7 +384,7 @@ func TestScoreAfterJoinNotClosed(t *testing.T) {
 	c.Position = position.New(1, 2)
 	manager.UpdateCities(c)
 
-	report := manager.ScoreCities(false)
+	report := manager.ScoreCities(rules.Standard(), false)
 
 	if report.ReceivedPoints[expectedPlayerID] != expectedScore {
 		t.Fatalf("expected %#v, got %#v instead", expectedScore, report.ReceivedPoints[expectedPlayerID])
@@ -414,7 +415,7 @@ func TestForceScoreAfterJoinNotClosedWithShield(t *testing.T) {
 	c.Position = position.New(1, 2)
 	manager.UpdateCities(c)
 
-	report := manager.ScoreCities(true)
+	report := manager.ScoreCities(rules.Standard(), true)
 
 	if report.ReceivedPoints[expectedPlayerID] != expectedScore {
 		t.Fatalf("expected %#v, got %#v instead", expectedScore, report.ReceivedPoints[expectedPlayerID])
@@ -449,7 +450,7 @@ func TestScoreTwoCitiesNotConnected(t *testing.T) {
 	c.Position = position.New(1, 2)
 	manager.UpdateCities(c)
 
-	report := manager.ScoreCities(false)
+	report := manager.ScoreCities(rules.Standard(), false)
 	if report.ReceivedPoints[expectedPlayerID1] != expectedScore {
 		t.Fatalf("expected %#v for player %#v, got %#v instead", expectedScore, expectedPlayerID1, report.ReceivedPoints[expectedPlayerID1])
 	}
@@ -487,7 +488,7 @@ func TestScoreTwoPlayersCityConnected(t *testing.T) {
 	c.Position = position.New(1, 2)
 	manager.UpdateCities(c)
 
-	report := manager.ScoreCities(false)
+	report := manager.ScoreCities(rules.Standard(), false)
 	if report.ReceivedPoints[expectedPlayerID1] != expectedScore {
 		t.Fatalf("expected %#v for player %#v, got %#v instead", expectedScore, expectedPlayerID1, report.ReceivedPoints[expectedPlayerID1])
 	}
@@ -686,3 +687,61 @@ func TestCanBePlacedReturnsFalseWhenExpandingExistingCityAndTryingToPlaceSecondM
 		t.Fatalf("expected %v, got %v instead", expected, actual)
 	}
 }
+
+func TestUndoUpdateSplitsJoinedCities(t *testing.T) {
+	manager := NewCityManager()
+	manager.EnableUndo()
+
+	a := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads().Rotate(1))
+	a.Position = position.New(0, 0)
+	manager.UpdateCities(a)
+
+	b := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads().Rotate(3))
+	b.Position = position.New(2, 0)
+	manager.UpdateCities(b)
+
+	expected := manager.DeepClone().cities
+
+	c := elements.ToPlacedTile(tiletemplates.TwoCityEdgesUpAndDownConnected().Rotate(1))
+	c.Position = position.New(1, 0)
+	manager.UpdateCities(c)
+	manager.ScoreCities(rules.Standard(), false)
+
+	if len(manager.cities) != 1 || !manager.cities[0].IsCompleted() {
+		t.Fatalf("expected a single completed city, got %#v instead", manager.cities)
+	}
+
+	if err := manager.UndoUpdate(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if !reflect.DeepEqual(expected, manager.cities) {
+		t.Fatalf("expected %#v, got %#v instead", expected, manager.cities)
+	}
+}
+
+func TestUndoUpdateReturnsErrorWhenNothingToUndo(t *testing.T) {
+	manager := NewCityManager()
+	if err := manager.UndoUpdate(); err != ErrNoUpdateToUndo {
+		t.Fatalf("expected ErrNoUpdateToUndo, got %#v instead", err)
+	}
+}
+
+func TestNewCityManagerFromSnapshotRestoresCities(t *testing.T) {
+	a := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads())
+	a.Position = position.New(1, 1)
+	b := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads().Rotate(2))
+	b.Position = position.New(1, 2)
+	original := NewCityManager()
+	original.UpdateCities(a)
+	original.UpdateCities(b)
+
+	restored := NewCityManagerFromSnapshot(original.Snapshot())
+
+	if !reflect.DeepEqual(original.cities, restored.cities) {
+		t.Fatalf("expected %#v, got %#v instead", original.cities, restored.cities)
+	}
+	if !restored.cities[0].IsCompleted() {
+		t.Fatal("expected the restored city to be completed")
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
 )
@@ -128,7 +129,7 @@ func TestScoreOneTileCity(t *testing.T) {
 	}
 	city := NewCity(position.New(1, 1), aFeatures)
 
-	scoreReport := city.GetScoreReport()
+	scoreReport := city.GetScoreReport(rules.Standard())
 	meeples, ok := scoreReport.ReturnedMeeples[expectedPlayerID]
 	if !ok {
 		t.Fatalf("expected player id not in the map")
@@ -159,7 +160,7 @@ func TestScoreOneTileCityWithShield(t *testing.T) {
 	}
 	city := NewCity(position.New(1, 1), aFeatures)
 
-	scoreReport := city.GetScoreReport()
+	scoreReport := city.GetScoreReport(rules.Standard())
 	meeples, ok := scoreReport.ReturnedMeeples[expectedPlayerID]
 	if !ok {
 		t.Fatalf("expected player id not in the map")
@@ -197,7 +198,7 @@ func TestScoreThreeTilesPlusShield(t *testing.T) {
 	cFeatures := c.GetFeaturesOfType(feature.City)
 	city.AddTile(position.New(1, 2), cFeatures)
 
-	report := city.GetScoreReport()
+	report := city.GetScoreReport(rules.Standard())
 	meeples, ok := report.ReturnedMeeples[expectedPlayerID]
 	if !ok {
 		t.Fatalf("expected player id not in the map")
@@ -212,3 +213,54 @@ func TestScoreThreeTilesPlusShield(t *testing.T) {
 		t.Fatalf("expected %#v, got %#v instead", expectedScore, report.ReceivedPoints[expectedPlayerID])
 	}
 }
+
+func TestScoreCompletedCityWithCathedral(t *testing.T) {
+	var expectedScore uint32 = 15
+	var expectedPlayerID elements.ID = 1
+
+	a := elements.ToPlacedTile(tiletemplates.FourCityEdgesConnectedCathedral())
+	aFeatures := a.GetFeaturesOfType(feature.City)
+	aFeatures[0].Meeple = elements.Meeple{Type: elements.NormalMeeple, PlayerID: expectedPlayerID}
+	city := NewCity(position.New(0, 0), aFeatures)
+
+	// surround the cathedral with city edges to complete the city
+	positions := []position.Position{
+		position.New(0, -1),
+		position.New(-1, 0),
+		position.New(0, 1),
+		position.New(1, 0),
+	}
+	for rotations, pos := range positions {
+		tile := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads().Rotate(uint(rotations)))
+		city.AddTile(pos, tile.GetFeaturesOfType(feature.City))
+	}
+	if !city.IsCompleted() {
+		t.Fatalf("expected the city to be completed")
+	}
+
+	report := city.GetScoreReport(rules.Standard())
+	if report.ReceivedPoints[expectedPlayerID] != expectedScore {
+		t.Fatalf("expected %#v, got %#v instead", expectedScore, report.ReceivedPoints[expectedPlayerID])
+	}
+}
+
+func TestScoreIncompleteCityWithCathedral(t *testing.T) {
+	var expectedScore uint32 = 0
+	var expectedPlayerID elements.ID = 1
+
+	a := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads())
+	aFeatures := a.GetFeaturesOfType(feature.City)
+	aFeatures[0].Meeple = elements.Meeple{Type: elements.NormalMeeple, PlayerID: expectedPlayerID}
+	city := NewCity(position.New(0, 0), aFeatures)
+
+	b := elements.ToPlacedTile(tiletemplates.FourCityEdgesConnectedCathedral())
+	city.AddTile(position.New(0, 1), b.GetFeaturesOfType(feature.City))
+
+	report := city.GetScoreReport(rules.Standard())
+	if _, ok := report.ReturnedMeeples[expectedPlayerID]; !ok {
+		t.Fatalf("expected player id not in the map")
+	}
+	if report.ReceivedPoints[expectedPlayerID] != expectedScore {
+		t.Fatalf("expected %#v, got %#v instead", expectedScore, report.ReceivedPoints[expectedPlayerID])
+	}
+}
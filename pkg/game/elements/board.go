@@ -15,6 +15,15 @@ type Board interface {
 	TileHasValidPlacement(tile tiles.Tile) bool
 	GetLegalMovesFor(tile PlacedTile) []PlacedTile
 	CanBePlaced(tile PlacedTile) bool
+	// Returns the meeples placed on the board on the features that the given feature
+	// of the tile would connect to, if the tile was placed (ignoring the tile's own meeples).
+	GetMeeplesOnConnectedFeature(tile PlacedTile, feat PlacedFeature) []MeepleWithPosition
+	// Returns the position of the tile with the abbot of the given player, if it's on the board.
+	GetAbbotPosition(playerID ID) (position.Position, bool)
 	PlaceTile(tile PlacedTile) (ScoreReport, error)
+	// Starts recording the changes made by PlaceTile(), allowing them to be reverted
+	// with UndoPlaceTile(). The clones of the board do not record their changes.
+	EnableUndo()
+	UndoPlaceTile() error
 	ScoreMeeples(final bool) ScoreReport
 }
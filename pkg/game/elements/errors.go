@@ -14,5 +14,7 @@ var (
 	ErrInvalidPosition   = &InvalidMove{"the tile cannot be placed at given position"}
 	ErrNoMeepleAvailable = &InvalidMove{"the player does not have any meeples available"}
 	ErrWrongTile         = &InvalidMove{"the played tile is not the one that was drawn"}
+	ErrNoAbbotToRecall   = &InvalidMove{"the player does not have an abbot at given position"}
 	ErrGameIsNotFinished = errors.New("the game is not finished yet")
+	ErrNoTurnToUndo      = errors.New("there is no turn to undo")
 )
@@ -16,10 +16,32 @@ type MeepleType uint8
 const (
 	NoneMeeple MeepleType = iota
 	NormalMeeple
+	// Big meeple from the Inns & Cathedrals expansion
+	BigMeeple
+	// Builder from the Traders & Builders expansion
+	BuilderMeeple
+	// Pig from the Traders & Builders expansion
+	PigMeeple
+	// Abbot from the 2nd edition of the game, it can only be placed on a monastery or a garden
+	AbbotMeeple
 
 	MeepleTypeCount int = iota
 )
 
+// Returns the number of meeples that the meeple of this type counts as
+// when determining which players have the majority on a feature.
+// Figures other than followers (such as builder or pig) do not count at all.
+func (meepleType MeepleType) Strength() uint8 {
+	switch meepleType {
+	case NoneMeeple, BuilderMeeple, PigMeeple:
+		return 0
+	case BigMeeple:
+		return 2
+	default:
+		return 1
+	}
+}
+
 type Meeple struct {
 	Type     MeepleType
 	PlayerID ID
@@ -34,6 +56,10 @@ type PlacedFeature struct {
 type PlacedTile struct {
 	Features []PlacedFeature
 	Position position.Position
+	// Set, if the player recalls their abbot from the monastery or garden
+	// at RecalledAbbotPosition instead of placing a meeple with the tile
+	RecallAbbot           bool
+	RecalledAbbotPosition position.Position
 }
 
 func (placedTile PlacedTile) DeepClone() PlacedTile {
@@ -174,6 +200,15 @@ func (placedTile PlacedTile) Monastery() *PlacedFeature {
 	return nil
 }
 
+func (placedTile PlacedTile) Garden() *PlacedFeature {
+	for i, feat := range placedTile.Features {
+		if feat.FeatureType == feature.Garden {
+			return &placedTile.Features[i]
+		}
+	}
+	return nil
+}
+
 func NewStartingTile(tileSet tilesets.TileSet) PlacedTile {
 	return ToPlacedTile(tileSet.StartingTile)
 }
@@ -13,6 +13,7 @@ type SerializedPlayer struct {
 	ID           ID
 	MeepleCounts []uint8
 	Score        uint32
+	TradeGoods   []uint8
 }
 
 type Player interface {
@@ -20,6 +21,8 @@ type Player interface {
 	ID() ID
 	MeepleCount(meepleType MeepleType) uint8
 	SetMeepleCount(meepleType MeepleType, value uint8)
+	TradeGoodCount(tradeGood TradeGood) uint8
+	SetTradeGoodCount(tradeGood TradeGood, value uint8)
 	Score() uint32
 	SetScore(value uint32)
 	// how am I supposed to name this sensibly...
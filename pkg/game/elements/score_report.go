@@ -25,6 +25,10 @@ type ScoreReport struct {
 	// ReturnedMeeples[playerID (uint8)][meeple type (MeepleType)] = number of returned meeples
 	// for reference, see also: player.meepleCounts
 	ReturnedMeeples map[ID][]MeepleWithPosition
+	// ReceivedTradeGoods[trade good] = number of goods from the completed cities
+	// These are received by the player that completed the cities, regardless of meeples,
+	// and are left unset (nil), if no goods were received.
+	ReceivedTradeGoods map[TradeGood]uint8
 }
 
 func NewScoreReport() ScoreReport {
@@ -35,7 +39,9 @@ func NewScoreReport() ScoreReport {
 }
 
 func (report *ScoreReport) IsEmpty() bool {
-	return len(report.ReceivedPoints) == 0 && len(report.ReturnedMeeples) == 0
+	return len(report.ReceivedPoints) == 0 &&
+		len(report.ReturnedMeeples) == 0 &&
+		len(report.ReceivedTradeGoods) == 0
 }
 
 // Adds the contents of otherReport to the contents of this score report
@@ -54,6 +60,56 @@ func (report *ScoreReport) Join(otherReport ScoreReport) {
 		report.ReturnedMeeples[playerID] = append(report.ReturnedMeeples[playerID], meeples...)
 
 	}
+
+	for tradeGood, count := range otherReport.ReceivedTradeGoods {
+		if report.ReceivedTradeGoods == nil {
+			report.ReceivedTradeGoods = map[TradeGood]uint8{}
+		}
+		report.ReceivedTradeGoods[tradeGood] += count
+	}
+}
+
+// Checks whether both reports describe the same outcome.
+//
+// The order of the returned meeples is not taken into account
+// and players without any received points or returned meeples are ignored
+// (as are trade goods that were not received).
+func (report *ScoreReport) Equals(otherReport ScoreReport) bool {
+	for tradeGood := range TradeGoodCount {
+		if report.ReceivedTradeGoods[TradeGood(tradeGood)] != otherReport.ReceivedTradeGoods[TradeGood(tradeGood)] {
+			return false
+		}
+	}
+
+	playerIDs := map[ID]struct{}{}
+	for _, r := range []*ScoreReport{report, &otherReport} {
+		for playerID := range r.ReceivedPoints {
+			playerIDs[playerID] = struct{}{}
+		}
+		for playerID := range r.ReturnedMeeples {
+			playerIDs[playerID] = struct{}{}
+		}
+	}
+
+	for playerID := range playerIDs {
+		if report.ReceivedPoints[playerID] != otherReport.ReceivedPoints[playerID] {
+			return false
+		}
+
+		meepleCounts := map[MeepleWithPosition]int{}
+		for _, meeple := range report.ReturnedMeeples[playerID] {
+			meepleCounts[meeple]++
+		}
+		for _, meeple := range otherReport.ReturnedMeeples[playerID] {
+			meepleCounts[meeple]--
+		}
+		for _, count := range meepleCounts {
+			if count != 0 {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 func (report *ScoreReport) MeepleInReport(testedMeeple MeepleWithPosition) bool {
@@ -67,22 +123,25 @@ func (report *ScoreReport) MeepleInReport(testedMeeple MeepleWithPosition) bool
 	return false
 }
 
-// Returns a list of IDs of players that have the most meeples in the given map
+// Returns a list of IDs of players that have the most meeples in the given map.
+// Each meeple counts as many meeples as its type's strength (see MeepleType.Strength()).
 func GetPlayersWithMostMeeples(meeples map[ID][]uint8) []ID {
-	var max uint8
+	var max uint
 	winningPlayers := []ID{}
 	for playerID, numMeeples := range meeples {
+		var strength uint
 		for meepleType, meepleCount := range numMeeples {
-			if meepleCount > 0 && MeepleType(meepleType) != NoneMeeple {
-				// TODO: add excluding meeples like builder, etc. when they are implemented
-				if meepleCount > max {
-					max = meepleCount
-					winningPlayers = nil // remove all values that are in array since there is a player with more meeples
-					winningPlayers = append(winningPlayers, playerID)
-				} else if meepleCount == max {
-					winningPlayers = append(winningPlayers, playerID)
-				}
-			}
+			strength += uint(meepleCount) * uint(MeepleType(meepleType).Strength())
+		}
+		if strength == 0 {
+			continue
+		}
+		if strength > max {
+			max = strength
+			winningPlayers = nil // remove all values that are in array since there is a player with more meeples
+			winningPlayers = append(winningPlayers, playerID)
+		} else if strength == max {
+			winningPlayers = append(winningPlayers, playerID)
 		}
 	}
 	return winningPlayers
@@ -102,15 +161,15 @@ func CalculateScoreReportOnMeeples(score int, meeples []MeepleWithPosition) Scor
 		if !existKey {
 			playerMeeples[uint8(meeple.PlayerID)] = 0
 		}
-		playerMeeples[uint8(meeple.PlayerID)]++
+		playerMeeples[uint8(meeple.PlayerID)] += meeple.Type.Strength()
 		if playerMeeples[uint8(meeple.PlayerID)] > mostMeeples {
 			mostMeeples = playerMeeples[uint8(meeple.PlayerID)]
 		}
 	}
 
-	// find players with max
+	// find players with max (figures that do not count towards the majority never score)
 	for playerID, count := range playerMeeples {
-		if count == mostMeeples {
+		if count != 0 && count == mostMeeples {
 			scoredPlayers = append(scoredPlayers, playerID)
 		}
 	}
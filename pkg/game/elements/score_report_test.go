@@ -245,3 +245,118 @@ func TestMeepleInReportNotExists(t *testing.T) {
 		t.Fatalf("Meeple should not be in report!")
 	}
 }
+
+func TestEqualsIgnoresOrderOfReturnedMeeples(t *testing.T) {
+	meeple1 := SimpleMeepleWithPosition(Meeple{NormalMeeple, ID(1)}, position.New(0, 0))
+	meeple2 := SimpleMeepleWithPosition(Meeple{NormalMeeple, ID(1)}, position.New(0, 1))
+
+	report := NewScoreReport()
+	report.ReceivedPoints[1] = 4
+	report.ReturnedMeeples[1] = []MeepleWithPosition{meeple1, meeple2}
+
+	otherReport := NewScoreReport()
+	otherReport.ReceivedPoints[1] = 4
+	otherReport.ReturnedMeeples[1] = []MeepleWithPosition{meeple2, meeple1}
+	otherReport.ReturnedMeeples[2] = []MeepleWithPosition{}
+
+	if !report.Equals(otherReport) {
+		t.Fatalf("expected %#v to equal %#v", report, otherReport)
+	}
+}
+
+func TestEqualsDetectsDifferentReports(t *testing.T) {
+	meeple1 := SimpleMeepleWithPosition(Meeple{NormalMeeple, ID(1)}, position.New(0, 0))
+	meeple2 := SimpleMeepleWithPosition(Meeple{NormalMeeple, ID(1)}, position.New(0, 1))
+
+	report := NewScoreReport()
+	report.ReceivedPoints[1] = 4
+	report.ReturnedMeeples[1] = []MeepleWithPosition{meeple1}
+
+	otherReport := NewScoreReport()
+	otherReport.ReceivedPoints[1] = 4
+	otherReport.ReturnedMeeples[1] = []MeepleWithPosition{meeple2}
+	if report.Equals(otherReport) {
+		t.Fatalf("expected %#v not to equal %#v", report, otherReport)
+	}
+
+	otherReport.ReturnedMeeples[1] = []MeepleWithPosition{meeple1}
+	otherReport.ReceivedPoints[2] = 4
+	if report.Equals(otherReport) {
+		t.Fatalf("expected %#v not to equal %#v", report, otherReport)
+	}
+}
+
+func TestCalculateScoreReportOnMeeplesCountsBigMeepleAsTwo(t *testing.T) {
+	meeples := []MeepleWithPosition{
+		SimpleMeepleWithPosition(Meeple{BigMeeple, ID(1)}, position.New(0, 0)),
+		SimpleMeepleWithPosition(Meeple{NormalMeeple, ID(2)}, position.New(0, 1)),
+	}
+
+	report := CalculateScoreReportOnMeeples(4, meeples)
+
+	expectedPoints := map[ID]uint32{1: 4}
+	if !reflect.DeepEqual(expectedPoints, report.ReceivedPoints) {
+		t.Fatalf("expected %#v, got %#v instead", expectedPoints, report.ReceivedPoints)
+	}
+
+	// two normal meeples tie with a big meeple
+	meeples = append(
+		meeples, SimpleMeepleWithPosition(Meeple{NormalMeeple, ID(2)}, position.New(0, 2)),
+	)
+
+	report = CalculateScoreReportOnMeeples(4, meeples)
+
+	expectedPoints = map[ID]uint32{1: 4, 2: 4}
+	if !reflect.DeepEqual(expectedPoints, report.ReceivedPoints) {
+		t.Fatalf("expected %#v, got %#v instead", expectedPoints, report.ReceivedPoints)
+	}
+}
+
+func TestGetPlayersWithMostMeeplesCountsBigMeepleAsTwo(t *testing.T) {
+	meeples := map[ID][]uint8{
+		1: {0, 1, 1},
+		2: {0, 2},
+	}
+
+	expectedPlayers := []ID{1}
+	actualplayers := GetPlayersWithMostMeeples(meeples)
+
+	if !reflect.DeepEqual(expectedPlayers, actualplayers) {
+		t.Fatalf("expected %#v, got %#v instead", expectedPlayers, actualplayers)
+	}
+}
+
+func TestUpdateScoreReportAddsReceivedTradeGoods(t *testing.T) {
+	report := NewScoreReport()
+	report.ReceivedTradeGoods = map[TradeGood]uint8{Wine: 1}
+
+	otherReport := NewScoreReport()
+	otherReport.ReceivedTradeGoods = map[TradeGood]uint8{Wine: 2, Cloth: 1}
+
+	report.Join(otherReport)
+
+	expectedGoods := map[TradeGood]uint8{Wine: 3, Cloth: 1}
+	if !reflect.DeepEqual(expectedGoods, report.ReceivedTradeGoods) {
+		t.Fatalf("expected %#v, got %#v instead", expectedGoods, report.ReceivedTradeGoods)
+	}
+	if report.Equals(NewScoreReport()) {
+		t.Fatalf("expected %#v not to equal empty report", report)
+	}
+}
+
+func TestCalculateScoreReportOnMeeplesIgnoresBuilderAndPig(t *testing.T) {
+	meeples := []MeepleWithPosition{
+		SimpleMeepleWithPosition(Meeple{BuilderMeeple, ID(1)}, position.New(0, 0)),
+		SimpleMeepleWithPosition(Meeple{PigMeeple, ID(1)}, position.New(0, 1)),
+	}
+
+	report := CalculateScoreReportOnMeeples(4, meeples)
+
+	expectedPoints := map[ID]uint32{}
+	if !reflect.DeepEqual(expectedPoints, report.ReceivedPoints) {
+		t.Fatalf("expected %#v, got %#v instead", expectedPoints, report.ReceivedPoints)
+	}
+	if len(report.ReturnedMeeples[1]) != len(meeples) {
+		t.Fatalf("expected %#v, got %#v instead", meeples, report.ReturnedMeeples[1])
+	}
+}
@@ -0,0 +1,33 @@
+package elements
+
+import "github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature/modifier"
+
+// Trade goods from the Traders & Builders expansion
+type TradeGood uint8
+
+const (
+	Wine TradeGood = iota
+	Grain
+	Cloth
+
+	TradeGoodCount int = iota
+)
+
+// Points received at the end of the game by each of the players
+// that have the most goods of a single type.
+const TradeGoodMajorityPoints = 10
+
+// Returns the trade good represented by the given city modifier
+// and whether the modifier represents a trade good at all.
+func TradeGoodFromModifier(modifierType modifier.Type) (TradeGood, bool) {
+	switch modifierType {
+	case modifier.Wine:
+		return Wine, true
+	case modifier.Grain:
+		return Grain, true
+	case modifier.Cloth:
+		return Cloth, true
+	default:
+		return 0, false
+	}
+}
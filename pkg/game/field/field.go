@@ -6,6 +6,7 @@ import (
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/city"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	featureMod "github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/utilities"
@@ -58,6 +59,11 @@ func (field Field) CitiesCount() int {
 	return len(field.neighbouringCities)
 }
 
+// Returns meeples found on this field. Has to be called after field.Expand()
+func (field Field) Meeples() []elements.MeepleWithPosition {
+	return field.meeples
+}
+
 // Expands this field to maximum possible size (like flood fill) and finds all neighbouring cities
 // Has to be called on a field which starting tile has already been placed (mostly only at the end of the game)
 func (field *Field) Expand(board elements.Board, cityManager city.Manager) {
@@ -177,6 +183,47 @@ func (field Field) IsFieldValid(board elements.Board, maxMeepleCount int) bool {
 	return true
 }
 
+// Returns all meeples placed on this field, including the ones on its starting tile.
+//
+// Unlike Expand(), it can be called on a field which starting tile has not been placed yet
+// (e.g. to check what meeples are on the fields that the tile would connect to).
+func (field Field) FindMeeples(board elements.Board) []elements.MeepleWithPosition {
+	newFeatures := map[fieldKey]struct{}{}
+	meeples := []elements.MeepleWithPosition{}
+
+	// copy the original field.features into features, to avoid modifying it
+	features := map[fieldKey]struct{}{}
+	for key := range field.features {
+		features[key] = struct{}{}
+	}
+
+	for len(features) != 0 {
+		element, _, _ := utilities.GetAnyElementFromMap(features)
+
+		// add neighbouring tiles to the set
+		_, exists := newFeatures[element]
+		if !exists {
+			newFeatures[element] = struct{}{}
+			for _, neighbour := range field.findNeighbours(element, board) {
+				_, exists := newFeatures[neighbour]
+				if !exists {
+					features[neighbour] = struct{}{}
+				}
+			}
+		}
+
+		// add meeple if it exists
+		meeple := element.feature.Meeple
+		if meeple.Type != elements.NoneMeeple {
+			meeples = append(meeples, elements.NewMeepleWithPosition(meeple, element.position))
+		}
+
+		// remove the processed field feature from features set
+		delete(features, element)
+	}
+	return meeples
+}
+
 // Returns a slice of fieldKey elements containing all features neighbouring a given fieldKey (feature and position)
 // The slice may contain duplicates in some cases (todo?)
 func (field *Field) findNeighbours(fieldK fieldKey, board elements.Board) []fieldKey {
@@ -215,9 +262,56 @@ func (field Field) getTile(position position.Position, board elements.Board) (el
 	return tile, ok
 }
 
-// Returns score report for this field. Has to be called after field.Expand() (todo?)
-func (field Field) GetScoreReport() elements.ScoreReport {
-	points := uint32(len(field.neighbouringCities) * 3)
+// Returns score report for this field using the points from the ruleset.
+// Has to be called after field.Expand() (todo?)
+//
+// A pig on the field boosts the score of its owner by 1 point per city,
+// if the owner receives points for the field.
+func (field Field) GetScoreReport(ruleset rules.Ruleset) elements.ScoreReport {
+	points := uint32(len(field.neighbouringCities)) * ruleset.FarmCityPoints
+
+	scoreReport := elements.CalculateScoreReportOnMeeples(int(points), field.meeples)
+	for _, meeple := range field.meeples {
+		if meeple.Type != elements.PigMeeple {
+			continue
+		}
+		if _, ok := scoreReport.ReceivedPoints[meeple.PlayerID]; ok {
+			scoreReport.ReceivedPoints[meeple.PlayerID] += uint32(len(field.neighbouringCities))
+		}
+	}
+	return scoreReport
+}
+
+// Returns points for the given fields scored by cities (1st edition rules).
+// Each completed city gives points to the players with the most farmers
+// on all of the fields neighbouring it. The fields have to be distinct
+// and expanded with field.Expand().
+//
+// A pig on any of these fields boosts the score of its owner by 1 point per city,
+// if the owner receives points for the city.
+//
+// Only the received points are reported, the farmers are not returned.
+func GetCityScoreReport(fields []Field, ruleset rules.Ruleset) elements.ScoreReport {
+	cityMeeples := map[int][]elements.MeepleWithPosition{}
+	for _, field := range fields {
+		for cityID := range field.neighbouringCities {
+			cityMeeples[cityID] = append(cityMeeples[cityID], field.meeples...)
+		}
+	}
 
-	return elements.CalculateScoreReportOnMeeples(int(points), field.meeples)
+	scoreReport := elements.NewScoreReport()
+	for _, meeples := range cityMeeples {
+		cityReport := elements.CalculateScoreReportOnMeeples(int(ruleset.FarmCityPoints), meeples)
+		hasPig := map[elements.ID]bool{}
+		for _, meeple := range meeples {
+			hasPig[meeple.PlayerID] = hasPig[meeple.PlayerID] || meeple.Type == elements.PigMeeple
+		}
+		for playerID, points := range cityReport.ReceivedPoints {
+			if hasPig[playerID] {
+				points++
+			}
+			scoreReport.ReceivedPoints[playerID] += points
+		}
+	}
+	return scoreReport
 }
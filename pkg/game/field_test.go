@@ -7,6 +7,7 @@ import (
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/field"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
@@ -30,7 +31,7 @@ func TestScoreFieldOnePlayerGetsPoints(t *testing.T) {
 		The meeple is placed on the field feature in the higher monastery tile (position: 1,0).
 	*/
 
-	boardInterface := NewBoard(tilesets.StandardTileSet())
+	boardInterface := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -92,7 +93,7 @@ func TestScoreFieldOnePlayerGetsPoints(t *testing.T) {
 			position.New(1, 0))},
 	}
 
-	actualReport := field.GetScoreReport()
+	actualReport := field.GetScoreReport(rules.Standard())
 
 	if !reflect.DeepEqual(expectedReport, actualReport) {
 		t.Fatalf("expected %#v, got %#v instead", expectedReport, actualReport)
@@ -122,7 +123,7 @@ func TestScoreFieldTwoPlayersGetPoints(t *testing.T) {
 		The second meeple is placed on the bottom-right corner part of the ┌ road below the starting tile (position: 0,-1).
 	*/
 
-	boardInterface := NewBoard(tilesets.StandardTileSet())
+	boardInterface := NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -195,9 +196,71 @@ func TestScoreFieldTwoPlayersGetPoints(t *testing.T) {
 			elements.Meeple{Type: elements.NormalMeeple, PlayerID: elements.ID(2)},
 			position.New(0, -1))},
 	}
-	actualReport := field.GetScoreReport()
+	actualReport := field.GetScoreReport(rules.Standard())
 
 	if !reflect.DeepEqual(expectedReport, actualReport) {
 		t.Fatalf("expected %#v, got %#v instead", expectedReport, actualReport)
 	}
 }
+
+func TestScoreFieldPigAddsPointsToFarmer(t *testing.T) {
+	/*
+		the board setup is the same as in TestScoreFieldOnePlayerGetsPoints
+		with the pig placed on the field under the higher monastery tile (position: 1,-1)
+	*/
+
+	boardInterface := NewBoard(tilesets.StandardTileSet(), rules.Standard())
+	board := boardInterface.(*board)
+
+	tiles := []elements.PlacedTile{
+		elements.ToPlacedTile(tiletemplates.MonasteryWithSingleRoad().Rotate(1)),
+		elements.ToPlacedTile(tiletemplates.XCrossRoad()),
+		elements.ToPlacedTile(tiletemplates.StraightRoads()),
+		elements.ToPlacedTile(tiletemplates.StraightRoads()),
+		elements.ToPlacedTile(tiletemplates.RoadsTurn()),
+		elements.ToPlacedTile(tiletemplates.RoadsTurn().Rotate(3)),
+		elements.ToPlacedTile(tiletemplates.MonasteryWithSingleRoad().Rotate(2)),
+		elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads().Rotate(2)),
+	}
+
+	// add meeple and pig to the field
+	tiles[0].GetPlacedFeatureAtSide(side.All, feature.Field).Meeple =
+		elements.Meeple{PlayerID: 1, Type: elements.NormalMeeple}
+	tiles[3].GetPlacedFeatureAtSide(side.TopLeftEdge, feature.Field).Meeple =
+		elements.Meeple{PlayerID: 1, Type: elements.PigMeeple}
+
+	// set positions
+	tiles[0].Position = position.New(1, 0)
+	tiles[1].Position = position.New(-1, 0)
+	tiles[2].Position = position.New(-2, 0)
+
+	tiles[3].Position = position.New(1, -1)
+	tiles[4].Position = position.New(2, -1)
+	tiles[5].Position = position.New(0, -1)
+
+	tiles[6].Position = position.New(0, -2)
+
+	tiles[7].Position = position.New(0, 1)
+
+	// place tiles
+	for i, tile := range tiles {
+		_, err := board.PlaceTile(tile)
+		if err != nil {
+			t.Fatalf("error placing tile number: %#v: %#v", i, err)
+		}
+	}
+
+	field := field.New(*tiles[0].GetPlacedFeatureAtSide(side.All, feature.Field), tiles[0])
+	field.Expand(board, board.cityManager)
+
+	// 3 points for the single city and 1 more for the pig
+	expectedPoints := map[elements.ID]uint32{
+		1: 4,
+	}
+
+	actualReport := field.GetScoreReport(rules.Standard())
+
+	if !reflect.DeepEqual(expectedPoints, actualReport.ReceivedPoints) {
+		t.Fatalf("expected %#v, got %#v instead", expectedPoints, actualReport.ReceivedPoints)
+	}
+}
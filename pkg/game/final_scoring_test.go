@@ -7,6 +7,7 @@ import (
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/test"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
@@ -43,7 +44,7 @@ func TestFinalScoreRoad(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -104,7 +105,7 @@ func TestFinalScoreMultipleRoads(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -171,7 +172,7 @@ func TestFinalScoreMonastery(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -227,7 +228,7 @@ func TestFinalScoreMonasteries(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -293,7 +294,7 @@ func TestFinalScoreCity(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -350,7 +351,7 @@ func TestFinalScoreCities(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -416,7 +417,7 @@ func TestFinalScoreField(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -486,7 +487,7 @@ func TestFinalScoreFields1(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -572,7 +573,7 @@ func TestFinalScoreFields2(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -674,7 +675,7 @@ func TestFinalScoreFields3(t *testing.T) { //nolint:gocyclo
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -775,7 +776,7 @@ func TestFinalScoreMultipleRoads123(t *testing.T) {
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -4,14 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/logger"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/player"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/binarytiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature/modifier"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
 )
 
@@ -27,7 +32,22 @@ type SerializedGame struct {
 	PlayerCount         int
 	Tiles               []elements.PlacedTile
 	TileSet             tilesets.TileSet
-	BinaryTiles         []binarytiles.BinaryTile // contains info about all placed tiles, not placed tiles are equal to 0
+	// contains info about all placed tiles, not placed tiles are equal to 0
+	//
+	// Bit layout (from the least significant bit), see binarytiles.BinaryTile for details:
+	//  - 0-9: fields, 10-19: roads, 20-29: cities, 30-33: city shields
+	//  - 34: monastery, 35: unconnected field
+	//  - 36-44: meeple
+	//  - 45-47: ID of the meeple's owner (binary, not one-hot), 0 without a meeple
+	//  - 48-55: X position, 56-63: Y position
+	//
+	// There is no "is placed" bit, decoders written for an earlier layout
+	// should check BinaryTilesLayout first.
+	BinaryTiles []binarytiles.BinaryTile
+	// version of the BinaryTiles' bit layout, see binarytiles.LayoutVersion
+	BinaryTilesLayout int
+	// hash of the game state that does not depend on the order of the moves, see Game.Hash()
+	Hash uint64
 }
 
 type Game struct {
@@ -38,46 +58,93 @@ type Game struct {
 	currentPlayer int
 	log           logger.Logger
 	canSwapTiles  bool
+	// whether the current player plays an extra turn granted by their builder
+	extraTurn bool
+	// turns that can be reverted with UndoTurn(), the last played turn is at the end,
+	// only recorded after EnableUndo() has been called
+	history     []turnRecord
+	keepHistory bool
+	// see Hash()
+	hash uint64
+	// keys of the meeples on the board included in the hash, see rehashMeeplesAt()
+	meepleHashes map[position.Position]uint64
 }
 
-func NewFromTileSet(tileSet tilesets.TileSet, log logger.Logger, playerCount uint8) (*Game, error) {
-	deckStack := stack.New(tileSet.Tiles)
+// Information needed to revert a single PlayTurn() call.
+type turnRecord struct {
+	// index in the `players` field, not the Player ID
+	player      int
+	move        elements.PlacedTile
+	scoreReport elements.ScoreReport
+	// number of tiles drawn from the deck during the turn,
+	// including the tiles that were discarded due to having no valid placement
+	drawnTiles int32
+	// whether the turn was an extra turn granted by the player's builder
+	extraTurn bool
+	// hash of the game before the turn
+	hash uint64
+}
+
+// Figures that are not followers and can only join the current player's followers
+var nonFollowerMeepleTypes = []elements.MeepleType{elements.BuilderMeeple, elements.PigMeeple}
+
+func NewFromTileSet(
+	tileSet tilesets.TileSet, log logger.Logger, playerCount uint8, ruleset rules.Ruleset,
+) (*Game, error) {
+	deckStack := stack.NewInGroups(tileSet.Tiles, tileSet.Groups)
 	deck := deck.Deck{
 		Stack:        &deckStack,
 		StartingTile: tileSet.StartingTile,
 	}
-	return NewFromDeck(deck, log, playerCount)
+	return NewFromDeck(deck, log, playerCount, ruleset)
 }
 
+// Creates a new game played with the given ruleset,
+// see rules.Standard() for the rules of the current edition of the game.
+// Returns rules.ErrInvalidRuleset, if the ruleset does not pass rules.Ruleset.Validate().
 func NewFromDeck(
-	deck deck.Deck, log logger.Logger, playerCount uint8,
+	deck deck.Deck, log logger.Logger, playerCount uint8, ruleset rules.Ruleset,
 ) (*Game, error) {
+	if err := ruleset.Validate(); err != nil {
+		return nil, err
+	}
 	if log == nil {
 		nullLogger := logger.NewEmpty()
 		log = &nullLogger
 	}
 
+	expansionMeepleCounts := getExpansionMeepleCounts(deck.TileSet())
 	var players = make([]elements.Player, playerCount)
 	for i := range playerCount {
 		players[i] = player.New(elements.ID(i + 1))
+		players[i].SetMeepleCount(elements.NormalMeeple, ruleset.MeepleCount)
+		for meepleType, count := range expansionMeepleCounts {
+			players[i].SetMeepleCount(meepleType, count)
+		}
 	}
 
 	game := &Game{
-		board:         NewBoard(deck.TileSet()),
+		board:         NewBoard(deck.TileSet(), ruleset),
 		deck:          deck,
 		players:       players,
 		currentPlayer: 0,
 		log:           log,
 	}
 
+	// the logged stack includes the tiles discarded below, so that the replayed game
+	// has the same tile set and discards them the same way
+	stack := game.deck.GetRemaining()
 	// All tiles in base game can be placed on the first move but let's just check this
 	// in case this isn't true for tiles from all of the expansions.
 	err := game.ensureCurrentTileHasValidPlacement()
 	if err != nil {
 		return nil, err
 	}
+	game.rehash()
 	if err := log.LogEvent(
-		logger.StartEvent, logger.NewStartEntryContent(game.deck.StartingTile, game.deck.GetRemaining(), len(game.players)),
+		logger.StartEvent, logger.NewStartEntryContent(
+			game.deck.StartingTile, stack, len(game.players), ruleset,
+		),
 	); err != nil {
 		return nil, err
 	}
@@ -85,6 +152,30 @@ func NewFromDeck(
 	return game, nil
 }
 
+// Figures that come with the expansions are only given to the players
+// when the tile set contains any of the expansion's tiles:
+//   - big meeple comes with the inn and cathedral tiles (Inns & Cathedrals)
+//   - builder and pig come with the trade goods tiles (Traders & Builders)
+//   - abbot comes with the garden tiles (2nd edition)
+func getExpansionMeepleCounts(tileSet tilesets.TileSet) map[elements.MeepleType]uint8 {
+	meepleCounts := map[elements.MeepleType]uint8{}
+	for _, tile := range append([]tiles.Tile{tileSet.StartingTile}, tileSet.Tiles...) {
+		for _, feat := range tile.Features {
+			if feat.FeatureType == feature.Garden {
+				meepleCounts[elements.AbbotMeeple] = 1
+			}
+			switch feat.ModifierType {
+			case modifier.Inn, modifier.Cathedral:
+				meepleCounts[elements.BigMeeple] = 1
+			case modifier.Wine, modifier.Grain, modifier.Cloth:
+				meepleCounts[elements.BuilderMeeple] = 1
+				meepleCounts[elements.PigMeeple] = 1
+			}
+		}
+	}
+	return meepleCounts
+}
+
 func (game Game) DeepClone() *Game {
 	game.board = game.board.DeepClone()
 	game.deck = game.deck.DeepClone()
@@ -98,6 +189,11 @@ func (game Game) DeepClone() *Game {
 	nullLogger := logger.New(io.Discard)
 	game.log = &nullLogger
 
+	// the board's history is not cloned either, see board.DeepClone()
+	game.history = nil
+	game.keepHistory = false
+	game.meepleHashes = maps.Clone(game.meepleHashes)
+
 	return &game
 }
 
@@ -116,6 +212,16 @@ func (game *Game) DeepCloneWithLog(log logger.Logger) (*Game, error) {
 	return clone, nil
 }
 
+// Replaces the logger of the game, the events logged so far are not copied to the new one.
+// Nil disables the logging.
+func (game *Game) SetLog(log logger.Logger) {
+	if log == nil {
+		nullLogger := logger.NewEmpty()
+		log = &nullLogger
+	}
+	game.log = log
+}
+
 func (game *Game) Serialized() SerializedGame {
 
 	// serialize serializedPlayers
@@ -131,12 +237,14 @@ func (game *Game) Serialized() SerializedGame {
 	}
 
 	serialized := SerializedGame{
-		CurrentPlayerID: game.CurrentPlayer().ID(),
-		Players:         serializedPlayers,
-		PlayerCount:     game.PlayerCount(),
-		Tiles:           game.board.Tiles(),
-		TileSet:         game.deck.TileSet(),
-		BinaryTiles:     serializedTiles,
+		CurrentPlayerID:   game.CurrentPlayer().ID(),
+		Players:           serializedPlayers,
+		PlayerCount:       game.PlayerCount(),
+		Tiles:             game.board.Tiles(),
+		TileSet:           game.deck.TileSet(),
+		BinaryTiles:       serializedTiles,
+		BinaryTilesLayout: binarytiles.LayoutVersion,
+		Hash:              game.hash,
 	}
 
 	// prevent leakage of future state of the CurrentTile
@@ -155,6 +263,12 @@ func (game *Game) CanSwapTiles() bool {
 	return game.canSwapTiles
 }
 
+// Returns whether the current player plays an extra turn granted by their builder,
+// i.e. whether the same player also played the previous turn.
+func (game *Game) IsExtraTurn() bool {
+	return game.extraTurn
+}
+
 func (game *Game) GetCurrentTile() (tiles.Tile, error) {
 	return game.deck.Peek()
 }
@@ -163,6 +277,11 @@ func (game *Game) GetRemainingTiles() []tiles.Tile {
 	return game.deck.GetRemaining()
 }
 
+// Returns the tile set that the game's deck was created from.
+func (game *Game) TileSet() tilesets.TileSet {
+	return game.deck.TileSet()
+}
+
 func (game *Game) CurrentPlayer() elements.Player {
 	return game.players[game.currentPlayer]
 }
@@ -204,9 +323,47 @@ outer:
 		moves = append(moves, move)
 	}
 
+	// non-followers cannot be placed on unoccupied features
+	// so they're not included in board's legal moves
+	for _, meepleType := range nonFollowerMeepleTypes {
+		if player.MeepleCount(meepleType) == 0 {
+			continue
+		}
+		for i := range placement.Features {
+			move := placement.DeepClone()
+			move.Features[i].Meeple = elements.Meeple{Type: meepleType, PlayerID: player.ID()}
+			if game.board.CanBePlaced(move) {
+				moves = append(moves, move)
+			}
+		}
+	}
+
+	// abbot can be recalled from the board instead of placing a meeple
+	if abbotPosition, ok := game.board.GetAbbotPosition(player.ID()); ok {
+		move := placement.DeepClone()
+		move.RecallAbbot = true
+		move.RecalledAbbotPosition = abbotPosition
+		moves = append(moves, move)
+	}
+
 	return moves
 }
 
+// Returns true if the move extends a road or a city with the builder of the given player.
+func (game *Game) extendsBuilderFeature(playerID elements.ID, move elements.PlacedTile) bool {
+	for _, feat := range move.Features {
+		if feat.FeatureType != feature.Road && feat.FeatureType != feature.City {
+			continue
+		}
+		for _, meeple := range game.board.GetMeeplesOnConnectedFeature(move, feat) {
+			if meeple.Type == elements.BuilderMeeple && meeple.PlayerID == playerID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (game *Game) ensureCurrentTileHasValidPlacement() error {
 	for {
 		// Peek at the tile that will be returned by GetCurrentTile() next time
@@ -229,6 +386,7 @@ func (game *Game) ensureCurrentTileHasValidPlacement() error {
 			// We already peeked and checked for out of bounds so that's unexpected...
 			return err
 		}
+		game.hash -= deckTileHash(nextTile)
 	}
 
 	return nil
@@ -254,6 +412,13 @@ func (game *Game) PlayTurn(move elements.PlacedTile) error {
 		return fmt.Errorf("%w: %#v", elements.ErrWrongTile, currentTile)
 	}
 	player := game.CurrentPlayer()
+	// The builder grants a single extra turn - extending its feature
+	// during the extra turn does not grant another one.
+	// This needs to be checked before the tile is placed since completing
+	// the feature returns the builder to the player.
+	extraTurn := !game.extraTurn && game.extendsBuilderFeature(player.ID(), move)
+	// placing a tile can already change the player's meeple counts
+	playersHash := game.playersHash()
 
 	// In the class diagram, the `scoreReport` would be returned by
 	// separate `CheckCompleted()` method but it's been abstracted by PlaceTile instead.
@@ -263,7 +428,26 @@ func (game *Game) PlayTurn(move elements.PlacedTile) error {
 	}
 	// if placing a tile hasn't failed, the board has already been modified
 	// and we can update the current player as well
-	game.currentPlayer = (game.currentPlayer + 1) % game.PlayerCount()
+	record := turnRecord{
+		player:      game.currentPlayer,
+		move:        move,
+		scoreReport: scoreReport,
+		extraTurn:   game.extraTurn,
+		hash:        game.hash,
+	}
+	remainingTileCount := game.deck.GetRemainingTileCount()
+	defer func() {
+		if game.keepHistory {
+			record.drawnTiles = remainingTileCount - game.deck.GetRemainingTileCount()
+			game.history = append(game.history, record)
+		}
+	}()
+	game.hash += placedTileHash(move) - turnHash(game.currentPlayer, game.extraTurn)
+	game.extraTurn = extraTurn
+	if !extraTurn {
+		game.currentPlayer = (game.currentPlayer + 1) % game.PlayerCount()
+	}
+	game.hash += turnHash(game.currentPlayer, game.extraTurn)
 
 	if err = game.log.LogEvent(
 		logger.PlaceTileEvent, logger.NewPlaceTileEntryContent(player.ID(), move),
@@ -288,6 +472,20 @@ func (game *Game) PlayTurn(move elements.PlacedTile) error {
 		}
 	}
 
+	// Trade goods from the completed cities go to the player that completed them
+	for tradeGood, count := range scoreReport.ReceivedTradeGoods {
+		player.SetTradeGoodCount(tradeGood, player.TradeGoodCount(tradeGood)+count)
+	}
+
+	game.hash += game.playersHash() - playersHash
+	meeplePositions := []position.Position{move.Position}
+	for _, returnedMeeples := range scoreReport.ReturnedMeeples {
+		for _, meeple := range returnedMeeples {
+			meeplePositions = append(meeplePositions, meeple.Position)
+		}
+	}
+	game.rehashMeeplesAt(meeplePositions)
+
 	if !scoreReport.IsEmpty() {
 		if err = game.log.LogEvent(
 			logger.ScoreEvent, logger.NewScoreEntryContent(scoreReport),
@@ -300,6 +498,7 @@ func (game *Game) PlayTurn(move elements.PlacedTile) error {
 	if _, err = game.deck.Next(); err != nil {
 		return err
 	}
+	game.hash -= deckTileHash(currentTile)
 
 	err = game.ensureCurrentTileHasValidPlacement()
 	if err != nil {
@@ -309,6 +508,87 @@ func (game *Game) PlayTurn(move elements.PlacedTile) error {
 	return nil
 }
 
+// Start recording the turns played with PlayTurn(), allowing them to be reverted
+// with UndoTurn(). Recording the turns copies parts of the board on each turn,
+// so it should only be enabled on the games that need to revert their turns.
+//
+// Like the turn history, the setting is not preserved by DeepClone() and its variants.
+func (game *Game) EnableUndo() {
+	game.keepHistory = true
+	game.board.EnableUndo()
+}
+
+// Revert the last turn played with PlayTurn(), returning the placed tile to the deck,
+// and restoring the meeples and scores of the players.
+//
+// If the game has been finalized after the last turn, the meeples removed
+// during final scoring are restored as well.
+//
+// Only the turns played on this instance after calling EnableUndo() can be reverted -
+// the turn history is not preserved by DeepClone() and its variants.
+func (game *Game) UndoTurn() error {
+	if len(game.history) == 0 {
+		return elements.ErrNoTurnToUndo
+	}
+	last := len(game.history) - 1
+	record := game.history[last]
+
+	// the deck is rewound first, as it can be restored if reverting the board fails
+	if err := game.deck.Rewind(record.drawnTiles); err != nil {
+		return err
+	}
+	if err := game.board.UndoPlaceTile(); err != nil {
+		for range record.drawnTiles {
+			//nolint:errcheck// drawing the rewound tiles again cannot fail
+			game.deck.Next()
+		}
+		return err
+	}
+	game.history = game.history[:last]
+
+	// Take back the points
+	for playerID, receivedPoints := range record.scoreReport.ReceivedPoints {
+		player := game.players[playerID-1]
+		player.SetScore(player.Score() - receivedPoints)
+	}
+
+	// Take back the meeples returned to the players
+	for playerID, returnedMeeples := range record.scoreReport.ReturnedMeeples {
+		player := game.players[playerID-1]
+		for _, meeple := range returnedMeeples {
+			player.SetMeepleCount(
+				meeple.Type,
+				player.MeepleCount(meeple.Type)-1,
+			)
+		}
+	}
+
+	player := game.players[record.player]
+	// Take back the trade goods received from the completed cities
+	for tradeGood, count := range record.scoreReport.ReceivedTradeGoods {
+		player.SetTradeGoodCount(tradeGood, player.TradeGoodCount(tradeGood)-count)
+	}
+
+	// Give back the meeples placed with the move
+	for _, feat := range record.move.Features {
+		if feat.Meeple.Type != elements.NoneMeeple {
+			player.SetMeepleCount(
+				feat.Meeple.Type,
+				player.MeepleCount(feat.Meeple.Type)+1,
+			)
+		}
+	}
+	game.currentPlayer = record.player
+	game.extraTurn = record.extraTurn
+	game.hash = record.hash
+	// the meeples removed during the turn (or final scoring) are back on the board
+	game.rehashMeeples()
+
+	return game.log.LogEvent(
+		logger.UndoTurnEvent, logger.NewUndoTurnEntryContent(player.ID(), record.move),
+	)
+}
+
 func (game *Game) Finalize() (elements.ScoreReport, error) {
 	playerScores := elements.NewScoreReport()
 
@@ -323,8 +603,12 @@ func (game *Game) Finalize() (elements.ScoreReport, error) {
 
 	// add final score report
 	meeplesReport := game.board.ScoreMeeples(true)
+	meeplesReport.Join(game.scoreTradeGoods())
 	playerScores.Join(meeplesReport)
 
+	// final scoring removes the meeples from the board
+	game.rehash()
+
 	if err := game.log.LogEvent(logger.ScoreEvent, logger.NewScoreEntryContent(meeplesReport)); err != nil {
 		return playerScores, err
 	}
@@ -338,6 +622,8 @@ func (game *Game) Finalize() (elements.ScoreReport, error) {
 
 /*
 Calculate points as if game has just finished
+
+The game is not modified, which allows calling it concurrently with other readers of the game.
 */
 func (game *Game) GetMidGameScore() elements.ScoreReport {
 	playerScores := elements.NewScoreReport()
@@ -349,7 +635,30 @@ func (game *Game) GetMidGameScore() elements.ScoreReport {
 
 	// add final score report
 	meeplesReport := game.board.ScoreMeeples(false)
+	meeplesReport.Join(game.scoreTradeGoods())
 	playerScores.Join(meeplesReport)
 
 	return playerScores
 }
+
+// Calculates points for the trade goods received at the end of the game.
+// Each of the players with the most goods of a single type receives the same points.
+func (game *Game) scoreTradeGoods() elements.ScoreReport {
+	scoreReport := elements.NewScoreReport()
+	for i := range elements.TradeGoodCount {
+		tradeGood := elements.TradeGood(i)
+		var mostGoods uint8
+		for _, player := range game.players {
+			mostGoods = max(mostGoods, player.TradeGoodCount(tradeGood))
+		}
+		if mostGoods == 0 {
+			continue
+		}
+		for _, player := range game.players {
+			if player.TradeGoodCount(tradeGood) == mostGoods {
+				scoreReport.ReceivedPoints[player.ID()] += elements.TradeGoodMajorityPoints
+			}
+		}
+	}
+	return scoreReport
+}
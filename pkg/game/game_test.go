@@ -10,6 +10,7 @@ import (
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/logger"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
@@ -40,7 +41,7 @@ func TestDeepClone(t *testing.T) {
 	tileSet.Tiles = []tiles.Tile{tiletemplates.SingleCityEdgeNoRoads().Rotate(2)}
 
 	originalLogger := &TestLogger{}
-	original, err := NewFromTileSet(tileSet, originalLogger, 2)
+	original, err := NewFromTileSet(tileSet, originalLogger, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -123,7 +124,7 @@ func TestFullGame(t *testing.T) {
 	}
 	deckStack := stack.NewOrdered(tileSet.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -192,7 +193,7 @@ func TestFullGame(t *testing.T) {
 }
 
 func TestGameFinalizeErrorsBeforeGameIsFinished(t *testing.T) {
-	game, err := NewFromTileSet(tilesets.StandardTileSet(), nil, 2)
+	game, err := NewFromTileSet(tilesets.StandardTileSet(), nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -207,11 +208,24 @@ func TestGameFinalizeErrorsBeforeGameIsFinished(t *testing.T) {
 	}
 }
 
+func TestNewGameErrorsForInvalidRuleset(t *testing.T) {
+	ruleset := rules.Standard()
+	ruleset.MeepleCount = 0
+	invalidRulesets := []rules.Ruleset{{}, ruleset}
+
+	for _, ruleset := range invalidRulesets {
+		_, err := NewFromTileSet(tilesets.StandardTileSet(), nil, 2, ruleset)
+		if !errors.Is(err, rules.ErrInvalidRuleset) {
+			t.Fatalf("expected %#v, got %#v instead", rules.ErrInvalidRuleset, err)
+		}
+	}
+}
+
 func TestGameSerializedCurrentTileNotSetWhenStackOutOfBounds(t *testing.T) {
 	tileSet := tilesets.StandardTileSet()
 	tileSet.Tiles = []tiles.Tile{}
 
-	game, err := NewFromTileSet(tileSet, nil, 2)
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -225,7 +239,7 @@ func TestGameSerializedCurrentTileNotSetWhenStackOutOfBounds(t *testing.T) {
 func TestGameSerializedCurrentTileNotSetForClonesWithSwappableTiles(t *testing.T) {
 	tileSet := tilesets.StandardTileSet()
 
-	game, err := NewFromTileSet(tileSet, nil, 2)
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -250,7 +264,7 @@ func TestGameGetLegalMovesForIncludesMeepleTypesCurrentPlayerDoesHave(t *testing
 		Tiles:        []tiles.Tile{tile},
 	}
 
-	game, err := NewFromTileSet(tileSet, nil, 2)
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -279,7 +293,7 @@ func TestGameGetLegalMovesForExcludesMeepleTypesCurrentPlayerDoesNotHave(t *test
 		Tiles:        []tiles.Tile{tile},
 	}
 
-	game, err := NewFromTileSet(tileSet, nil, 2)
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -296,6 +310,37 @@ func TestGameGetLegalMovesForExcludesMeepleTypesCurrentPlayerDoesNotHave(t *test
 	}
 }
 
+func TestGameGetLegalMovesForIncludesBigMeepleWithInnsAndCathedralsTiles(t *testing.T) {
+	tile := tiletemplates.MonasteryWithSingleRoadInn()
+	tileSet := tilesets.TileSet{
+		// non-default starting tile - limits number of possible positions to one
+		StartingTile: tiletemplates.ThreeCityEdgesConnected(),
+		Tiles:        []tiles.Tile{tile},
+	}
+
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	basePlacement := game.GetTilePlacementsFor(tile)[0]
+	expected := []elements.PlacedTile{basePlacement}
+	for i := range basePlacement.Features {
+		for _, meepleType := range []elements.MeepleType{elements.NormalMeeple, elements.BigMeeple} {
+			ptile := basePlacement.DeepClone()
+			ptile.Features[i].Meeple = elements.Meeple{
+				Type: meepleType, PlayerID: 1,
+			}
+			expected = append(expected, ptile)
+		}
+	}
+	actual := game.GetLegalMovesFor(basePlacement)
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, got %#v instead", expected, actual)
+	}
+}
+
 func TestGameSwapCurrentTileReturnsErrorOnOriginalGame(t *testing.T) {
 	tileSet := tilesets.StandardTileSet()
 	tileSet.Tiles = []tiles.Tile{
@@ -305,7 +350,7 @@ func TestGameSwapCurrentTileReturnsErrorOnOriginalGame(t *testing.T) {
 	deckStack := stack.NewOrdered(tileSet.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -337,7 +382,7 @@ func TestGameSwapCurrentTileSwapTileOnCloneWithSwappableTiles(t *testing.T) {
 	deckStack := stack.NewOrdered(tileSet.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -362,7 +407,7 @@ func TestGamePlayTurnDoesNotMutateInput(t *testing.T) {
 	tileSet := tilesets.StandardTileSet()
 	tileSet.Tiles = []tiles.Tile{tiletemplates.SingleCityEdgeNoRoads().Rotate(2)}
 
-	game, err := NewFromTileSet(tileSet, nil, 2)
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -388,7 +433,7 @@ func TestGamePlayTurnDoesNotMutateInput(t *testing.T) {
 
 func TestGameGetPlayerById(t *testing.T) {
 	tileSet := tilesets.StandardTileSet()
-	game, err := NewFromTileSet(tileSet, nil, 2)
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -417,7 +462,7 @@ func TestGameGetPlayerById(t *testing.T) {
 
 func TestGameGetPlayerByIdNotFound(t *testing.T) {
 	tileSet := tilesets.StandardTileSet()
-	game, err := NewFromTileSet(tileSet, nil, 2)
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -437,7 +482,7 @@ func TestGetBoard(t *testing.T) {
 	deckStack := stack.NewOrdered(tileSet.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
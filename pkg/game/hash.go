@@ -0,0 +1,164 @@
+package game
+
+import (
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+)
+
+// The game's hash is a Zobrist-style hash - a sum of the pseudorandom keys
+// of the state's components, which can be updated incrementally as the components change:
+//   - tiles placed on the board (position and rotated features),
+//   - meeples placed on the board (position, feature and the meeple),
+//   - players (score, meeple counts and trade goods),
+//   - current turn (current player and whether it's an extra turn),
+//   - remaining tiles in the deck (including the current tile).
+//
+// The keys are summed rather than XOR-ed, so that the remaining tiles, which can contain
+// the same tile multiple times, are hashed as a multiset.
+// The keys are derived from the components with a fixed mixing function (instead of
+// the usual random table), so the hash is the same in every process.
+const (
+	placedTileHashTag uint64 = iota + 1
+	meepleHashTag
+	playerHashTag
+	turnHashTag
+	deckTileHashTag
+)
+
+// Returns the hash of the game state that does not depend on the order of the moves
+// that led to it, making it usable as a key of a transposition table.
+// Different states can have the same hash, although it is very unlikely.
+func (game *Game) Hash() uint64 {
+	return game.hash
+}
+
+// Recompute the hash of the whole game state, see Hash().
+func (game *Game) rehash() {
+	game.rehashMeeples()
+	hash := turnHash(game.currentPlayer, game.extraTurn) + game.playersHash()
+	for _, tile := range game.board.Tiles() {
+		// unplaced tiles are zero values
+		if tile.Features != nil {
+			hash += placedTileHash(tile)
+		}
+	}
+	for _, meepleHash := range game.meepleHashes {
+		hash += meepleHash
+	}
+	for _, tile := range game.deck.GetRemaining() {
+		hash += deckTileHash(tile)
+	}
+	game.hash = hash
+}
+
+// Update the hash after the meeples were placed or removed on the tiles
+// at the given positions.
+//
+// A removed meeple cannot be looked up on the board anymore
+// so the keys of the meeples on the board are kept in `meepleHashes`.
+func (game *Game) rehashMeeplesAt(positions []position.Position) {
+	for _, pos := range positions {
+		game.hash -= game.meepleHashes[pos]
+		delete(game.meepleHashes, pos)
+		tile, ok := game.board.GetTileAt(pos)
+		if !ok {
+			continue
+		}
+		if meepleHash, ok := tileMeepleHash(tile); ok {
+			game.meepleHashes[pos] = meepleHash
+			game.hash += meepleHash
+		}
+	}
+}
+
+// Rebuild the keys of the meeples on the board without updating the hash.
+func (game *Game) rehashMeeples() {
+	game.meepleHashes = map[position.Position]uint64{}
+	for _, tile := range game.board.Tiles() {
+		if meepleHash, ok := tileMeepleHash(tile); ok {
+			game.meepleHashes[tile.Position] = meepleHash
+		}
+	}
+}
+
+func (game *Game) playersHash() uint64 {
+	var hash uint64
+	for _, player := range game.players {
+		hash += playerHash(player)
+	}
+	return hash
+}
+
+func placedTileHash(tile elements.PlacedTile) uint64 {
+	hash := mixHash(placedTileHashTag, uint64(uint16(tile.Position.X())), uint64(uint16(tile.Position.Y())))
+	for _, feat := range tile.Features {
+		hash = mixHash(hash, featureHashValue(feat.Feature))
+	}
+	return hash
+}
+
+// Returns the key of the meeple on the tile, if there is any.
+// A tile can hold at most a single meeple.
+func tileMeepleHash(tile elements.PlacedTile) (uint64, bool) {
+	for _, feat := range tile.Features {
+		if feat.Meeple.Type == elements.NoneMeeple {
+			continue
+		}
+		return mixHash(
+			meepleHashTag,
+			uint64(uint16(tile.Position.X())),
+			uint64(uint16(tile.Position.Y())),
+			featureHashValue(feat.Feature),
+			uint64(feat.Meeple.Type),
+			uint64(feat.Meeple.PlayerID),
+		), true
+	}
+	return 0, false
+}
+
+func playerHash(player elements.Player) uint64 {
+	hash := mixHash(playerHashTag, uint64(player.ID()), uint64(player.Score()))
+	for meepleType := range elements.MeepleTypeCount {
+		hash = mixHash(hash, uint64(player.MeepleCount(elements.MeepleType(meepleType))))
+	}
+	for tradeGood := range elements.TradeGoodCount {
+		hash = mixHash(hash, uint64(player.TradeGoodCount(elements.TradeGood(tradeGood))))
+	}
+	return hash
+}
+
+func turnHash(currentPlayer int, extraTurn bool) uint64 {
+	var extraTurnValue uint64
+	if extraTurn {
+		extraTurnValue = 1
+	}
+	return mixHash(turnHashTag, uint64(currentPlayer), extraTurnValue)
+}
+
+func deckTileHash(tile tiles.Tile) uint64 {
+	hash := deckTileHashTag
+	for _, feat := range tile.Features {
+		hash = mixHash(hash, featureHashValue(feat))
+	}
+	return hash
+}
+
+func featureHashValue(feat feature.Feature) uint64 {
+	return uint64(uint8(feat.FeatureType)) |
+		uint64(uint8(feat.ModifierType))<<8 |
+		uint64(feat.Sides)<<16
+}
+
+// Mixes the values into the given hash with the SplitMix64 finalizer.
+func mixHash(hash uint64, values ...uint64) uint64 {
+	for _, value := range values {
+		hash ^= value
+		hash += 0x9e3779b97f4a7c15
+		hash = (hash ^ (hash >> 30)) * 0xbf58476d1ce4e5b9
+		hash = (hash ^ (hash >> 27)) * 0x94d049bb133111eb
+		hash ^= hash >> 31
+	}
+	return hash
+}
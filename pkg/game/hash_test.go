@@ -0,0 +1,122 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func checkHashMatchesRecomputedHash(t *testing.T, game *Game) {
+	clone := game.DeepClone()
+	clone.rehash()
+	if game.Hash() != clone.Hash() {
+		t.Fatalf("expected %#v, got %#v instead", clone.Hash(), game.Hash())
+	}
+}
+
+func TestGameHashMatchesRecomputedHashDuringGame(t *testing.T) {
+	tileSets := []tilesets.TileSet{
+		tilesets.StandardTileSet(),
+		tilesets.InnsAndCathedralsTileSet(),
+		tilesets.TradersAndBuildersTileSet(),
+		tilesets.GardensTileSet(),
+	}
+	for i, tileSet := range tileSets {
+		deckStack := stack.NewSeeded(tileSet.Tiles, int64(i))
+		deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+		game, err := NewFromDeck(deck, nil, 3, rules.Standard())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		game.EnableUndo()
+		checkHashMatchesRecomputedHash(t, game)
+
+		rng := rand.New(rand.NewSource(int64(i))) //nolint:gosec// Weak number generator is sufficent in our case
+		hashes := []uint64{game.Hash()}
+		for {
+			tile, err := game.GetCurrentTile()
+			if err != nil {
+				break
+			}
+			moves := []elements.PlacedTile{}
+			for _, placement := range game.GetTilePlacementsFor(tile) {
+				moves = append(moves, game.GetLegalMovesFor(placement)...)
+			}
+			if err = game.PlayTurn(moves[rng.Intn(len(moves))]); err != nil {
+				t.Fatal(err.Error())
+			}
+			checkHashMatchesRecomputedHash(t, game)
+			hashes = append(hashes, game.Hash())
+		}
+
+		if _, err := game.Finalize(); err != nil {
+			t.Fatal(err.Error())
+		}
+		checkHashMatchesRecomputedHash(t, game)
+
+		// undoing the turns restores the previous hashes
+		for j := len(hashes) - 2; j >= 0; j-- {
+			if err := game.UndoTurn(); err != nil {
+				t.Fatal(err.Error())
+			}
+			if game.Hash() != hashes[j] {
+				t.Fatalf("expected %#v, got %#v instead", hashes[j], game.Hash())
+			}
+			checkHashMatchesRecomputedHash(t, game)
+		}
+	}
+}
+
+func TestGameHashIsSameForTranspositions(t *testing.T) {
+	tileSet := tilesets.StandardTileSet()
+	deckStack := stack.NewSeeded(tileSet.Tiles, 1)
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// tiles placed next to the starting tile without meeples do not affect each other
+	roadTile := tiletemplates.StraightRoads()
+	monasteryTile := tiletemplates.MonasteryWithoutRoads()
+	roadMove := game.GetTilePlacementsFor(roadTile)[0]
+	var monasteryMove elements.PlacedTile
+	for _, placement := range game.GetTilePlacementsFor(monasteryTile) {
+		if placement.Position != roadMove.Position {
+			monasteryMove = placement
+			break
+		}
+	}
+
+	playMoves := func(moves ...elements.PlacedTile) *Game {
+		clone := game.DeepCloneWithSwappableTiles()
+		for _, move := range moves {
+			if err := clone.SwapCurrentTile(elements.ToTile(move)); err != nil {
+				t.Fatal(err.Error())
+			}
+			if err := clone.PlayTurn(move); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+		return clone
+	}
+	first := playMoves(roadMove, monasteryMove)
+	second := playMoves(monasteryMove, roadMove)
+	other := playMoves(roadMove)
+
+	if first.Hash() != second.Hash() {
+		t.Fatalf("expected %#v, got %#v instead", first.Hash(), second.Hash())
+	}
+	if first.Serialized().Hash != first.Hash() {
+		t.Fatalf("expected %#v, got %#v instead", first.Hash(), first.Serialized().Hash)
+	}
+	if first.Hash() == other.Hash() || first.Hash() == game.Hash() {
+		t.Fatalf("expected different hashes, got %#v for all of them", first.Hash())
+	}
+}
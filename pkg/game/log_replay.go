@@ -0,0 +1,213 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/logger"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+)
+
+var (
+	ErrInvalidLog     = errors.New("the log does not describe a valid game")
+	ErrReplayDiverged = errors.New("the replayed game diverged from the log")
+)
+
+// Returned by NewFromLog() when the replayed game does not match the logged one.
+type ReplayDivergenceError struct {
+	// number of the turn (counted from 1) at which the replay diverged,
+	// for the final scoring this is the number of the last played turn
+	Turn int
+	// index of the log entry that could not be reproduced
+	EntryIndex int
+	err        error
+}
+
+func (err *ReplayDivergenceError) Error() string {
+	return fmt.Sprintf("turn %v (log entry %v): %v", err.Turn, err.EntryIndex, err.err)
+}
+
+func (err *ReplayDivergenceError) Unwrap() error {
+	return err.err
+}
+
+// Internal struct holding the state of the log replay done by NewFromLog()
+type logReplay struct {
+	game    *Game
+	entries []logger.Entry
+	index   int
+}
+
+// Rebuild the game from the log entries created by a previous game.
+//
+// The deck is recreated from the start event, after which all moves
+// (including the undone turns) are replayed on the new game.
+// The score reports obtained while replaying are compared with the logged ones
+// and a *ReplayDivergenceError is returned for the first one that differs.
+//
+// The replayed events are logged again using the given logger.
+// The turns of the returned game can be reverted, see EnableUndo().
+func NewFromLog(entries []logger.Entry, log logger.Logger) (*Game, error) {
+	if len(entries) == 0 || entries[0].Event != logger.StartEvent {
+		return nil, fmt.Errorf(
+			"%w: the log has to begin with the %q event", ErrInvalidLog, logger.StartEvent,
+		)
+	}
+
+	var start logger.StartEntryContent
+	if err := json.Unmarshal(entries[0].Content, &start); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLog, err)
+	}
+	if start.PlayerCount < 1 || start.PlayerCount > math.MaxUint8 {
+		return nil, fmt.Errorf(
+			"%w: invalid player count %v", ErrInvalidLog, start.PlayerCount,
+		)
+	}
+
+	deckStack := stack.NewOrdered(start.Stack)
+	deck := deck.Deck{
+		Stack:        &deckStack,
+		StartingTile: start.StartingTile,
+	}
+	ruleset := rules.Standard()
+	if start.Ruleset != nil {
+		ruleset = *start.Ruleset
+	}
+	game, err := NewFromDeck(deck, log, uint8(start.PlayerCount), ruleset)
+	if err != nil {
+		return nil, err
+	}
+	// the logged turns are compared against the turn history
+	// and the logged undos are replayed with UndoTurn()
+	game.EnableUndo()
+
+	replay := &logReplay{game: game, entries: entries}
+	for replay.index = 1; replay.index < len(entries); replay.index++ {
+		if err := replay.replayEntry(); err != nil {
+			return nil, err
+		}
+	}
+
+	return game, nil
+}
+
+func (replay *logReplay) replayEntry() error {
+	entry := replay.entries[replay.index]
+	switch entry.Event {
+	case logger.PlaceTileEvent:
+		return replay.replayPlaceTile(entry)
+	case logger.UndoTurnEvent:
+		return replay.replayUndoTurn(entry)
+	case logger.ScoreEvent:
+		// score events logged after turns are consumed by replayPlaceTile(),
+		// so this can only be the meeple report of the final scoring
+		if !replay.nextEventIs(logger.FinalScoreEvent) {
+			return fmt.Errorf(
+				"%w: unexpected %q event at entry %v", ErrInvalidLog, entry.Event, replay.index,
+			)
+		}
+		var content logger.ScoreEntryContent
+		if err := json.Unmarshal(entry.Content, &content); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidLog, err)
+		}
+		scores := replay.game.board.ScoreMeeples(false)
+		scores.Join(replay.game.scoreTradeGoods())
+		return replay.compareScores(content.Scores, scores)
+	case logger.FinalScoreEvent:
+		var content logger.FinalScoreEntryContent
+		if err := json.Unmarshal(entry.Content, &content); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidLog, err)
+		}
+		scores, err := replay.game.Finalize()
+		if err != nil {
+			return replay.diverged(len(replay.game.history), err)
+		}
+		return replay.compareScores(content.Scores, scores)
+	default:
+		return fmt.Errorf(
+			"%w: unknown %q event at entry %v", ErrInvalidLog, entry.Event, replay.index,
+		)
+	}
+}
+
+func (replay *logReplay) replayPlaceTile(entry logger.Entry) error {
+	var content logger.PlaceTileEntryContent
+	if err := json.Unmarshal(entry.Content, &content); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLog, err)
+	}
+
+	turn := len(replay.game.history) + 1
+	if playerID := replay.game.CurrentPlayer().ID(); content.PlayerID != playerID {
+		return replay.diverged(turn, fmt.Errorf(
+			"expected player %v to play the turn, got %v instead", playerID, content.PlayerID,
+		))
+	}
+	if err := replay.game.PlayTurn(content.Move); err != nil {
+		return replay.diverged(turn, err)
+	}
+
+	// turns without any scoring do not log a score event
+	logged := elements.NewScoreReport()
+	if replay.nextEventIs(logger.ScoreEvent) && !replay.eventIs(replay.index+2, logger.FinalScoreEvent) {
+		replay.index++
+		var scoreContent logger.ScoreEntryContent
+		if err := json.Unmarshal(replay.entries[replay.index].Content, &scoreContent); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidLog, err)
+		}
+		logged = scoreContent.Scores
+	}
+	return replay.compareScores(logged, replay.game.history[turn-1].scoreReport)
+}
+
+func (replay *logReplay) replayUndoTurn(entry logger.Entry) error {
+	var content logger.UndoTurnEntryContent
+	if err := json.Unmarshal(entry.Content, &content); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLog, err)
+	}
+
+	turn := len(replay.game.history)
+	if turn != 0 {
+		record := replay.game.history[turn-1]
+		playerID := replay.game.players[record.player].ID()
+		if content.PlayerID != playerID || content.Move.Position != record.move.Position {
+			return replay.diverged(turn, fmt.Errorf(
+				"expected the undone move to be %v played by player %v, got %v played by player %v instead",
+				record.move.Position, playerID, content.Move.Position, content.PlayerID,
+			))
+		}
+	}
+	if err := replay.game.UndoTurn(); err != nil {
+		return replay.diverged(turn, err)
+	}
+	return nil
+}
+
+func (replay *logReplay) compareScores(logged elements.ScoreReport, actual elements.ScoreReport) error {
+	if !logged.Equals(actual) {
+		return replay.diverged(len(replay.game.history), fmt.Errorf(
+			"logged score report %v does not match the recomputed one %v", logged, actual,
+		))
+	}
+	return nil
+}
+
+func (replay *logReplay) diverged(turn int, err error) error {
+	return &ReplayDivergenceError{
+		Turn:       turn,
+		EntryIndex: replay.index,
+		err:        fmt.Errorf("%w: %w", ErrReplayDiverged, err),
+	}
+}
+
+func (replay *logReplay) nextEventIs(event logger.EventType) bool {
+	return replay.eventIs(replay.index+1, event)
+}
+
+func (replay *logReplay) eventIs(index int, event logger.EventType) bool {
+	return index < len(replay.entries) && replay.entries[index].Event == event
+}
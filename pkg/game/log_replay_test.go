@@ -0,0 +1,198 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/logger"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+// Plays a seeded game with random moves, undoing every `undoInterval`-th turn,
+// and returns it along with its log entries.
+func playLoggedGame(t *testing.T, undoInterval int) (*Game, []logger.Entry) {
+	buffer := bytes.NewBuffer(nil)
+	log := logger.New(buffer)
+
+	tileSet := tilesets.StandardTileSet()
+	deckStack := stack.NewSeeded(tileSet.Tiles, 7)
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+	game, err := NewFromDeck(deck, &log, 3, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	game.EnableUndo()
+
+	rng := rand.New(rand.NewSource(7)) //nolint:gosec// Weak number generator is sufficent in our case
+	for turn := 1; ; turn++ {
+		tile, err := game.GetCurrentTile()
+		if err != nil {
+			break
+		}
+		moves := []elements.PlacedTile{}
+		for _, placement := range game.GetTilePlacementsFor(tile) {
+			moves = append(moves, game.GetLegalMovesFor(placement)...)
+		}
+		if err = game.PlayTurn(moves[rng.Intn(len(moves))]); err != nil {
+			t.Fatal(err.Error())
+		}
+		if undoInterval != 0 && turn%undoInterval == 0 {
+			if err = game.UndoTurn(); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	}
+
+	if _, err = game.Finalize(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	entries, err := logger.ReadEntries(buffer)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return game, entries
+}
+
+func TestNewFromLogReplaysFullGame(t *testing.T) {
+	expected, entries := playLoggedGame(t, 5)
+
+	actual, err := NewFromLog(entries, nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if !reflect.DeepEqual(expected.Serialized().Players, actual.Serialized().Players) {
+		t.Fatalf(
+			"expected players %#v, got %#v instead",
+			expected.Serialized().Players,
+			actual.Serialized().Players,
+		)
+	}
+	// the board of the replayed game uses a different tile set order
+	// so the tiles have to be compared by their position
+	expectedTiles := map[position.Position]elements.PlacedTile{}
+	for _, tile := range expected.GetBoard().Tiles() {
+		expectedTiles[tile.Position] = tile
+	}
+	actualTiles := map[position.Position]elements.PlacedTile{}
+	for _, tile := range actual.GetBoard().Tiles() {
+		actualTiles[tile.Position] = tile
+	}
+	if !reflect.DeepEqual(expectedTiles, actualTiles) {
+		t.Fatalf("expected tiles %#v, got %#v instead", expectedTiles, actualTiles)
+	}
+}
+
+func TestNewFromLogReportsFirstDivergentTurn(t *testing.T) {
+	_, entries := playLoggedGame(t, 0)
+
+	// tamper with the score report of the second turn that scored any points
+	expectedTurn := 0
+	tamperedIndex := -1
+	scoringTurns := 0
+	for i, entry := range entries {
+		if entry.Event == logger.PlaceTileEvent {
+			expectedTurn++
+			continue
+		}
+		if entry.Event != logger.ScoreEvent {
+			continue
+		}
+		scoringTurns++
+		if scoringTurns == 2 {
+			tamperedIndex = i
+			break
+		}
+	}
+	if tamperedIndex == -1 {
+		t.Fatal("the played game did not score any points twice")
+	}
+
+	var content logger.ScoreEntryContent
+	if err := json.Unmarshal(entries[tamperedIndex].Content, &content); err != nil {
+		t.Fatal(err.Error())
+	}
+	content.Scores.ReceivedPoints[elements.ID(1)]++
+	tamperedContent, err := json.Marshal(content)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	entries[tamperedIndex].Content = tamperedContent
+
+	_, err = NewFromLog(entries, nil)
+	if !errors.Is(err, ErrReplayDiverged) {
+		t.Fatalf("expected ErrReplayDiverged, got %#v instead", err)
+	}
+	var divergenceErr *ReplayDivergenceError
+	if !errors.As(err, &divergenceErr) {
+		t.Fatalf("expected ReplayDivergenceError, got %#v instead", err)
+	}
+	if divergenceErr.Turn != expectedTurn {
+		t.Fatalf("expected turn %v, got %v instead", expectedTurn, divergenceErr.Turn)
+	}
+	if divergenceErr.EntryIndex != tamperedIndex {
+		t.Fatalf("expected entry %v, got %v instead", tamperedIndex, divergenceErr.EntryIndex)
+	}
+}
+
+func TestNewFromLogReturnsErrorWhenStartEventIsMissing(t *testing.T) {
+	_, entries := playLoggedGame(t, 0)
+
+	_, err := NewFromLog(entries[1:], nil)
+	if !errors.Is(err, ErrInvalidLog) {
+		t.Fatalf("expected ErrInvalidLog, got %#v instead", err)
+	}
+}
+
+func TestNewFromLogKeepsTilesDiscardedBeforeFirstTurn(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	log := logger.New(buffer)
+
+	// a tile filled with a city cannot be placed next to the monastery
+	deckStack := stack.NewOrdered([]tiles.Tile{
+		tiletemplates.FourCityEdgesConnectedShield(),
+		tiletemplates.MonasteryWithoutRoads(),
+		tiletemplates.MonasteryWithoutRoads(),
+	})
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tiletemplates.MonasteryWithoutRoads()}
+	expected, err := NewFromDeck(deck, &log, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if expected.deck.GetRemainingTileCount() != 2 {
+		t.Fatalf("expected %#v, got %#v instead", 2, expected.deck.GetRemainingTileCount())
+	}
+	move := elements.ToPlacedTile(tiletemplates.MonasteryWithoutRoads())
+	move.Position = position.New(0, 1)
+	if err = expected.PlayTurn(move); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	entries, err := logger.ReadEntries(buffer)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	actual, err := NewFromLog(entries, nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if !reflect.DeepEqual(expected.deck.TileSet(), actual.deck.TileSet()) {
+		t.Fatalf("expected tile set %#v, got %#v instead", expected.deck.TileSet(), actual.deck.TileSet())
+	}
+	if !reflect.DeepEqual(expected.Serialized(), actual.Serialized()) {
+		t.Fatalf("expected %#v, got %#v instead", expected.Serialized(), actual.Serialized())
+	}
+}
@@ -1,11 +1,15 @@
 package position
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
 )
 
+var ErrInvalidBinaryPosition = errors.New("binary position has to be exactly 4 bytes long")
+
 type Position struct {
 	// int8 would be fine for base game (72 tiles) but let's be a bit more generous
 	x int16
@@ -37,6 +41,11 @@ func (pos Position) Rotate(rotations uint) Position {
 	return pos
 }
 
+// Reflects the position across the Y axis.
+func (pos Position) Reflect() Position {
+	return New(-pos.x, pos.y)
+}
+
 /*
 Returns position neighbouring (0,0) from the given side.
 Valid sides are either a single half-edge side (e.g. side.TopLeftEdge) or a single primary side (e.g. side.Right)
@@ -77,3 +86,17 @@ func (pos *Position) UnmarshalText(text []byte) error {
 	_, err := fmt.Sscanf(string(text), "%v,%v", &pos.x, &pos.y)
 	return err
 }
+
+func (pos Position) MarshalBinary() ([]byte, error) {
+	data := binary.BigEndian.AppendUint16(nil, uint16(pos.x))
+	return binary.BigEndian.AppendUint16(data, uint16(pos.y)), nil
+}
+
+func (pos *Position) UnmarshalBinary(data []byte) error {
+	if len(data) != 4 {
+		return ErrInvalidBinaryPosition
+	}
+	pos.x = int16(binary.BigEndian.Uint16(data[:2]))
+	pos.y = int16(binary.BigEndian.Uint16(data[2:]))
+	return nil
+}
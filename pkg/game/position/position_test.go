@@ -1,6 +1,7 @@
 package position
 
 import (
+	"errors"
 	"slices"
 	"testing"
 
@@ -164,6 +165,31 @@ func TestPositionUnmarshalTextWithNegativeCoords(t *testing.T) {
 	}
 }
 
+func TestPositionUnmarshalBinaryWithNegativeCoords(t *testing.T) {
+	expected := New(-31, 5)
+	data, err := expected.MarshalBinary()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	actual := Position{}
+	err = actual.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if actual != expected {
+		t.Fatalf("expected %#v, got %#v instead", expected, actual)
+	}
+}
+
+func TestPositionUnmarshalBinaryReturnsErrorForInvalidLength(t *testing.T) {
+	actual := Position{}
+	err := actual.UnmarshalBinary([]byte{0, 1, 0})
+	if !errors.Is(err, ErrInvalidBinaryPosition) {
+		t.Fatalf("expected ErrInvalidBinaryPosition, got %#v instead", err)
+	}
+}
+
 func TestPositionRotate(t *testing.T) {
 	position := New(2, 3)
 
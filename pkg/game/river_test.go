@@ -0,0 +1,137 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/logger"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func placeRiverTile(t *testing.T, board elements.Board, tile tiles.Tile, pos position.Position) {
+	placedTile := elements.ToPlacedTile(tile)
+	placedTile.Position = pos
+	if !board.CanBePlaced(placedTile) {
+		t.Fatalf("expected %#v to be placeable", placedTile)
+	}
+	if _, err := board.PlaceTile(placedTile); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestBoardGetTilePlacementsForRiverTileOnlyContinuesRiver(t *testing.T) {
+	board := NewBoard(tilesets.RiverTileSet(), rules.Standard())
+
+	placements := board.GetTilePlacementsFor(tiletemplates.RiverTurn())
+
+	// the turn can go either left or right
+	expectedCount := 2
+	if len(placements) != expectedCount {
+		t.Fatalf("expected %#v placements, got %#v instead", expectedCount, len(placements))
+	}
+	expectedPosition := position.New(0, -1)
+	for _, placement := range placements {
+		if placement.Position != expectedPosition {
+			t.Fatalf("expected position %#v, got %#v instead", expectedPosition, placement.Position)
+		}
+	}
+}
+
+func TestBoardCanBePlacedReturnsFalseWhenRiverTurnsTwiceInSameDirection(t *testing.T) {
+	board := NewBoard(tilesets.RiverTileSet(), rules.Standard())
+	// the river flows down from the spring and turns right (to the left side of the board)
+	placeRiverTile(t, board, tiletemplates.RiverTurn().Rotate(1), position.New(0, -1))
+	// a straight tile between the turns doesn't change anything
+	placeRiverTile(t, board, tiletemplates.StraightRiver().Rotate(1), position.New(-1, -1))
+
+	rightTurn := elements.ToPlacedTile(tiletemplates.RiverTurn().Rotate(2))
+	rightTurn.Position = position.New(-2, -1)
+	if board.CanBePlaced(rightTurn) {
+		t.Fatalf("expected %#v not to be placeable", rightTurn)
+	}
+
+	leftTurn := elements.ToPlacedTile(tiletemplates.RiverTurn().Rotate(3))
+	leftTurn.Position = position.New(-2, -1)
+	if !board.CanBePlaced(leftTurn) {
+		t.Fatalf("expected %#v to be placeable", leftTurn)
+	}
+}
+
+func TestBoardCanBePlacedReturnsFalseWhenTileDoesNotContinueRiver(t *testing.T) {
+	board := NewBoard(tilesets.RiverTileSet(), rules.Standard())
+
+	// the river tile is next to the spring but not connected to its river
+	river := elements.ToPlacedTile(tiletemplates.StraightRiver().Rotate(1))
+	river.Position = position.New(1, 0)
+	if board.CanBePlaced(river) {
+		t.Fatalf("expected %#v not to be placeable", river)
+	}
+
+	// the regular tile cannot cover the river
+	regular := elements.ToPlacedTile(tiletemplates.MonasteryWithoutRoads())
+	regular.Position = position.New(0, -1)
+	if board.CanBePlaced(regular) {
+		t.Fatalf("expected %#v not to be placeable", regular)
+	}
+}
+
+func TestBoardGetLegalMovesForDoesNotPlaceMeeplesOnRiver(t *testing.T) {
+	board := NewBoard(tilesets.RiverTileSet(), rules.Standard())
+	placement := elements.ToPlacedTile(tiletemplates.StraightRiver())
+	placement.Position = position.New(0, -1)
+
+	for _, move := range board.GetLegalMovesFor(placement) {
+		river := move.GetPlacedFeatureAtSide(side.Top, feature.River)
+		if river.Meeple.Type != elements.NoneMeeple {
+			t.Fatalf("expected no meeple on river, got %#v instead", move)
+		}
+	}
+}
+
+func TestGameWithRiverTileSetPlaysRiverFirst(t *testing.T) {
+	tileSet := tilesets.RiverTileSet()
+	deckStack := stack.NewSeededInGroups(tileSet.Tiles, tileSet.Groups, 7)
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+	nullLogger := logger.NewEmpty()
+	game, err := NewFromDeck(deck, &nullLogger, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	riverTileCount := tileSet.Groups[0] + tileSet.Groups[1]
+	rng := rand.New(rand.NewSource(7)) //nolint:gosec// Weak number generator is sufficent in our case
+	for turn := 1; ; turn++ {
+		tile, err := game.GetCurrentTile()
+		if err != nil {
+			break
+		}
+		hasRiver := false
+		for _, feat := range tile.Features {
+			hasRiver = hasRiver || feat.FeatureType == feature.River
+		}
+		if hasRiver != (turn <= riverTileCount) {
+			t.Fatalf("unexpected tile %#v in turn %v", tile, turn)
+		}
+
+		moves := []elements.PlacedTile{}
+		for _, placement := range game.GetTilePlacementsFor(tile) {
+			moves = append(moves, game.GetLegalMovesFor(placement)...)
+		}
+		if err = game.PlayTurn(moves[rng.Intn(len(moves))]); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	if _, err = game.Finalize(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
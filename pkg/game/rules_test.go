@@ -0,0 +1,137 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/test"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func newOrderedGameWithRuleset(t *testing.T, tileSet tilesets.TileSet, ruleset rules.Ruleset) *Game {
+	deckStack := stack.NewOrdered(tileSet.Tiles)
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+	game, err := NewFromDeck(deck, nil, 2, ruleset)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return game
+}
+
+func checkFinalScores(t *testing.T, game *Game, expectedScores []uint32) {
+	scores, err := game.Finalize()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i, expected := range expectedScores {
+		actual := scores.ReceivedPoints[elements.ID(i+1)]
+		if actual != expected {
+			t.Fatalf("expected %#v, got %#v instead (player %v)", expected, actual, i+1)
+		}
+	}
+}
+
+func TestNewFromDeckGivesPlayersMeepleCountFromRuleset(t *testing.T) {
+	ruleset := rules.Standard()
+	ruleset.MeepleCount = 5
+	game := newOrderedGameWithRuleset(t, tilesets.StandardTileSet(), ruleset)
+
+	for _, player := range game.players {
+		actual := player.MeepleCount(elements.NormalMeeple)
+		if actual != ruleset.MeepleCount {
+			t.Fatalf("expected %#v, got %#v instead", ruleset.MeepleCount, actual)
+		}
+	}
+}
+
+/*
+|                 0    1
+
+|               |   |.....
+|               .\ /......
+|0              --0----1-!
+|               ..........
+|               ..........
+*/
+func TestFinalScoreRoadIsNotScoredWhenIncompleteRoadsAreWorthNothing(t *testing.T) {
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles:        []tiles.Tile{tiletemplates.StraightRoads()},
+	}
+	ruleset := rules.Standard()
+	ruleset.RoadTilePoints.Incomplete = 0
+	game := newOrderedGameWithRuleset(t, tileSet, ruleset)
+
+	test.MakeTurn{
+		Game:         game,
+		TestingT:     t,
+		Position:     position.New(1, 0),
+		MeepleParams: test.MeepleParams{MeepleType: elements.NormalMeeple, FeatureSide: side.Right, FeatureType: feature.Road},
+	}.Run()
+
+	checkFinalScores(t, game, []uint32{0, 0})
+}
+
+/*
+|                 0    1
+
+|               .....
+|               .....
+|1              ..1..
+|               ./ \.
+|               |   |.....
+|               |   |..!..
+|               .\ /......
+|0              --0----2--
+|               ..........
+|               ..........
+
+	Two different fields neighbour the same city
+*/
+func TestFinalScoreFieldsWithFirstEditionRulesScoresCities(t *testing.T) {
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles: []tiles.Tile{
+			tiletemplates.SingleCityEdgeNoRoads().Rotate(2),
+			tiletemplates.StraightRoads(),
+		},
+	}
+
+	for _, tc := range []struct {
+		name           string
+		ruleset        rules.Ruleset
+		expectedScores []uint32
+	}{
+		// each field scores the city separately
+		{"standard", rules.Standard(), []uint32{3, 3}},
+		// the city is scored once for the tied majority of farmers on both fields
+		{"first edition", rules.FirstEdition(), []uint32{4, 4}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			game := newOrderedGameWithRuleset(t, tileSet, tc.ruleset)
+
+			test.MakeTurn{
+				Game:         game,
+				TestingT:     t,
+				Position:     position.New(0, 1),
+				MeepleParams: test.MeepleParams{MeepleType: elements.NormalMeeple, FeatureSide: side.Top, FeatureType: feature.Field},
+			}.Run()
+			test.MakeTurn{
+				Game:         game,
+				TestingT:     t,
+				Position:     position.New(1, 0),
+				MeepleParams: test.MeepleParams{MeepleType: elements.NormalMeeple, FeatureSide: side.TopLeftEdge, FeatureType: feature.Field},
+			}.Run()
+
+			checkFinalScores(t, game, tc.expectedScores)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/test"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
@@ -55,7 +56,7 @@ func TestScoringMidGame(t *testing.T) { // nolint: gocyclo
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -164,7 +165,7 @@ func TestMidGameScorePreventCalculatingSameMeepleOnRoadsMultipleTimes(t *testing
 	deckStack := stack.NewOrdered(tileset.Tiles)
 	deck := deck.Deck{Stack: &deckStack, StartingTile: tileset.StartingTile}
 
-	game, err := NewFromDeck(deck, nil, 2)
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
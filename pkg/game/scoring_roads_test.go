@@ -6,6 +6,7 @@ import (
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
@@ -23,7 +24,7 @@ Roads:
 */
 func TestBoardScoreRoadLoop(t *testing.T) {
 	var report elements.ScoreReport
-	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet())
+	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -97,7 +98,7 @@ Roads:
   - 4 -	3
 */
 func TestBoardScoreRoadLoopCrossroad(t *testing.T) {
-	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet())
+	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -157,7 +158,7 @@ Roads:
 */
 func TestBoardScoreRoadCityMonastery(t *testing.T) {
 	var report elements.ScoreReport
-	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet())
+	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -210,7 +211,7 @@ Roads:
 */
 func TestBoardScoreRoadMultipleMeeplesOnSameRoad(t *testing.T) {
 	var report elements.ScoreReport
-	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet())
+	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -284,7 +285,7 @@ board drawing:
 */
 func TestScoreRoadPreventCheckingWithNoSideAtTile5(t *testing.T) {
 	var report elements.ScoreReport
-	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet())
+	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	board := boardInterface.(*board)
 
 	tiles := []elements.PlacedTile{
@@ -369,3 +370,160 @@ func TestScoreRoadPreventCheckingWithNoSideAtTile5(t *testing.T) {
 		}
 	}
 }
+
+/*
+Test scoring finished road with an inn (on tile 1) which doubles its points
+Roads:
+
+	3 -	0 -	1 -	2
+*/
+func TestBoardScoreFinishedRoadWithInn(t *testing.T) {
+	board := NewBoard(tilesets.InnsAndCathedralsTileSet(), rules.Standard())
+	expectedScore := uint32(8)
+
+	tiles := []elements.PlacedTile{
+		elements.ToPlacedTile(tiletemplates.StraightRoadsInn()),
+		elements.ToPlacedTile(tiletemplates.MonasteryWithSingleRoad().Rotate(1)),
+		elements.ToPlacedTile(tiletemplates.MonasteryWithSingleRoad().Rotate(3)),
+	}
+	tiles[0].GetPlacedFeatureAtSide(side.Right, feature.Road).Meeple = elements.Meeple{
+		Type: elements.NormalMeeple, PlayerID: 1,
+	}
+	tiles[0].Position = position.New(1, 0)
+	tiles[1].Position = position.New(2, 0)
+	tiles[2].Position = position.New(-1, 0)
+
+	var report elements.ScoreReport
+	for _, tile := range tiles {
+		var err error
+		report, err = board.PlaceTile(tile)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	if report.ReceivedPoints[1] != expectedScore {
+		t.Fatalf("expected %#v, got %#v instead", expectedScore, report.ReceivedPoints[1])
+	}
+}
+
+func TestBoardScoreUnfinishedRoadWithInnAtGameEnd(t *testing.T) {
+	board := NewBoard(tilesets.InnsAndCathedralsTileSet(), rules.Standard())
+	expectedScore := uint32(0)
+
+	tile := elements.ToPlacedTile(tiletemplates.StraightRoadsInn())
+	tile.GetPlacedFeatureAtSide(side.Right, feature.Road).Meeple = elements.Meeple{
+		Type: elements.NormalMeeple, PlayerID: 1,
+	}
+	tile.Position = position.New(1, 0)
+	if _, err := board.PlaceTile(tile); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	report := board.ScoreMeeples(true)
+
+	if report.ReceivedPoints[1] != expectedScore {
+		t.Fatalf("expected %#v, got %#v instead", expectedScore, report.ReceivedPoints[1])
+	}
+	if len(report.ReturnedMeeples[1]) != 1 {
+		t.Fatalf("expected %#v returned meeple, got %#v instead", 1, report.ReturnedMeeples[1])
+	}
+}
+
+/*
+Test that the meeples on the road going through the starting tile are found
+from its unfinished end (which used to be mistaken for a loop).
+Roads:
+
+	1 -	0 -	2
+*/
+func TestBoardGetMeeplesOnRoadGoingThroughStartingTile(t *testing.T) {
+	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet(), rules.Standard())
+	board := boardInterface.(*board)
+
+	tileWithMeeple := elements.ToPlacedTile(tiletemplates.StraightRoads())
+	tileWithMeeple.Position = position.New(-1, 0)
+	tileWithMeeple.GetPlacedFeatureAtSide(side.Right, feature.Road).Meeple = elements.Meeple{
+		Type: elements.NormalMeeple, PlayerID: 1,
+	}
+	if _, err := board.PlaceTile(tileWithMeeple); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tile := elements.ToPlacedTile(tiletemplates.StraightRoads())
+	tile.Position = position.New(1, 0)
+	road := tile.GetPlacedFeatureAtSide(side.Left, feature.Road)
+	road.Meeple = elements.Meeple{Type: elements.NormalMeeple, PlayerID: 2}
+
+	expected := []elements.MeepleWithPosition{
+		elements.NewMeepleWithPosition(tileWithMeeple.Features[0].Meeple, tileWithMeeple.Position),
+	}
+	actual := board.getMeeplesOnConnectedRoads(tile, *road)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v instead", expected, actual)
+	}
+	if board.CanBePlaced(tile) {
+		t.Fatal("expected the meeple to not be placeable on the occupied road")
+	}
+}
+
+/*
+Test that the meeples on the road loop are still found from the tile closing it,
+which makes the meeple unplaceable on the closing road.
+Roads:
+
+	2 -	0 -	X
+	|		|
+	3 -	1 -	4
+*/
+func TestBoardGetMeeplesOnRoadClosedIntoLoop(t *testing.T) {
+	var boardInterface interface{} = NewBoard(tilesets.StandardTileSet(), rules.Standard())
+	board := boardInterface.(*board)
+
+	tiles := []elements.PlacedTile{
+		elements.ToPlacedTile(tiletemplates.StraightRoads()),
+		elements.ToPlacedTile(tiletemplates.RoadsTurn().Rotate(3)),
+		elements.ToPlacedTile(tiletemplates.RoadsTurn().Rotate(2)),
+		elements.ToPlacedTile(tiletemplates.RoadsTurn().Rotate(1)),
+	}
+	tiles[0].GetPlacedFeatureAtSide(side.Right, feature.Road).Meeple = elements.Meeple{
+		Type: elements.NormalMeeple, PlayerID: 1,
+	}
+	tiles[0].Position = position.New(0, -1)
+	tiles[1].Position = position.New(-1, 0)
+	tiles[2].Position = position.New(-1, -1)
+	tiles[3].Position = position.New(1, -1)
+	for _, tile := range tiles {
+		if _, err := board.PlaceTile(tile); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	closingTile := elements.ToPlacedTile(tiletemplates.RoadsTurn())
+	closingTile.Position = position.New(1, 0)
+	road := closingTile.GetPlacedFeatureAtSide(side.Left, feature.Road)
+	road.Meeple = elements.Meeple{Type: elements.NormalMeeple, PlayerID: 2}
+
+	expected := elements.NewMeepleWithPosition(tiles[0].Features[0].Meeple, tiles[0].Position)
+	actual := board.getMeeplesOnConnectedRoads(closingTile, *road)
+	if len(actual) == 0 {
+		t.Fatalf("expected %#v, got %#v instead", expected, actual)
+	}
+	for _, meeple := range actual {
+		if meeple != expected {
+			t.Fatalf("expected %#v, got %#v instead", expected, meeple)
+		}
+	}
+	if board.CanBePlaced(closingTile) {
+		t.Fatal("expected the meeple to not be placeable on the occupied road")
+	}
+
+	road.Meeple = elements.Meeple{}
+	report, err := board.PlaceTile(closingTile)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if report.ReceivedPoints[1] != 6 {
+		t.Fatalf("expected %#v, got %#v instead", 6, report.ReceivedPoints[1])
+	}
+}
@@ -0,0 +1,243 @@
+package game
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/city"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/logger"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/player"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+// Version of the format produced by Game.MarshalBinary(),
+// it needs to be bumped whenever the saved structures change.
+const snapshotVersion uint16 = 4
+
+var (
+	snapshotMagic = [4]byte{'C', 'A', 'R', 'C'}
+
+	ErrInvalidSnapshot            = errors.New("the data is not a valid game snapshot")
+	ErrUnsupportedSnapshotVersion = errors.New("the game snapshot version is not supported")
+	ErrUnsupportedBoard           = errors.New("only the board created by NewBoard() can be saved")
+)
+
+// The header that precedes the gob-encoded savedGame in the snapshot.
+type snapshotHeader struct {
+	Magic   [4]byte
+	Version uint16
+}
+
+type savedGame struct {
+	Deck          stack.Snapshot[tiles.Tile]
+	StartingTile  tiles.Tile
+	Board         savedBoard
+	Players       []elements.SerializedPlayer
+	CurrentPlayer int
+	CanSwapTiles  bool
+	ExtraTurn     bool
+}
+
+type savedBoard struct {
+	Ruleset            rules.Ruleset
+	Tiles              []elements.PlacedTile
+	PlaceablePositions []position.Position
+	Cities             city.ManagerSnapshot
+}
+
+// Save the full state of the game (including the deck order) in a versioned binary format
+// that can be restored with UnmarshalBinary().
+//
+// The log and the turn history used by UndoTurn() are not saved.
+func (game *Game) MarshalBinary() ([]byte, error) {
+	saved, err := game.save()
+	if err != nil {
+		return nil, err
+	}
+	return marshalSaved(saved)
+}
+
+func marshalSaved(saved savedGame) ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	header := snapshotHeader{Magic: snapshotMagic, Version: snapshotVersion}
+	if err := binary.Write(buffer, binary.BigEndian, header); err != nil {
+		return nil, err
+	}
+	if err := gob.NewEncoder(buffer).Encode(saved); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (game *Game) save() (savedGame, error) {
+	board, ok := game.board.(*board)
+	if !ok {
+		return savedGame{}, ErrUnsupportedBoard
+	}
+
+	players := make([]elements.SerializedPlayer, len(game.players))
+	for i, player := range game.players {
+		players[i] = player.Serialized()
+	}
+
+	return savedGame{
+		Deck:         game.deck.Snapshot(),
+		StartingTile: game.deck.StartingTile,
+		Board: savedBoard{
+			Ruleset:            board.ruleset,
+			Tiles:              board.tiles,
+			PlaceablePositions: board.placeablePositions,
+			Cities:             board.cityManager.Snapshot(),
+		},
+		Players:       players,
+		CurrentPlayer: game.currentPlayer,
+		CanSwapTiles:  game.canSwapTiles,
+		ExtraTurn:     game.extraTurn,
+	}, nil
+}
+
+// Restore the game saved with Game.MarshalBinary(). The restored game logs
+// its events using the given logger (which does not contain the events from before saving).
+func UnmarshalBinary(data []byte, log logger.Logger) (*Game, error) {
+	reader := bytes.NewReader(data)
+	var header snapshotHeader
+	if err := binary.Read(reader, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if header.Magic != snapshotMagic {
+		return nil, fmt.Errorf("%w: unknown file signature", ErrInvalidSnapshot)
+	}
+	if header.Version != snapshotVersion {
+		return nil, fmt.Errorf(
+			"%w: %v (expected %v)", ErrUnsupportedSnapshotVersion, header.Version, snapshotVersion,
+		)
+	}
+
+	var saved savedGame
+	if err := gob.NewDecoder(reader).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if len(saved.Players) == 0 || saved.CurrentPlayer < 0 || saved.CurrentPlayer >= len(saved.Players) {
+		return nil, fmt.Errorf("%w: invalid players", ErrInvalidSnapshot)
+	}
+	if len(saved.Board.Tiles) != len(saved.Deck.Tiles)+1 {
+		return nil, fmt.Errorf("%w: board does not match the deck", ErrInvalidSnapshot)
+	}
+
+	return newGameFromSaved(saved, log)
+}
+
+// Creates the game from the saved state, validating the players
+// and the placement of the tiles on the board.
+func newGameFromSaved(saved savedGame, log logger.Logger) (*Game, error) {
+	deckStack, err := stack.FromSnapshot(saved.Deck)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	deck := deck.Deck{
+		Stack:        &deckStack,
+		StartingTile: saved.StartingTile,
+	}
+
+	players := make([]elements.Player, len(saved.Players))
+	for i, serialized := range saved.Players {
+		if len(serialized.MeepleCounts) > elements.MeepleTypeCount {
+			return nil, fmt.Errorf("%w: invalid meeple counts", ErrInvalidSnapshot)
+		}
+		if len(serialized.TradeGoods) > elements.TradeGoodCount {
+			return nil, fmt.Errorf("%w: invalid trade goods", ErrInvalidSnapshot)
+		}
+		// the players are looked up by their IDs
+		if serialized.ID != elements.ID(i+1) {
+			return nil, fmt.Errorf(
+				"%w: invalid ID %v of player number %v", ErrInvalidSnapshot, serialized.ID, i+1,
+			)
+		}
+		player := player.New(serialized.ID)
+		for meepleType, count := range serialized.MeepleCounts {
+			player.SetMeepleCount(elements.MeepleType(meepleType), count)
+		}
+		for tradeGood, count := range serialized.TradeGoods {
+			player.SetTradeGoodCount(elements.TradeGood(tradeGood), count)
+		}
+		player.SetScore(serialized.Score)
+		players[i] = player
+	}
+
+	board, err := newBoardFromSaved(deck.TileSet(), saved.Board, len(players))
+	if err != nil {
+		return nil, err
+	}
+
+	if log == nil {
+		nullLogger := logger.NewEmpty()
+		log = &nullLogger
+	}
+
+	game := &Game{
+		board:         board,
+		deck:          deck,
+		players:       players,
+		currentPlayer: saved.CurrentPlayer,
+		log:           log,
+		canSwapTiles:  saved.CanSwapTiles,
+		extraTurn:     saved.ExtraTurn,
+	}
+	game.rehash()
+	return game, nil
+}
+
+// Creates the board from the saved state, checking that the tiles match the tile set,
+// occupy distinct positions and only hold the meeples of the given number of players.
+func newBoardFromSaved(tileSet tilesets.TileSet, saved savedBoard, playerCount int) (*board, error) {
+	if err := saved.Ruleset.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	tilesMap := map[position.Position]elements.PlacedTile{}
+	for i, tile := range saved.Tiles {
+		// see board.DeepClone() for an explanation of this check
+		if tile.Features == nil {
+			continue
+		}
+		expectedTile := tileSet.StartingTile
+		if i != 0 {
+			expectedTile = tileSet.Tiles[i-1]
+		}
+		if !tile.EqualsTile(expectedTile) {
+			return nil, fmt.Errorf("%w: tile number %v does not match the tile set", ErrInvalidSnapshot, i)
+		}
+		if _, ok := tilesMap[tile.Position]; ok {
+			return nil, fmt.Errorf("%w: multiple tiles at %v", ErrInvalidSnapshot, tile.Position)
+		}
+		for _, feat := range tile.Features {
+			if int(feat.Meeple.PlayerID) > playerCount {
+				return nil, fmt.Errorf(
+					"%w: meeple of unknown player %v at %v", ErrInvalidSnapshot, feat.Meeple.PlayerID, tile.Position,
+				)
+			}
+		}
+		tilesMap[tile.Position] = tile
+	}
+	if saved.Tiles[0].Features == nil || saved.Tiles[0].Position != position.New(0, 0) {
+		return nil, fmt.Errorf("%w: the starting tile is not placed at (0, 0)", ErrInvalidSnapshot)
+	}
+
+	return &board{
+		tileSet:            tileSet,
+		ruleset:            saved.Ruleset,
+		tiles:              saved.Tiles,
+		tilesMap:           tilesMap,
+		placeablePositions: saved.PlaceablePositions,
+		cityManager:        city.NewCityManagerFromSnapshot(saved.Cities),
+	}, nil
+}
@@ -0,0 +1,194 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func compareGameSnapshots(t *testing.T, expected gameSnapshot, actual gameSnapshot) {
+	if string(expected.serialized) != string(actual.serialized) {
+		t.Fatalf("expected serialized game:\n%s\ngot:\n%s", expected.serialized, actual.serialized)
+	}
+	if !reflect.DeepEqual(expected.midGameScore, actual.midGameScore) {
+		t.Fatalf("expected mid game score %#v, got %#v instead", expected.midGameScore, actual.midGameScore)
+	}
+	if expected.legalMovesLen != actual.legalMovesLen {
+		t.Fatalf("expected %v legal moves, got %v instead", expected.legalMovesLen, actual.legalMovesLen)
+	}
+}
+
+func TestUnmarshalBinaryRestoresGameThatPlaysTheSame(t *testing.T) {
+	tileSet := tilesets.StandardTileSet()
+	deckStack := stack.NewSeeded(tileSet.Tiles, 3)
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+	// non-standard rules to make sure that the ruleset is restored as well
+	original, err := NewFromDeck(deck, nil, 4, rules.FirstEdition())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	rng := rand.New(rand.NewSource(3)) //nolint:gosec// Weak number generator is sufficent in our case
+	playRandomTurn := func(game *Game) elements.PlacedTile {
+		tile, err := game.GetCurrentTile()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		moves := []elements.PlacedTile{}
+		for _, placement := range game.GetTilePlacementsFor(tile) {
+			moves = append(moves, game.GetLegalMovesFor(placement)...)
+		}
+		move := moves[rng.Intn(len(moves))]
+		if err = game.PlayTurn(move); err != nil {
+			t.Fatal(err.Error())
+		}
+		return move
+	}
+	for range 30 {
+		playRandomTurn(original)
+	}
+
+	data, err := original.MarshalBinary()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	restored, err := UnmarshalBinary(data, nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	compareGameSnapshots(t, takeGameSnapshot(t, original), takeGameSnapshot(t, restored))
+
+	// both games should keep behaving the same way until the end
+	for {
+		if _, err := original.GetCurrentTile(); err != nil {
+			break
+		}
+		move := playRandomTurn(original)
+		if err = restored.PlayTurn(move); err != nil {
+			t.Fatal(err.Error())
+		}
+		compareGameSnapshots(t, takeGameSnapshot(t, original), takeGameSnapshot(t, restored))
+	}
+
+	expectedScores, err := original.Finalize()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	actualScores, err := restored.Finalize()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(expectedScores.ReceivedPoints, actualScores.ReceivedPoints) {
+		t.Fatalf("expected %#v, got %#v instead", expectedScores.ReceivedPoints, actualScores.ReceivedPoints)
+	}
+}
+
+func TestUnmarshalBinaryReturnsErrorForUnsupportedVersion(t *testing.T) {
+	game, err := NewFromTileSet(tilesets.StandardTileSet(), nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	data, err := game.MarshalBinary()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// the version directly follows the 4-byte signature
+	data[5]++
+
+	_, err = UnmarshalBinary(data, nil)
+	if !errors.Is(err, ErrUnsupportedSnapshotVersion) {
+		t.Fatalf("expected ErrUnsupportedSnapshotVersion, got %#v instead", err)
+	}
+}
+
+func TestUnmarshalBinaryReturnsErrorForInvalidData(t *testing.T) {
+	game, err := NewFromTileSet(tilesets.StandardTileSet(), nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	data, err := game.MarshalBinary()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for _, invalidData := range [][]byte{nil, []byte("not a snapshot"), data[:len(data)/2]} {
+		_, err = UnmarshalBinary(invalidData, nil)
+		if !errors.Is(err, ErrInvalidSnapshot) {
+			t.Fatalf("expected ErrInvalidSnapshot, got %#v instead", err)
+		}
+	}
+}
+
+func TestUnmarshalBinaryReturnsErrorForInconsistentState(t *testing.T) {
+	tileSet := tilesets.StandardTileSet()
+	deckStack := stack.NewSeeded(tileSet.Tiles, 1)
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	tile, err := game.GetCurrentTile()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err = game.PlayTurn(game.GetTilePlacementsFor(tile)[0]); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	testCases := map[string]func(saved *savedGame){
+		"zero player ID": func(saved *savedGame) {
+			saved.Players[0].ID = 0
+		},
+		"player ID out of range": func(saved *savedGame) {
+			saved.Players[1].ID = 3
+		},
+		"swapped player IDs": func(saved *savedGame) {
+			saved.Players[0].ID, saved.Players[1].ID = saved.Players[1].ID, saved.Players[0].ID
+		},
+		"tiles at the same position": func(saved *savedGame) {
+			for i, tile := range saved.Board.Tiles[1:] {
+				if tile.Features != nil {
+					saved.Board.Tiles[i+1].Position = saved.Board.Tiles[0].Position
+				}
+			}
+		},
+		"tile not matching the tile set": func(saved *savedGame) {
+			saved.Board.Tiles[0].Features = saved.Board.Tiles[0].Features[1:]
+		},
+		"moved starting tile": func(saved *savedGame) {
+			saved.Board.Tiles[0].Position = position.New(5, 5)
+		},
+		"meeple of unknown player": func(saved *savedGame) {
+			saved.Board.Tiles[0].Features[0].Meeple = elements.Meeple{Type: elements.NormalMeeple, PlayerID: 3}
+		},
+	}
+	for name, corrupt := range testCases {
+		saved, err := game.save()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		// the saved board shares its tiles with the game
+		saved.Board.Tiles = slices.Clone(saved.Board.Tiles)
+		saved.Board.Tiles[0] = saved.Board.Tiles[0].DeepClone()
+		saved.Players = slices.Clone(saved.Players)
+		corrupt(&saved)
+
+		data, err := marshalSaved(saved)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if _, err = UnmarshalBinary(data, nil); !errors.Is(err, ErrInvalidSnapshot) {
+			t.Fatalf("%v: expected ErrInvalidSnapshot, got %#v instead", name, err)
+		}
+	}
+}
@@ -52,6 +52,18 @@ func (board *BoardMock) CanBePlaced(tile elements.PlacedTile) bool {
 	return true
 }
 
+func (board *BoardMock) GetMeeplesOnConnectedFeature(
+	tile elements.PlacedTile, feat elements.PlacedFeature,
+) []elements.MeepleWithPosition {
+	_, _ = tile, feat
+	return []elements.MeepleWithPosition{}
+}
+
+func (board *BoardMock) GetAbbotPosition(playerID elements.ID) (position.Position, bool) {
+	_ = playerID
+	return position.Position{}, false
+}
+
 func (board *BoardMock) PlaceTile(
 	tile elements.PlacedTile,
 ) (elements.ScoreReport, error) {
@@ -61,6 +73,12 @@ func (board *BoardMock) PlaceTile(
 	return board.PlaceTileFunc(tile)
 }
 
+func (board *BoardMock) EnableUndo() {}
+
+func (board *BoardMock) UndoPlaceTile() error {
+	return nil
+}
+
 func (board *BoardMock) RemoveMeeple(pos position.Position) {
 	_ = pos
 }
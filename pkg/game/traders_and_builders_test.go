@@ -0,0 +1,173 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+)
+
+func newTradersAndBuildersTestGame(t *testing.T, tileList []tiles.Tile) *Game {
+	deckStack := stack.NewOrdered(tileList)
+	deck := deck.Deck{
+		Stack:        &deckStack,
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+	}
+	game, err := NewFromDeck(deck, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return game
+}
+
+func straightRoadMove(pos position.Position, meeple elements.Meeple) elements.PlacedTile {
+	move := elements.ToPlacedTile(tiletemplates.StraightRoads())
+	move.Position = pos
+	move.GetPlacedFeatureAtSide(side.Left, feature.Road).Meeple = meeple
+	return move
+}
+
+func TestGamePlayTurnGrantsSingleExtraTurnWhenExtendingRoadWithBuilder(t *testing.T) {
+	tileList := []tiles.Tile{}
+	for range 6 {
+		tileList = append(tileList, tiletemplates.StraightRoads())
+	}
+	// trade goods tile gives the players their builders
+	tileList = append(tileList, tiletemplates.TwoCityEdgesCornerConnectedWine())
+	game := newTradersAndBuildersTestGame(t, tileList)
+	game.EnableUndo()
+
+	none := elements.Meeple{}
+	follower := elements.Meeple{Type: elements.NormalMeeple, PlayerID: 1}
+	builder := elements.Meeple{Type: elements.BuilderMeeple, PlayerID: 1}
+	moves := []struct {
+		move            elements.PlacedTile
+		expectedPlayer  elements.ID
+		expectExtraTurn bool
+	}{
+		{straightRoadMove(position.New(1, 0), follower), 2, false},
+		{straightRoadMove(position.New(-1, 0), none), 1, false},
+		// placing the builder itself does not grant the extra turn
+		{straightRoadMove(position.New(2, 0), builder), 2, false},
+		// extending the road with other player's builder does not grant the extra turn
+		{straightRoadMove(position.New(-2, 0), none), 1, false},
+		{straightRoadMove(position.New(3, 0), none), 1, true},
+		// extending the road again during the extra turn does not grant another one
+		{straightRoadMove(position.New(4, 0), none), 2, false},
+	}
+
+	for i, testCase := range moves {
+		if err := game.PlayTurn(testCase.move); err != nil {
+			t.Fatalf("error playing turn number %#v: %#v", i, err)
+		}
+		if game.CurrentPlayer().ID() != testCase.expectedPlayer {
+			t.Fatalf(
+				"turn %#v: expected %#v, got %#v instead",
+				i, testCase.expectedPlayer, game.CurrentPlayer().ID(),
+			)
+		}
+		if game.IsExtraTurn() != testCase.expectExtraTurn {
+			t.Fatalf(
+				"turn %#v: expected %#v, got %#v instead",
+				i, testCase.expectExtraTurn, game.IsExtraTurn(),
+			)
+		}
+	}
+
+	if err := game.UndoTurn(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if game.CurrentPlayer().ID() != 1 || !game.IsExtraTurn() {
+		t.Fatalf(
+			"expected player 1 in extra turn, got %#v (extra turn: %#v) instead",
+			game.CurrentPlayer().ID(), game.IsExtraTurn(),
+		)
+	}
+}
+
+func TestGameGetLegalMovesForIncludesBuilderOnlyOnFeatureWithOwnFollower(t *testing.T) {
+	tileList := []tiles.Tile{
+		tiletemplates.StraightRoads(),
+		tiletemplates.StraightRoads(),
+		tiletemplates.StraightRoads(),
+		tiletemplates.TwoCityEdgesCornerConnectedWine(),
+	}
+	game := newTradersAndBuildersTestGame(t, tileList)
+
+	follower := elements.Meeple{Type: elements.NormalMeeple, PlayerID: 1}
+	if err := game.PlayTurn(straightRoadMove(position.New(1, 0), follower)); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := game.PlayTurn(straightRoadMove(position.New(-1, 0), elements.Meeple{})); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	builderMoveCount := 0
+	placement := straightRoadMove(position.New(2, 0), elements.Meeple{})
+	for _, move := range game.GetLegalMovesFor(placement) {
+		for _, feat := range move.Features {
+			if feat.Meeple.Type == elements.BuilderMeeple {
+				builderMoveCount++
+				if feat.FeatureType != feature.Road {
+					t.Fatalf("expected builder on road, got %#v instead", move)
+				}
+			}
+		}
+	}
+	if builderMoveCount != 1 {
+		t.Fatalf("expected %#v, got %#v instead", 1, builderMoveCount)
+	}
+
+	// the road below the starting tile is not connected to any follower
+	placement = straightRoadMove(position.New(0, -1), elements.Meeple{})
+	for _, move := range game.GetLegalMovesFor(placement) {
+		for _, feat := range move.Features {
+			if feat.Meeple.Type == elements.BuilderMeeple {
+				t.Fatalf("expected no builder, got %#v instead", move)
+			}
+		}
+	}
+}
+
+func TestGameFinalizeAwardsTradeGoodsToPlayerThatCompletedCity(t *testing.T) {
+	// closes the city on the starting tile
+	closingTile := elements.ToPlacedTile(tiletemplates.SingleCityEdgeStraightRoadsWine().Rotate(2))
+	closingTile.Position = position.New(0, 1)
+	game := newTradersAndBuildersTestGame(t, []tiles.Tile{elements.ToTile(closingTile)})
+	game.EnableUndo()
+
+	if err := game.PlayTurn(closingTile); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if game.GetPlayerByID(1).TradeGoodCount(elements.Wine) != 1 {
+		t.Fatalf("expected %#v, got %#v instead", 1, game.GetPlayerByID(1).TradeGoodCount(elements.Wine))
+	}
+
+	scoreReport, err := game.Finalize()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expectedScore := uint32(elements.TradeGoodMajorityPoints)
+	if scoreReport.ReceivedPoints[1] != expectedScore {
+		t.Fatalf("expected %#v, got %#v instead", expectedScore, scoreReport.ReceivedPoints[1])
+	}
+	if scoreReport.ReceivedPoints[2] != 0 {
+		t.Fatalf("expected %#v, got %#v instead", 0, scoreReport.ReceivedPoints[2])
+	}
+
+	if err = game.UndoTurn(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if game.GetPlayerByID(1).TradeGoodCount(elements.Wine) != 0 {
+		t.Fatalf("expected %#v, got %#v instead", 0, game.GetPlayerByID(1).TradeGoodCount(elements.Wine))
+	}
+}
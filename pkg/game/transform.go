@@ -0,0 +1,109 @@
+package game
+
+import (
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/city"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/logger"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+)
+
+// The board is symmetric under the 8 transformations of the square (its dihedral group),
+// each of them is given as the reflection across the Y axis (if `mirror` is set)
+// followed by `rotation` clockwise rotations around the starting tile.
+
+// Returns the game with the whole state (the board, the deck and the tile set)
+// transformed by the given symmetry of the board. The transformed game plays the same
+// as the original one, with its moves transformed by TransformMove().
+//
+// The transformed game logs no events and the turns played before the transformation
+// cannot be undone on it.
+func (game *Game) Transformed(rotation uint, mirror bool) (*Game, error) {
+	saved, err := game.save()
+	if err != nil {
+		return nil, err
+	}
+
+	deckTiles := make([]tiles.Tile, len(saved.Deck.Tiles))
+	for i, tile := range saved.Deck.Tiles {
+		deckTiles[i] = TransformTile(tile, rotation, mirror)
+	}
+	saved.Deck.Tiles = deckTiles
+	saved.StartingTile = TransformTile(saved.StartingTile, rotation, mirror)
+
+	boardTiles := make([]elements.PlacedTile, len(saved.Board.Tiles))
+	for i, tile := range saved.Board.Tiles {
+		// unplaced tiles are zero values
+		if tile.Features != nil {
+			boardTiles[i] = TransformMove(tile, rotation, mirror)
+		}
+	}
+	saved.Board.Tiles = boardTiles
+
+	placeablePositions := make([]position.Position, len(saved.Board.PlaceablePositions))
+	for i, pos := range saved.Board.PlaceablePositions {
+		placeablePositions[i] = TransformPosition(pos, rotation, mirror)
+	}
+	saved.Board.PlaceablePositions = placeablePositions
+
+	cities := make([]city.CitySnapshot, len(saved.Board.Cities.Cities))
+	for i, citySnapshot := range saved.Board.Cities.Cities {
+		features := map[position.Position][]elements.PlacedFeature{}
+		for pos, placedFeatures := range citySnapshot.Features {
+			features[TransformPosition(pos, rotation, mirror)] = transformPlacedFeatures(placedFeatures, rotation, mirror)
+		}
+		citySnapshot.Features = features
+		cities[i] = citySnapshot
+	}
+	saved.Board.Cities.Cities = cities
+
+	nullLogger := logger.NewEmpty()
+	return newGameFromSaved(saved, &nullLogger)
+}
+
+// Returns the position transformed by the given symmetry of the board, see Game.Transformed().
+func TransformPosition(pos position.Position, rotation uint, mirror bool) position.Position {
+	if mirror {
+		pos = pos.Reflect()
+	}
+	return pos.Rotate(rotation)
+}
+
+// Returns the tile transformed by the given symmetry of the board, see Game.Transformed().
+// The order of the features is preserved.
+func TransformTile(tile tiles.Tile, rotation uint, mirror bool) tiles.Tile {
+	if mirror {
+		tile = tile.Reflect()
+	}
+	return tile.Rotate(rotation)
+}
+
+// Returns the move (or a tile placed on the board) transformed by the given symmetry
+// of the board, see Game.Transformed(). The order of the features is preserved.
+func TransformMove(move elements.PlacedTile, rotation uint, mirror bool) elements.PlacedTile {
+	move.Features = transformPlacedFeatures(move.Features, rotation, mirror)
+	move.Position = TransformPosition(move.Position, rotation, mirror)
+	if move.RecallAbbot {
+		move.RecalledAbbotPosition = TransformPosition(move.RecalledAbbotPosition, rotation, mirror)
+	}
+	return move
+}
+
+func transformPlacedFeatures(
+	placedFeatures []elements.PlacedFeature, rotation uint, mirror bool,
+) []elements.PlacedFeature {
+	transformed := make([]elements.PlacedFeature, len(placedFeatures))
+	for i, placedFeature := range placedFeatures {
+		transformed[i] = placedFeature
+		transformed[i].Feature = transformFeature(placedFeature.Feature, rotation, mirror)
+	}
+	return transformed
+}
+
+func transformFeature(feat feature.Feature, rotation uint, mirror bool) feature.Feature {
+	if mirror {
+		feat = feat.Reflect()
+	}
+	return feat.Rotate(rotation)
+}
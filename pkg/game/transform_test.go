@@ -0,0 +1,174 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+// Returns the legal moves of the game as strings that do not depend on the order of the features.
+func legalMoveKeys(t *testing.T, game *Game) []string {
+	tile, err := game.GetCurrentTile()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	keys := []string{}
+	for _, placement := range game.GetTilePlacementsFor(tile) {
+		for _, move := range game.GetLegalMovesFor(placement) {
+			keys = append(keys, moveKey(move))
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func moveKey(move elements.PlacedTile) string {
+	features := []string{}
+	for _, feat := range move.Features {
+		features = append(features, fmt.Sprintf("%v", feat))
+	}
+	slices.Sort(features)
+	return fmt.Sprintf("%v %v %v %v", move.Position, features, move.RecallAbbot, move.RecalledAbbotPosition)
+}
+
+func TestGameTransformedPlaysSameAsOriginalGame(t *testing.T) {
+	tileSets := []tilesets.TileSet{
+		tilesets.StandardTileSet(),
+		tilesets.InnsAndCathedralsTileSet(),
+		tilesets.TradersAndBuildersTileSet(),
+		tilesets.GardensTileSet(),
+		tilesets.RiverTileSet(),
+	}
+	for i, tileSet := range tileSets {
+		deckStack := stack.NewSeededInGroups(tileSet.Tiles, tileSet.Groups, int64(i))
+		deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+		game, err := NewFromDeck(deck, nil, 2, rules.Standard())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		// play a part of the game before transforming it
+		rng := rand.New(rand.NewSource(int64(i))) //nolint:gosec// Weak number generator is sufficent in our case
+		for range len(tileSet.Tiles) / 2 {
+			tile, err := game.GetCurrentTile()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			moves := []elements.PlacedTile{}
+			for _, placement := range game.GetTilePlacementsFor(tile) {
+				moves = append(moves, game.GetLegalMovesFor(placement)...)
+			}
+			if err := game.PlayTurn(moves[rng.Intn(len(moves))]); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		for rotation := range uint(4) {
+			for _, mirror := range []bool{false, true} {
+				original := game.DeepClone()
+				transformed, err := game.Transformed(rotation, mirror)
+				if err != nil {
+					t.Fatal(err.Error())
+				}
+
+				for {
+					tile, err := original.GetCurrentTile()
+					if err != nil {
+						break
+					}
+					expectedKeys := []string{}
+					moves := []elements.PlacedTile{}
+					for _, placement := range original.GetTilePlacementsFor(tile) {
+						for _, move := range original.GetLegalMovesFor(placement) {
+							moves = append(moves, move)
+							expectedKeys = append(expectedKeys, moveKey(TransformMove(move, rotation, mirror)))
+						}
+					}
+					slices.Sort(expectedKeys)
+					actualKeys := legalMoveKeys(t, transformed)
+					if !slices.Equal(actualKeys, expectedKeys) {
+						t.Fatalf("expected %#v, got %#v instead", expectedKeys, actualKeys)
+					}
+
+					move := moves[rng.Intn(len(moves))]
+					if err := original.PlayTurn(move); err != nil {
+						t.Fatal(err.Error())
+					}
+					if err := transformed.PlayTurn(TransformMove(move, rotation, mirror)); err != nil {
+						t.Fatal(err.Error())
+					}
+				}
+
+				expectedScores, err := original.Finalize()
+				if err != nil {
+					t.Fatal(err.Error())
+				}
+				actualScores, err := transformed.Finalize()
+				if err != nil {
+					t.Fatal(err.Error())
+				}
+				for playerID, score := range expectedScores.ReceivedPoints {
+					if actualScores.ReceivedPoints[playerID] != score {
+						t.Fatalf("expected %#v, got %#v instead", expectedScores, actualScores)
+					}
+				}
+				for _, player := range original.players {
+					if transformed.GetPlayerByID(player.ID()).Score() != player.Score() {
+						t.Fatalf(
+							"expected %#v, got %#v instead",
+							player.Score(), transformed.GetPlayerByID(player.ID()).Score(),
+						)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGameTransformedWithIdentityHasSameHash(t *testing.T) {
+	game, err := NewFromTileSet(tilesets.StandardTileSet(), nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	tile, err := game.GetCurrentTile()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := game.PlayTurn(game.GetTilePlacementsFor(tile)[0]); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	transformed, err := game.Transformed(0, false)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if transformed.Hash() != game.Hash() {
+		t.Fatalf("expected %#v, got %#v instead", game.Hash(), transformed.Hash())
+	}
+
+	// transforming back restores the original state
+	for _, mirror := range []bool{false, true} {
+		transformed, err := game.Transformed(1, mirror)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !mirror {
+			transformed, err = transformed.Transformed(3, false)
+		} else {
+			transformed, err = transformed.Transformed(1, true)
+		}
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if transformed.Hash() != game.Hash() {
+			t.Fatalf("expected %#v, got %#v instead", game.Hash(), transformed.Hash())
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/deck"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+type gameSnapshot struct {
+	serialized    []byte
+	midGameScore  elements.ScoreReport
+	legalMovesLen int
+}
+
+func takeGameSnapshot(t *testing.T, game *Game) gameSnapshot {
+	serialized, err := json.Marshal(game.Serialized())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	snapshot := gameSnapshot{
+		serialized:   serialized,
+		midGameScore: game.GetMidGameScore(),
+	}
+	if tile, err := game.GetCurrentTile(); err == nil {
+		for _, placement := range game.GetTilePlacementsFor(tile) {
+			snapshot.legalMovesLen += len(game.GetLegalMovesFor(placement))
+		}
+	}
+	return snapshot
+}
+
+func TestUndoTurnReturnsErrorWhenNoTurnWasPlayed(t *testing.T) {
+	game, err := NewFromTileSet(tilesets.StandardTileSet(), nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	err = game.UndoTurn()
+	if !errors.Is(err, elements.ErrNoTurnToUndo) {
+		t.Fatalf("expected ErrNoTurnToUndo, got %#v instead", err)
+	}
+}
+
+// Returns a game with a single tile in the deck and the move placing that tile.
+func newSingleTileGame(t *testing.T) (*Game, elements.PlacedTile) {
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles:        []tiles.Tile{tiletemplates.SingleCityEdgeNoRoads()},
+	}
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	move := elements.ToPlacedTile(tiletemplates.SingleCityEdgeNoRoads().Rotate(2))
+	move.Position = position.New(0, 1)
+	return game, move
+}
+
+func TestUndoTurnReturnsErrorWhenUndoIsNotEnabled(t *testing.T) {
+	game, move := newSingleTileGame(t)
+	if err := game.PlayTurn(move); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	err := game.UndoTurn()
+	if !errors.Is(err, elements.ErrNoTurnToUndo) {
+		t.Fatalf("expected ErrNoTurnToUndo, got %#v instead", err)
+	}
+}
+
+func TestUndoTurnReturnsErrorForTurnPlayedOnCloneOfGameWithUndoEnabled(t *testing.T) {
+	game, move := newSingleTileGame(t)
+	game.EnableUndo()
+	clone := game.DeepClone()
+	if err := clone.PlayTurn(move); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	err := clone.UndoTurn()
+	if !errors.Is(err, elements.ErrNoTurnToUndo) {
+		t.Fatalf("expected ErrNoTurnToUndo, got %#v instead", err)
+	}
+}
+
+func TestUndoTurnRestoresEveryStateOfFullGame(t *testing.T) {
+	tileSet := tilesets.StandardTileSet()
+	deckStack := stack.NewSeeded(tileSet.Tiles, 42)
+	deck := deck.Deck{Stack: &deckStack, StartingTile: tileSet.StartingTile}
+	game, err := NewFromDeck(deck, nil, 3, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	game.EnableUndo()
+
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec// Weak number generator is sufficent in our case
+	snapshots := []gameSnapshot{takeGameSnapshot(t, game)}
+	for {
+		tile, err := game.GetCurrentTile()
+		if err != nil {
+			break
+		}
+		moves := []elements.PlacedTile{}
+		for _, placement := range game.GetTilePlacementsFor(tile) {
+			moves = append(moves, game.GetLegalMovesFor(placement)...)
+		}
+		if err = game.PlayTurn(moves[rng.Intn(len(moves))]); err != nil {
+			t.Fatal(err.Error())
+		}
+		snapshots = append(snapshots, takeGameSnapshot(t, game))
+	}
+
+	if _, err = game.Finalize(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for turn := len(snapshots) - 2; turn >= 0; turn-- {
+		if err = game.UndoTurn(); err != nil {
+			t.Fatalf("turn %v: %v", turn, err.Error())
+		}
+		expected := snapshots[turn]
+		actual := takeGameSnapshot(t, game)
+		if string(expected.serialized) != string(actual.serialized) {
+			t.Fatalf(
+				"turn %v: expected serialized game:\n%s\ngot:\n%s",
+				turn,
+				expected.serialized,
+				actual.serialized,
+			)
+		}
+		if !reflect.DeepEqual(expected.midGameScore, actual.midGameScore) {
+			t.Fatalf(
+				"turn %v: expected mid game score %#v, got %#v instead",
+				turn,
+				expected.midGameScore,
+				actual.midGameScore,
+			)
+		}
+		if expected.legalMovesLen != actual.legalMovesLen {
+			t.Fatalf(
+				"turn %v: expected %v legal moves, got %v instead",
+				turn,
+				expected.legalMovesLen,
+				actual.legalMovesLen,
+			)
+		}
+	}
+
+	if err = game.UndoTurn(); !errors.Is(err, elements.ErrNoTurnToUndo) {
+		t.Fatalf("expected ErrNoTurnToUndo, got %#v instead", err)
+	}
+}
+
+/*
+Closing a city, undoing it, and closing it again with another player's meeple.
+
+	 C
+	[0]
+*/
+func TestUndoTurnUnmergesCityAndAllowsDifferentMove(t *testing.T) {
+	tileSet := tilesets.TileSet{
+		StartingTile: tiletemplates.SingleCityEdgeStraightRoads(),
+		Tiles: []tiles.Tile{
+			tiletemplates.SingleCityEdgeNoRoads(),
+		},
+	}
+	game, err := NewFromTileSet(tileSet, nil, 2, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	game.EnableUndo()
+
+	tile, err := game.GetCurrentTile()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ptile := elements.ToPlacedTile(tile.Rotate(2))
+	ptile.Position = position.New(0, 1)
+	ptile.GetPlacedFeatureAtSide(side.Bottom, feature.City).Meeple = elements.Meeple{
+		Type: elements.NormalMeeple, PlayerID: game.CurrentPlayer().ID(),
+	}
+	if err = game.PlayTurn(ptile); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	player := game.GetPlayerByID(1)
+	if player.Score() != 4 {
+		t.Fatalf("expected player 1 to score 4 points, got %v instead", player.Score())
+	}
+
+	if err = game.UndoTurn(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if player.Score() != 0 {
+		t.Fatalf("expected player 1 to have no points, got %v instead", player.Score())
+	}
+	if player.MeepleCount(elements.NormalMeeple) != 7 {
+		t.Fatalf("expected player 1 to have 7 meeples, got %v instead", player.MeepleCount(elements.NormalMeeple))
+	}
+	if game.CurrentPlayer().ID() != 1 {
+		t.Fatalf("expected player 1 to be the current player, got %v instead", game.CurrentPlayer().ID())
+	}
+	if _, ok := game.board.GetTileAt(position.New(0, 1)); ok {
+		t.Fatal("expected the tile to be removed from the board")
+	}
+
+	// the city is open again so the same tile can be placed without any meeple
+	ptile = elements.ToPlacedTile(tile.Rotate(2))
+	ptile.Position = position.New(0, 1)
+	if err = game.PlayTurn(ptile); err != nil {
+		t.Fatal(err.Error())
+	}
+	if player.Score() != 0 {
+		t.Fatalf("expected player 1 to have no points, got %v instead", player.Score())
+	}
+}
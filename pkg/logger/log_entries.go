@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 )
 
@@ -14,6 +15,7 @@ const (
 	PlaceTileEvent  EventType = "place"
 	ScoreEvent      EventType = "score"
 	FinalScoreEvent EventType = "final_score"
+	UndoTurnEvent   EventType = "undo"
 )
 
 type Entry struct {
@@ -29,16 +31,23 @@ func NewEntry(event EventType, content []byte) Entry {
 }
 
 type StartEntryContent struct {
-	StartingTile tiles.Tile   `json:"startingTile"`
-	Stack        []tiles.Tile `json:"stack"`
-	PlayerCount  int          `json:"playerCount"`
+	StartingTile tiles.Tile `json:"startingTile"`
+	// tiles of the deck in the order they are drawn, including the ones discarded
+	// before the first turn (which are missing in the older logs)
+	Stack       []tiles.Tile `json:"stack"`
+	PlayerCount int          `json:"playerCount"`
+	// nil in the logs written before the rulesets were introduced (standard rules)
+	Ruleset *rules.Ruleset `json:"ruleset,omitempty"`
 }
 
-func NewStartEntryContent(startingTile tiles.Tile, stack []tiles.Tile, playerCount int) StartEntryContent {
+func NewStartEntryContent(
+	startingTile tiles.Tile, stack []tiles.Tile, playerCount int, ruleset rules.Ruleset,
+) StartEntryContent {
 	return StartEntryContent{
 		StartingTile: startingTile,
 		Stack:        stack,
 		PlayerCount:  playerCount,
+		Ruleset:      &ruleset,
 	}
 }
 
@@ -105,3 +114,24 @@ func ParseFinalScoreEntryContent(entryContent []byte) FinalScoreEntryContent {
 	}
 	return content
 }
+
+type UndoTurnEntryContent struct {
+	PlayerID elements.ID         `json:"playerID"`
+	Move     elements.PlacedTile `json:"move"`
+}
+
+func NewUndoTurnEntryContent(player elements.ID, move elements.PlacedTile) UndoTurnEntryContent {
+	return UndoTurnEntryContent{
+		PlayerID: player,
+		Move:     move,
+	}
+}
+
+func ParseUndoTurnEntryContent(entryContent []byte) UndoTurnEntryContent {
+	var content UndoTurnEntryContent
+	err := json.Unmarshal(entryContent, &content)
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
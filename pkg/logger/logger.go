@@ -72,3 +72,20 @@ func (*EmptyLogger) LogEvent(eventName EventType, event interface{}) error { //n
 func (*EmptyLogger) CopyTo(dst Logger) error { //nolint:revive // causes gopy to fail
 	return nil
 }
+
+// Reads all log entries from the given reader, e.g. a log file created by FileLogger.
+func ReadEntries(reader io.Reader) ([]Entry, error) {
+	entries := []Entry{}
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	for {
+		var entry Entry
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			return entries, nil
+		} else if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/test"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/player"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/stack"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
@@ -42,7 +43,7 @@ func TestFileLogger(t *testing.T) {
 	expectedStack := deck.GetRemaining()
 	expectedPlayerCount := 2
 
-	err = log.LogEvent(StartEvent, NewStartEntryContent(expectedStartingTile, expectedStack, expectedPlayerCount))
+	err = log.LogEvent(StartEvent, NewStartEntryContent(expectedStartingTile, expectedStack, expectedPlayerCount, rules.Standard()))
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -149,7 +150,7 @@ func TestReadLogsFileLogger(t *testing.T) {
 	expectedStack := deck.GetRemaining()
 	expectedPlayerCount := 2
 
-	err = log.LogEvent(StartEvent, NewStartEntryContent(expectedStartingTile, expectedStack, expectedPlayerCount))
+	err = log.LogEvent(StartEvent, NewStartEntryContent(expectedStartingTile, expectedStack, expectedPlayerCount, rules.Standard()))
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -237,7 +238,7 @@ func TestFileLoggerInvalidFiles(t *testing.T) {
 	}
 
 	deck := getTestDeck()
-	err = log.LogEvent(StartEvent, NewStartEntryContent(deck.StartingTile, deck.Stack.GetTiles(), 2))
+	err = log.LogEvent(StartEvent, NewStartEntryContent(deck.StartingTile, deck.Stack.GetTiles(), 2, rules.Standard()))
 	if err == nil {
 		t.Fatal("FAILED")
 	}
@@ -253,7 +254,7 @@ func TestLogger(t *testing.T) {
 	expectedStack := deck.GetRemaining()
 	expectedStartingTile := deck.StartingTile
 	expectedPlayerCount := 2
-	err := log.LogEvent(StartEvent, NewStartEntryContent(expectedStartingTile, expectedStack, expectedPlayerCount))
+	err := log.LogEvent(StartEvent, NewStartEntryContent(expectedStartingTile, expectedStack, expectedPlayerCount, rules.Standard()))
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -355,7 +356,7 @@ func TestParseEntries(t *testing.T) {
 	expectedStack := deck.GetRemaining()
 	expectedStartingTile := deck.StartingTile
 	expectedPlayerCount := 2
-	err := log.LogEvent(StartEvent, NewStartEntryContent(expectedStartingTile, expectedStack, expectedPlayerCount))
+	err := log.LogEvent(StartEvent, NewStartEntryContent(expectedStartingTile, expectedStack, expectedPlayerCount, rules.Standard()))
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -470,3 +471,42 @@ func TestParseEntries(t *testing.T) {
 		t.Fatalf("expected %#v, got %#v instead", expectedFinalScores, finalScoreContent.Scores)
 	}
 }
+
+func TestReadEntries(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+
+	log := New(buffer)
+
+	deck := getTestDeck()
+	err := log.LogEvent(StartEvent, NewStartEntryContent(deck.StartingTile, deck.GetRemaining(), 2, rules.Standard()))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	err = log.LogEvent(PlaceTileEvent, NewPlaceTileEntryContent(elements.ID(1), test.GetTestPlacedTile()))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	entries, err := ReadEntries(buffer)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expectedEvents := []EventType{StartEvent, PlaceTileEvent}
+	if len(entries) != len(expectedEvents) {
+		t.Fatalf("expected %#v entries, got %#v instead", len(expectedEvents), len(entries))
+	}
+	for i, entry := range entries {
+		if entry.Event != expectedEvents[i] {
+			t.Fatalf("expected %#v, got %#v instead", expectedEvents[i], entry.Event)
+		}
+	}
+}
+
+func TestReadEntriesReturnsErrorOnMalformedInput(t *testing.T) {
+	buffer := bytes.NewBufferString("{\"event\": \"start\", \"content\": ")
+
+	_, err := ReadEntries(buffer)
+	if err == nil {
+		t.Fatal("expected an error, got nil instead")
+	}
+}
@@ -11,6 +11,8 @@ type player struct {
 	// indexed by meeple's enum value
 	meepleCounts []uint8
 	score        uint32
+	// indexed by trade good's enum value
+	tradeGoods []uint8
 }
 
 func New(id elements.ID) elements.Player {
@@ -20,11 +22,13 @@ func New(id elements.ID) elements.Player {
 		id:           id,
 		meepleCounts: meepleCounts,
 		score:        0,
+		tradeGoods:   make([]uint8, elements.TradeGoodCount),
 	}
 }
 
 func (player player) DeepClone() elements.Player {
 	player.meepleCounts = slices.Clone(player.meepleCounts)
+	player.tradeGoods = slices.Clone(player.tradeGoods)
 	return &player
 }
 
@@ -40,6 +44,14 @@ func (player *player) SetMeepleCount(meepleType elements.MeepleType, value uint8
 	player.meepleCounts[meepleType] = value
 }
 
+func (player player) TradeGoodCount(tradeGood elements.TradeGood) uint8 {
+	return player.tradeGoods[tradeGood]
+}
+
+func (player *player) SetTradeGoodCount(tradeGood elements.TradeGood, value uint8) {
+	player.tradeGoods[tradeGood] = value
+}
+
 func (player player) Score() uint32 {
 	return player.score
 }
@@ -85,6 +97,12 @@ func (player *player) PlaceTile(
 	if !player.IsEligibleFor(move) {
 		return elements.ScoreReport{}, elements.ErrNoMeepleAvailable
 	}
+	if move.RecallAbbot {
+		abbotPosition, ok := board.GetAbbotPosition(player.id)
+		if !ok || abbotPosition != move.RecalledAbbotPosition {
+			return elements.ScoreReport{}, elements.ErrNoAbbotToRecall
+		}
+	}
 
 	scoreReport, err := board.PlaceTile(move)
 	if err != nil {
@@ -104,5 +122,6 @@ func (player *player) Serialized() elements.SerializedPlayer {
 		ID:           player.id,
 		MeepleCounts: player.meepleCounts,
 		Score:        player.score,
+		TradeGoods:   player.tradeGoods,
 	}
 }
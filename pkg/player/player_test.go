@@ -9,6 +9,7 @@ import (
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/test"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/player"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
 )
 
@@ -53,7 +54,7 @@ func TestPlayerGetEligibleMovesFromReturnsMovesWithoutMeepleWhenPlayerHasNoMeepl
 }
 
 func TestPlayerPlaceTileErrorsWhenPlayerHasNoMeeples(t *testing.T) {
-	board := game.NewBoard(tilesets.StandardTileSet())
+	board := game.NewBoard(tilesets.StandardTileSet(), rules.Standard())
 	tile := test.GetTestPlacedTile()
 	player := player.New(1)
 	player.SetMeepleCount(elements.NormalMeeple, 0)
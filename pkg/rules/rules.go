@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrUnknownRuleset = errors.New("unknown ruleset")
+	ErrInvalidRuleset = errors.New("invalid ruleset")
+)
+
+var namedRulesets = map[string]func() Ruleset{
+	"standard":      Standard,
+	"first-edition": FirstEdition,
+}
+
+// The way the farmers are scored at the end of the game
+type FarmScoring uint8
+
+const (
+	// Each field gives points to the players with the most farmers on it
+	// for every completed city it neighbours (3rd edition rules)
+	FieldFarmScoring FarmScoring = iota
+	// Each completed city gives points to the players with the most farmers
+	// on all of the fields neighbouring it (1st edition rules)
+	CityFarmScoring
+)
+
+// Points received for each tile (or shield) of a feature
+type FeaturePoints struct {
+	// Points for a completed feature
+	Completed uint32 `json:"completed"`
+	// Points for a feature that is incomplete at the end of the game,
+	// incomplete features are not scored at all when this is 0
+	Incomplete uint32 `json:"incomplete"`
+}
+
+// Configuration of the rules that can differ between the editions of the game
+// (or be changed for research purposes)
+type Ruleset struct {
+	// Number of normal meeples that each player starts with
+	MeepleCount uint8 `json:"meepleCount"`
+
+	// Points for each tile of a road
+	RoadTilePoints FeaturePoints `json:"roadTilePoints"`
+	// Points for each tile of a city
+	CityTilePoints FeaturePoints `json:"cityTilePoints"`
+	// Points for each shield in a city
+	CityShieldPoints FeaturePoints `json:"cityShieldPoints"`
+	// Points for each tile surrounding (and including) a monastery or a garden
+	MonasteryTilePoints FeaturePoints `json:"monasteryTilePoints"`
+
+	// Points for each completed city neighbouring a field
+	// (or all fields of the city, see FarmScoring)
+	FarmCityPoints uint32      `json:"farmCityPoints"`
+	FarmScoring    FarmScoring `json:"farmScoring"`
+}
+
+// Returns an error if the game cannot be played with the ruleset - the players need
+// some meeples and the completed roads, cities and monasteries have to give some points
+// (a zero value ruleset, e.g. one left unset, does neither).
+func (ruleset Ruleset) Validate() error {
+	if ruleset.MeepleCount == 0 {
+		return fmt.Errorf("%w: meeple count has to be positive", ErrInvalidRuleset)
+	}
+	featurePoints := map[string]FeaturePoints{
+		"road tile":      ruleset.RoadTilePoints,
+		"city tile":      ruleset.CityTilePoints,
+		"monastery tile": ruleset.MonasteryTilePoints,
+	}
+	for name, points := range featurePoints {
+		if points.Completed == 0 {
+			return fmt.Errorf("%w: %v points for a completed feature have to be positive", ErrInvalidRuleset, name)
+		}
+	}
+	if ruleset.FarmScoring != FieldFarmScoring && ruleset.FarmScoring != CityFarmScoring {
+		return fmt.Errorf("%w: unknown farm scoring %v", ErrInvalidRuleset, ruleset.FarmScoring)
+	}
+	return nil
+}
+
+// Rules of the current (3rd) edition of the game
+func Standard() Ruleset {
+	return Ruleset{
+		MeepleCount:         7,
+		RoadTilePoints:      FeaturePoints{Completed: 1, Incomplete: 1},
+		CityTilePoints:      FeaturePoints{Completed: 2, Incomplete: 1},
+		CityShieldPoints:    FeaturePoints{Completed: 2, Incomplete: 1},
+		MonasteryTilePoints: FeaturePoints{Completed: 1, Incomplete: 1},
+		FarmCityPoints:      3,
+		FarmScoring:         FieldFarmScoring,
+	}
+}
+
+// Rules of the 1st edition of the game, they only differ
+// from the standard rules in the way the farmers are scored
+func FirstEdition() Ruleset {
+	ruleset := Standard()
+	ruleset.FarmCityPoints = 4
+	ruleset.FarmScoring = CityFarmScoring
+	return ruleset
+}
+
+// Returns the sorted names of the rulesets that can be passed to ByName()
+func Names() []string {
+	names := make([]string, 0, len(namedRulesets))
+	for name := range namedRulesets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the ruleset with the given name, either "standard" or "first-edition"
+func ByName(name string) (Ruleset, error) {
+	newRuleset, ok := namedRulesets[name]
+	if !ok {
+		return Ruleset{}, fmt.Errorf("%w: %q (expected one of %v)", ErrUnknownRuleset, name, Names())
+	}
+	return newRuleset(), nil
+}
@@ -0,0 +1,63 @@
+package selfplay
+
+import (
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+)
+
+// Chooses the moves played in the self-play games.
+//
+// Like the agents, the policies are not safe for concurrent use,
+// the runner creates a separate policy for each of the games (see Config.NewPolicy).
+type Policy interface {
+	// Returns the index of the chosen move in the given legal moves
+	// of the game's current player and the number of visits of each of the legal moves,
+	// if the policy searches the game tree (nil otherwise).
+	// The given game is not modified.
+	ChooseMove(g *game.Game, legalMoves []elements.PlacedTile) (int, []int)
+}
+
+type agentPolicy struct {
+	agent agents.Agent
+}
+
+// Returns the policy playing the moves chosen by the agent, without any visits.
+func NewAgentPolicy(agent agents.Agent) Policy {
+	return &agentPolicy{agent: agent}
+}
+
+func (policy *agentPolicy) ChooseMove(g *game.Game, legalMoves []elements.PlacedTile) (int, []int) {
+	return agents.IndexOfMove(legalMoves, policy.agent.ChooseMove(g)), nil
+}
+
+type mctsPolicy struct {
+	agent *agents.MCTSAgent
+}
+
+// Returns the policy playing the most visited move of the MCTS agent's search,
+// with the visits of all of the legal moves (or without any visits,
+// if the move is forced and nothing is searched).
+func NewMCTSPolicy(config agents.MCTSConfig, seed int64) Policy {
+	return &mctsPolicy{agent: agents.NewMCTSAgent(config, seed)}
+}
+
+func (policy *mctsPolicy) ChooseMove(g *game.Game, legalMoves []elements.PlacedTile) (int, []int) {
+	moves, moveVisits := policy.agent.Search(g)
+	if moveVisits == nil {
+		return agents.IndexOfMove(legalMoves, moves[0]), nil
+	}
+	visits := make([]int, len(legalMoves))
+	bestMove := 0
+	for i, move := range moves {
+		index := agents.IndexOfMove(legalMoves, move)
+		if index == -1 {
+			continue
+		}
+		visits[index] = moveVisits[i]
+		if visits[index] > visits[bestMove] {
+			bestMove = index
+		}
+	}
+	return bestMove, visits
+}
@@ -0,0 +1,123 @@
+package selfplay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+)
+
+// Single turn of a self-play game, see the package documentation for the schema.
+type Record struct {
+	Seed     int64       `json:"seed"`
+	Turn     int         `json:"turn"`
+	PlayerID elements.ID `json:"playerID"`
+	Tile     tiles.Tile  `json:"tile"`
+
+	Shape         []int             `json:"shape"`
+	Planes        []byte            `json:"planes"`
+	Origin        position.Position `json:"origin"`
+	TileHistogram []int             `json:"tileHistogram"`
+
+	LegalMoves   []elements.PlacedTile `json:"legalMoves"`
+	LegalActions []int                 `json:"legalActions"`
+	ChosenMove   int                   `json:"chosenMove"`
+	Visits       []int                 `json:"visits,omitempty"`
+
+	FinalScores []uint32 `json:"finalScores"`
+}
+
+// Returns the path of the shard with the given index in the directory.
+func ShardPath(dir string, index int) string {
+	return filepath.Join(dir, fmt.Sprintf("selfplay-%05d.jsonl.gz", index))
+}
+
+// Writes the records of the games into the shards, starting a new shard
+// after every `gamesPerShard` games.
+type shardWriter struct {
+	dir           string
+	gamesPerShard int
+
+	paths        []string
+	file         *os.File
+	compressor   *gzip.Writer
+	encoder      *json.Encoder
+	gamesInShard int
+}
+
+func (writer *shardWriter) writeGame(records []Record) error {
+	if writer.file == nil {
+		path := ShardPath(writer.dir, len(writer.paths))
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		writer.paths = append(writer.paths, path)
+		writer.file = file
+		writer.compressor = gzip.NewWriter(file)
+		writer.encoder = json.NewEncoder(writer.compressor)
+	}
+
+	for _, record := range records {
+		if err := writer.encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	writer.gamesInShard++
+	if writer.gamesInShard == writer.gamesPerShard {
+		return writer.closeShard()
+	}
+	return nil
+}
+
+func (writer *shardWriter) closeShard() error {
+	if writer.file == nil {
+		return nil
+	}
+	err := writer.compressor.Close()
+	if closeErr := writer.file.Close(); err == nil {
+		err = closeErr
+	}
+	writer.file = nil
+	writer.compressor = nil
+	writer.encoder = nil
+	writer.gamesInShard = 0
+	return err
+}
+
+// Reads all of the records of the shard written by Run().
+func ReadShard(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decompressor, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return nil, err
+	}
+	defer decompressor.Close()
+
+	records := []Record{}
+	decoder := json.NewDecoder(decompressor)
+	for {
+		var record Record
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
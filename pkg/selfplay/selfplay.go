@@ -0,0 +1,294 @@
+// Package selfplay plays seeded games with a policy and records the training data
+// of each of their turns.
+//
+// The games are played in parallel on the workers of the game engine and the records
+// are written as shards - gzip-compressed JSON Lines files named selfplay-00000.jsonl.gz,
+// selfplay-00001.jsonl.gz, ... (see ShardPath()), each of them holding the turns
+// of Config.GamesPerShard games (the last one can hold fewer) in the order they were played.
+// The games are written to the shards in the order they finish.
+//
+// Each line of the shard is a single turn (see Record) with the fields:
+//   - seed - seed of the game, the game can be reproduced with
+//     engine.GenerateSeededGame() and the chosen moves
+//   - turn - index of the turn in the game, starting from 0
+//   - playerID - ID of the player that played the turn (starting from 1)
+//   - tile - the tile placed in the turn
+//   - shape - shape of the planes: plane count, height and width (see encoding.Encoding)
+//   - planes - the planes of the state before the turn, a base64-encoded byte for each cell
+//   - origin - position of the tile in the top-left corner of the planes
+//   - tileHistogram - number of the remaining tiles of each of the distinct tiles
+//     of the tile set (see encoding.DistinctTiles())
+//   - legalMoves - all legal moves of the turn, in the order of agents.LegalMoves()
+//   - legalActions - action of each of the legal moves (see encoding.ActionSpace)
+//     or -1, if the move places the tile outside of the planes
+//   - chosenMove - index of the played move in legalMoves
+//   - visits - number of visits of each of the legal moves,
+//     omitted if the policy does not search the game tree (e.g. for a forced move)
+//   - finalScores - final scores of the game, indexed by player ID - 1
+package selfplay
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/encoding"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+var (
+	ErrInvalidConfig = errors.New("invalid self-play config")
+	ErrInvalidPolicy = errors.New("policy returned an invalid choice")
+)
+
+type Config struct {
+	// name of the tile set of the games, see tilesets.ByName()
+	TileSet string
+	// name of the ruleset of the games, see rules.ByName()
+	Ruleset     string
+	PlayerCount int
+	// number of games to play
+	Games int
+	// seed of the first game, every next game uses the next seed
+	Seed int64
+	// maximum number of games played at the same time
+	ParallelGames int
+	// number of games written to each of the shards
+	GamesPerShard int
+	// directory of the shards, created if it does not exist
+	OutputDir       string
+	EncodingOptions encoding.Options
+	// Creates the policy choosing the moves of all players of the game with the given seed.
+	NewPolicy func(seed int64) Policy
+}
+
+func DefaultConfig() Config {
+	return Config{
+		TileSet:         "standard",
+		Ruleset:         "standard",
+		PlayerCount:     2,
+		Games:           1000,
+		Seed:            1,
+		ParallelGames:   4 * runtime.NumCPU(),
+		GamesPerShard:   100,
+		OutputDir:       "selfplay",
+		EncodingOptions: encoding.DefaultOptions(),
+		NewPolicy: func(seed int64) Policy {
+			return NewAgentPolicy(agents.NewRandomAgent(seed))
+		},
+	}
+}
+
+type Summary struct {
+	// number of the finished games
+	Games int
+	// number of the recorded turns
+	Turns int
+	// paths of the written shards
+	Shards []string
+}
+
+// Game that is being played by the runner.
+type selfPlayGame struct {
+	id      int
+	seed    int64
+	policy  Policy
+	records []Record
+}
+
+// Plays the games on the workers of the engine and writes their records
+// to the shards in the output directory.
+//
+// On error, the games that are still being played are discarded and the summary
+// of the games written so far is returned together with the error.
+func Run(eng *engine.GameEngine, config Config) (Summary, error) {
+	summary := Summary{Shards: []string{}}
+	if config.Games < 0 || config.ParallelGames <= 0 || config.GamesPerShard <= 0 || config.NewPolicy == nil {
+		return summary, fmt.Errorf(
+			"%w: games, parallel games and games per shard have to be positive and policy has to be set",
+			ErrInvalidConfig,
+		)
+	}
+	if _, err := tilesets.ByName(config.TileSet); err != nil {
+		return summary, err
+	}
+	ruleset, err := rules.ByName(config.Ruleset)
+	if err != nil {
+		return summary, err
+	}
+	if err := os.MkdirAll(config.OutputDir, 0700); err != nil {
+		return summary, err
+	}
+
+	writer := &shardWriter{dir: config.OutputDir, gamesPerShard: config.GamesPerShard}
+	active := []*selfPlayGame{}
+	defer func() {
+		for _, g := range active {
+			eng.DeleteGames([]int{g.id})
+		}
+	}()
+
+	nextGame := 0
+	for nextGame < config.Games || len(active) != 0 {
+		for ; nextGame < config.Games && len(active) < config.ParallelGames; nextGame++ {
+			seed := config.Seed + int64(nextGame)
+			// each game gets its own copy of the tile set
+			tileSet, err := tilesets.ByName(config.TileSet)
+			if err != nil {
+				return summary, err
+			}
+			gameWithID, err := eng.GenerateSeededGame(tileSet, seed, config.PlayerCount, ruleset)
+			if err != nil {
+				return summary, fmt.Errorf("game with seed %v: %w", seed, err)
+			}
+			active = append(active, &selfPlayGame{
+				id:     gameWithID.ID,
+				seed:   seed,
+				policy: config.NewPolicy(seed),
+			})
+		}
+
+		active, err = playTurns(eng, config, active, writer, &summary)
+		if err != nil {
+			break
+		}
+	}
+
+	if closeErr := writer.closeShard(); err == nil {
+		err = closeErr
+	}
+	summary.Shards = writer.paths
+	return summary, err
+}
+
+// Plays a single turn in each of the games and returns the games that are not finished yet.
+// The finished games are written to the shards and deleted from the engine.
+func playTurns(
+	eng *engine.GameEngine, config Config, active []*selfPlayGame, writer *shardWriter, summary *Summary,
+) ([]*selfPlayGame, error) {
+	recorders := make([]*turnRecorder, len(active))
+	chooseRequests := make([]*engine.ChooseMoveRequest, len(active))
+	for i, g := range active {
+		recorders[i] = &turnRecorder{policy: g.policy, options: config.EncodingOptions}
+		chooseRequests[i] = &engine.ChooseMoveRequest{GameID: g.id, Agent: recorders[i]}
+	}
+	playRequests := make([]*engine.PlayTurnRequest, len(active))
+	for i, resp := range eng.SendChooseMoveBatch(chooseRequests) {
+		err := resp.Err()
+		if err == nil {
+			err = recorders[i].err
+		}
+		if err != nil {
+			return active, fmt.Errorf("game with seed %v: %w", active[i].seed, err)
+		}
+		playRequests[i] = &engine.PlayTurnRequest{GameID: active[i].id, Move: resp.Move}
+	}
+
+	remaining := []*selfPlayGame{}
+	for i, resp := range eng.SendPlayTurnBatch(playRequests) {
+		g := active[i]
+		if resp.Err() != nil {
+			return append(remaining, active[i:]...), fmt.Errorf("game with seed %v: %w", g.seed, resp.Err())
+		}
+		record := recorders[i].record
+		record.Seed = g.seed
+		record.Turn = len(g.records)
+		g.records = append(g.records, record)
+		if resp.FinalScores == nil {
+			remaining = append(remaining, g)
+			continue
+		}
+
+		finalScores := make([]uint32, config.PlayerCount)
+		for playerIndex := range finalScores {
+			finalScores[playerIndex] = resp.FinalScores[elements.ID(playerIndex+1)]
+		}
+		for turn := range g.records {
+			g.records[turn].FinalScores = finalScores
+		}
+		eng.DeleteGames([]int{g.id})
+		if err := writer.writeGame(g.records); err != nil {
+			return append(remaining, active[i+1:]...), err
+		}
+		summary.Games++
+		summary.Turns += len(g.records)
+	}
+	return remaining, nil
+}
+
+// Agent recording the turn of a game while choosing its move with the policy,
+// it lets the engine's workers encode the state and run the policy.
+type turnRecorder struct {
+	policy  Policy
+	options encoding.Options
+	record  Record
+	err     error
+}
+
+func (recorder *turnRecorder) ChooseMove(g *game.Game) elements.PlacedTile {
+	// the engine only asks for the move if the game has a current tile
+	tile, _ := g.GetCurrentTile()
+	legalMoves := agents.LegalMoves(g)
+	if recorder.err = recorder.recordState(g, tile, legalMoves); recorder.err != nil {
+		return legalMoves[0]
+	}
+
+	chosenMove, visits := recorder.policy.ChooseMove(g, legalMoves)
+	if chosenMove < 0 || chosenMove >= len(legalMoves) {
+		recorder.err = fmt.Errorf("%w: move %v out of %v legal moves", ErrInvalidPolicy, chosenMove, len(legalMoves))
+		return legalMoves[0]
+	}
+	if visits != nil && len(visits) != len(legalMoves) {
+		recorder.err = fmt.Errorf("%w: %v visits for %v legal moves", ErrInvalidPolicy, len(visits), len(legalMoves))
+		return legalMoves[0]
+	}
+	recorder.record.ChosenMove = chosenMove
+	recorder.record.Visits = visits
+	return legalMoves[chosenMove]
+}
+
+func (recorder *turnRecorder) recordState(
+	g *game.Game, tile tiles.Tile, legalMoves []elements.PlacedTile,
+) error {
+	stateEncoding, err := encoding.Encode(g, tile, recorder.options)
+	if err != nil {
+		return err
+	}
+	space, err := encoding.NewActionSpace(g, recorder.options)
+	if err != nil {
+		return err
+	}
+
+	legalActions := make([]int, len(legalMoves))
+	for i, move := range legalMoves {
+		legalActions[i], err = space.EncodeMove(tile, move)
+		if errors.Is(err, encoding.ErrMoveNotEncodable) {
+			legalActions[i] = -1
+		} else if err != nil {
+			return err
+		}
+	}
+	tileHistogram := make([]int, len(stateEncoding.TileHistogram))
+	for i, count := range stateEncoding.TileHistogram {
+		tileHistogram[i] = int(count)
+	}
+
+	recorder.record = Record{
+		PlayerID:      g.CurrentPlayer().ID(),
+		Tile:          tile,
+		Shape:         stateEncoding.Shape,
+		Planes:        stateEncoding.Planes,
+		Origin:        stateEncoding.Origin,
+		TileHistogram: tileHistogram,
+		LegalMoves:    legalMoves,
+		LegalActions:  legalActions,
+	}
+	return nil
+}
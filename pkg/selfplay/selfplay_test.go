@@ -0,0 +1,170 @@
+package selfplay
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/agents"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+func startEngine(t *testing.T) *engine.GameEngine {
+	eng, err := engine.StartGameEngine(4, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(eng.Close)
+	return eng
+}
+
+func readShards(t *testing.T, paths []string) []Record {
+	records := []Record{}
+	for _, path := range paths {
+		shardRecords, err := ReadShard(path)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		records = append(records, shardRecords...)
+	}
+	return records
+}
+
+func TestRunWritesRecordsOfAllTurnsToShards(t *testing.T) {
+	eng := startEngine(t)
+	config := DefaultConfig()
+	config.Games = 5
+	config.ParallelGames = 3
+	config.GamesPerShard = 2
+	config.OutputDir = t.TempDir()
+
+	summary, err := Run(eng, config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if summary.Games != config.Games {
+		t.Fatalf("expected %#v, got %#v instead", config.Games, summary.Games)
+	}
+	expectedShards := []string{
+		ShardPath(config.OutputDir, 0), ShardPath(config.OutputDir, 1), ShardPath(config.OutputDir, 2),
+	}
+	if !slices.Equal(summary.Shards, expectedShards) {
+		t.Fatalf("expected %#v, got %#v instead", expectedShards, summary.Shards)
+	}
+
+	records := readShards(t, summary.Shards)
+	if len(records) != summary.Turns {
+		t.Fatalf("expected %#v, got %#v instead", summary.Turns, len(records))
+	}
+	movesBySeed := map[int64][]elements.PlacedTile{}
+	for _, record := range records {
+		moves := movesBySeed[record.Seed]
+		if record.Turn != len(moves) {
+			t.Fatalf("expected %#v, got %#v instead", len(moves), record.Turn)
+		}
+		if record.ChosenMove < 0 || record.ChosenMove >= len(record.LegalMoves) {
+			t.Fatalf("expected a legal move index, got %#v instead", record.ChosenMove)
+		}
+		if len(record.LegalActions) != len(record.LegalMoves) || record.Visits != nil {
+			t.Fatalf("expected an action and no visits for each legal move, got %#v", record)
+		}
+		if len(record.Planes) != record.Shape[0]*record.Shape[1]*record.Shape[2] {
+			t.Fatalf("expected %#v, got %#v instead", record.Shape, len(record.Planes))
+		}
+		movesBySeed[record.Seed] = append(moves, record.LegalMoves[record.ChosenMove])
+	}
+	if len(movesBySeed) != config.Games {
+		t.Fatalf("expected %#v, got %#v instead", config.Games, len(movesBySeed))
+	}
+
+	// the game can be reproduced from the seed and the chosen moves
+	tileSet, err := tilesets.ByName(config.TileSet)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	gameWithID, err := eng.GenerateSeededGame(tileSet, config.Seed, config.PlayerCount, rules.Standard())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	var finalScores map[elements.ID]uint32
+	for _, move := range movesBySeed[config.Seed] {
+		req := &engine.PlayTurnRequest{GameID: gameWithID.ID, Move: move}
+		resp := eng.SendPlayTurnBatch([]*engine.PlayTurnRequest{req})[0]
+		if resp.Err() != nil {
+			t.Fatal(resp.Err().Error())
+		}
+		finalScores = resp.FinalScores
+	}
+	for _, record := range records {
+		if record.Seed != config.Seed {
+			continue
+		}
+		for playerIndex, score := range record.FinalScores {
+			if finalScores[elements.ID(playerIndex+1)] != score {
+				t.Fatalf("expected %#v, got %#v instead", finalScores, record.FinalScores)
+			}
+		}
+	}
+}
+
+func TestRunRecordsVisitsOfMCTSPolicy(t *testing.T) {
+	eng := startEngine(t)
+	mctsConfig := agents.DefaultMCTSConfig()
+	mctsConfig.Iterations = 10
+	mctsConfig.Workers = 1
+	mctsConfig.RolloutDepth = 3
+	config := DefaultConfig()
+	config.Games = 1
+	config.OutputDir = t.TempDir()
+	config.NewPolicy = func(seed int64) Policy {
+		return NewMCTSPolicy(mctsConfig, seed)
+	}
+
+	summary, err := Run(eng, config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, record := range readShards(t, summary.Shards) {
+		// forced moves are not searched
+		if len(record.LegalMoves) == 1 {
+			if record.Visits != nil {
+				t.Fatalf("expected no visits for a forced move, got %#v instead", record.Visits)
+			}
+			continue
+		}
+		if len(record.Visits) != len(record.LegalMoves) {
+			t.Fatalf("expected %#v, got %#v instead", len(record.LegalMoves), len(record.Visits))
+		}
+		if record.Visits[record.ChosenMove] != slices.Max(record.Visits) {
+			t.Fatalf("expected the most visited move to be chosen, got %#v", record)
+		}
+	}
+}
+
+type invalidPolicy struct{}
+
+func (policy invalidPolicy) ChooseMove(_ *game.Game, legalMoves []elements.PlacedTile) (int, []int) {
+	return len(legalMoves), nil
+}
+
+func TestRunReturnsErrorForInvalidPolicyChoice(t *testing.T) {
+	eng := startEngine(t)
+	config := DefaultConfig()
+	config.Games = 2
+	config.OutputDir = t.TempDir()
+	config.NewPolicy = func(int64) Policy {
+		return invalidPolicy{}
+	}
+
+	summary, err := Run(eng, config)
+	if !errors.Is(err, ErrInvalidPolicy) {
+		t.Fatalf("expected %#v, got %#v instead", ErrInvalidPolicy, err)
+	}
+	if summary.Games != 0 || len(summary.Shards) != 0 {
+		t.Fatalf("expected no games to be written, got %#v instead", summary)
+	}
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/elements"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/game/position"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+)
+
+// Body of every response with a non-2xx status code.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// elements.SerializedPlayer with the counts encoded as arrays of numbers
+// (encoding/json would encode the byte slices as base64 strings).
+type SerializedPlayer struct {
+	ID           elements.ID
+	MeepleCounts []int
+	Score        uint32
+	TradeGoods   []int
+}
+
+func newSerializedPlayer(player elements.SerializedPlayer) SerializedPlayer {
+	return SerializedPlayer{
+		ID:           player.ID,
+		MeepleCounts: toInts(player.MeepleCounts),
+		Score:        player.Score,
+		TradeGoods:   toInts(player.TradeGoods),
+	}
+}
+
+// game.SerializedGame with the players encoded as SerializedPlayer.
+type SerializedGame struct {
+	game.SerializedGame
+	Players []SerializedPlayer
+}
+
+func newSerializedGame(serialized game.SerializedGame) SerializedGame {
+	players := make([]SerializedPlayer, len(serialized.Players))
+	for i, player := range serialized.Players {
+		players[i] = newSerializedPlayer(player)
+	}
+	return SerializedGame{SerializedGame: serialized, Players: players}
+}
+
+// engine.GameState with the game encoded as SerializedGame,
+// it can be passed back as is in the `state` field of the requests.
+type GameState struct {
+	Game  SerializedGame        `json:"game"`
+	Moves []elements.PlacedTile `json:"moves"`
+}
+
+func newGameState(state *engine.GameState) *GameState {
+	if state == nil {
+		return nil
+	}
+	return &GameState{Game: newSerializedGame(state.Serialized()), Moves: state.SimulatedMoves()}
+}
+
+func toInts(values []uint8) []int {
+	ints := make([]int, len(values))
+	for i, value := range values {
+		ints[i] = int(value)
+	}
+	return ints
+}
+
+// Body of the POST /games/generate request.
+type GenerateGameRequest struct {
+	// Name of the tile set, see tilesets.ByName(), "standard" by default
+	TileSet string `json:"tileSet"`
+	// Name of the ruleset, see rules.ByName(), "standard" by default
+	Ruleset string `json:"ruleset"`
+	// Ruleset to use instead of the named one
+	CustomRuleset *rules.Ruleset `json:"customRuleset,omitempty"`
+	PlayerCount   int            `json:"playerCount"`
+	// Seed for the order of the tiles, the order is random if not set
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// Body of the response to the POST /games/generate request.
+type GameResponse struct {
+	GameID int            `json:"gameID"`
+	Game   SerializedGame `json:"game"`
+}
+
+// Body of the POST /games/clone request.
+type CloneGameRequest struct {
+	GameID int `json:"gameID"`
+	Count  int `json:"count"`
+	// Clone the game as a child game (see engine.GameEngine.SubCloneGame())
+	// instead of a full clone with the log (see engine.GameEngine.CloneGame())
+	Sub bool `json:"sub"`
+}
+
+// Body of the response to the POST /games/clone request.
+type CloneGameResponse struct {
+	GameIDs []int `json:"gameIDs"`
+}
+
+// Body of the POST /games/delete request, the response has an empty body.
+type DeleteGamesRequest struct {
+	GameIDs []int `json:"gameIDs"`
+}
+
+// Body of the batch requests. The requests in the batch are executed in parallel
+// by the engine's workers, so each of them has to be made for a different game,
+// unless all of the requests for that game are read-only.
+type BatchRequest[T any] struct {
+	Requests []T `json:"requests"`
+}
+
+// Body of the response to a batch request, the order of the responses
+// corresponds to the order of the requests.
+type BatchResponse[T any] struct {
+	Responses []T `json:"responses"`
+}
+
+// Fields shared by all of the responses in a batch.
+type BaseResponse struct {
+	GameID int `json:"gameID"`
+	// Empty, if the request succeeded
+	Error string `json:"error,omitempty"`
+}
+
+func newBaseResponse(resp engine.Response) BaseResponse {
+	base := BaseResponse{GameID: resp.GameID()}
+	if err := resp.Err(); err != nil {
+		base.Error = err.Error()
+	}
+	return base
+}
+
+// Item of the POST /batch/play-turn request.
+type PlayTurnRequest struct {
+	GameID int                 `json:"gameID"`
+	Move   elements.PlacedTile `json:"move"`
+}
+
+type PlayTurnResponse struct {
+	BaseResponse
+	Game SerializedGame `json:"game"`
+	// Set once the game is finished, indexed by player ID
+	FinalScores map[elements.ID]uint32 `json:"finalScores,omitempty"`
+	ExtraTurn   bool                   `json:"extraTurn"`
+}
+
+// Item of the POST /batch/legal-moves request.
+type GetLegalMovesRequest struct {
+	GameID int `json:"gameID"`
+	// State returned with one of the legal moves, the game itself is used if not set
+	State *engine.GameState `json:"state,omitempty"`
+	Tile  tiles.Tile        `json:"tile"`
+	// Skip the states of the moves, see /batch/successor-state
+	SkipStates      bool `json:"skipStates,omitempty"`
+	WithScoreDeltas bool `json:"withScoreDeltas,omitempty"`
+}
+
+type MoveWithState struct {
+	Move elements.PlacedTile `json:"move"`
+	// State of the game after the move is played,
+	// can be passed back in the requests to simulate further moves
+	State *GameState `json:"state,omitempty"`
+	// Points received by each of the players when the move is played, indexed by player ID
+	ScoreDelta map[elements.ID]uint32 `json:"scoreDelta,omitempty"`
+}
+
+type GetLegalMovesResponse struct {
+	BaseResponse
+	Moves []MoveWithState `json:"moves"`
+}
+
+// Item of the POST /batch/remaining-tiles request.
+type GetRemainingTilesRequest struct {
+	GameID int               `json:"gameID"`
+	State  *engine.GameState `json:"state,omitempty"`
+}
+
+type TileProbability struct {
+	Tile        tiles.Tile `json:"tile"`
+	Probability float32    `json:"probability"`
+}
+
+type GetRemainingTilesResponse struct {
+	BaseResponse
+	TileProbabilities []TileProbability `json:"tileProbabilities"`
+}
+
+// Item of the POST /batch/mid-game-score request.
+type GetMidGameScoreRequest struct {
+	GameID int               `json:"gameID"`
+	State  *engine.GameState `json:"state,omitempty"`
+}
+
+type GetMidGameScoreResponse struct {
+	BaseResponse
+	// Indexed by player ID
+	Scores map[elements.ID]uint32 `json:"scores"`
+}
+
+// Item of the POST /batch/chance-expansion request.
+type GetChanceExpansionRequest struct {
+	GameID int               `json:"gameID"`
+	State  *engine.GameState `json:"state,omitempty"`
+}
+
+type ChanceOutcome struct {
+	Tile        tiles.Tile      `json:"tile"`
+	Probability float32         `json:"probability"`
+	Moves       []MoveWithState `json:"moves"`
+}
+
+type GetChanceExpansionResponse struct {
+	BaseResponse
+	Outcomes []ChanceOutcome `json:"outcomes"`
+}
+
+// Item of the POST /batch/successor-state request.
+type GetSuccessorStateRequest struct {
+	GameID int                 `json:"gameID"`
+	State  *engine.GameState   `json:"state,omitempty"`
+	Move   elements.PlacedTile `json:"move"`
+}
+
+type GetSuccessorStateResponse struct {
+	BaseResponse
+	State *GameState `json:"state"`
+}
+
+// Item of the POST /batch/state-encoding request.
+type GetStateEncodingRequest struct {
+	GameID int               `json:"gameID"`
+	State  *engine.GameState `json:"state,omitempty"`
+	// Tile for which the placeable positions are encoded, none are encoded if not set
+	Tile tiles.Tile `json:"tile"`
+	// Size of the encoded window of the board in tiles, encoding.DefaultBoardSize if not set
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// See encoding.Encoding for the meaning of the fields.
+type GetStateEncodingResponse struct {
+	BaseResponse
+	Shape         []int             `json:"shape"`
+	Planes        []int             `json:"planes"`
+	Origin        position.Position `json:"origin"`
+	TileHistogram []int             `json:"tileHistogram"`
+}
+
+// Item of the POST /batch/legal-action-mask request.
+type GetLegalActionMaskRequest struct {
+	GameID int               `json:"gameID"`
+	State  *engine.GameState `json:"state,omitempty"`
+	Tile   tiles.Tile        `json:"tile"`
+	// Size of the window of the board in tiles, encoding.DefaultBoardSize if not set
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// See encoding.ActionSpace for the meaning of the fields.
+type GetLegalActionMaskResponse struct {
+	BaseResponse
+	// 1 for each of the legal actions
+	Mask   []int             `json:"mask"`
+	Origin position.Position `json:"origin"`
+	Width  int               `json:"width"`
+	Height int               `json:"height"`
+}
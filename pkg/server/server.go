@@ -0,0 +1,419 @@
+// Package server exposes the GameEngine over HTTP with JSON bodies,
+// allowing agents written in any language to use a single long-lived engine.
+//
+// All endpoints accept POST requests with a JSON body (see the types in schema.go)
+// and reply with a JSON body:
+//   - /games/generate - GenerateGameRequest -> GameResponse
+//   - /games/clone - CloneGameRequest -> CloneGameResponse
+//   - /games/delete - DeleteGamesRequest -> empty body
+//   - /batch/play-turn - BatchRequest[PlayTurnRequest] -> BatchResponse[PlayTurnResponse]
+//   - /batch/legal-moves - BatchRequest[GetLegalMovesRequest] -> BatchResponse[GetLegalMovesResponse]
+//   - /batch/remaining-tiles - BatchRequest[GetRemainingTilesRequest] -> BatchResponse[GetRemainingTilesResponse]
+//   - /batch/mid-game-score - BatchRequest[GetMidGameScoreRequest] -> BatchResponse[GetMidGameScoreResponse]
+//   - /batch/successor-state - BatchRequest[GetSuccessorStateRequest] -> BatchResponse[GetSuccessorStateResponse]
+//   - /batch/state-encoding - BatchRequest[GetStateEncodingRequest] -> BatchResponse[GetStateEncodingResponse]
+//   - /batch/legal-action-mask - BatchRequest[GetLegalActionMaskRequest] -> BatchResponse[GetLegalActionMaskResponse]
+//   - /batch/chance-expansion - BatchRequest[GetChanceExpansionRequest] -> BatchResponse[GetChanceExpansionResponse]
+//
+// Errors of single requests in a batch are returned in the `error` field of their responses,
+// any other error is returned as ErrorResponse with a non-2xx status code.
+//
+// The game types (game.SerializedGame, elements.PlacedTile, tiles.Tile)
+// are encoded the same way as in the game logs, except for the byte slices
+// (e.g. SerializedPlayer.MeepleCounts) which are encoded as arrays of numbers
+// instead of base64 strings.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/encoding"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/rules"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tilesets"
+)
+
+const (
+	// Prefix of the listen address of a Unix socket, see Listen()
+	UnixAddressPrefix  = "unix:"
+	maxRequestBodySize = 64 * 1024 * 1024
+)
+
+var ErrInvalidRequestBody = errors.New("invalid request body")
+
+type Server struct {
+	engine *engine.GameEngine
+	mux    *http.ServeMux
+}
+
+// Creates the server for the given engine. The engine is not closed by the server.
+func New(eng *engine.GameEngine) *Server {
+	server := &Server{engine: eng, mux: http.NewServeMux()}
+	server.mux.HandleFunc("POST /games/generate", server.handleGenerateGame)
+	server.mux.HandleFunc("POST /games/clone", server.handleCloneGame)
+	server.mux.HandleFunc("POST /games/delete", server.handleDeleteGames)
+	server.mux.HandleFunc("POST /batch/play-turn", server.handlePlayTurnBatch)
+	server.mux.HandleFunc("POST /batch/legal-moves", server.handleGetLegalMovesBatch)
+	server.mux.HandleFunc("POST /batch/remaining-tiles", server.handleGetRemainingTilesBatch)
+	server.mux.HandleFunc("POST /batch/mid-game-score", server.handleGetMidGameScoreBatch)
+	server.mux.HandleFunc("POST /batch/successor-state", server.handleGetSuccessorStateBatch)
+	server.mux.HandleFunc("POST /batch/state-encoding", server.handleGetStateEncodingBatch)
+	server.mux.HandleFunc("POST /batch/legal-action-mask", server.handleGetLegalActionMaskBatch)
+	server.mux.HandleFunc("POST /batch/chance-expansion", server.handleGetChanceExpansionBatch)
+	return server
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mux.ServeHTTP(w, r)
+}
+
+// Listens on the given address, which is either a TCP address (e.g. "127.0.0.1:8080")
+// or a path to a Unix socket prefixed with UnixAddressPrefix (e.g. "unix:/tmp/engine.sock").
+func Listen(address string) (net.Listener, error) {
+	if socketPath, ok := strings.CutPrefix(address, UnixAddressPrefix); ok {
+		return net.Listen("unix", socketPath)
+	}
+	return net.Listen("tcp", address)
+}
+
+func (server *Server) handleGenerateGame(w http.ResponseWriter, r *http.Request) {
+	var req GenerateGameRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	tileSetName := req.TileSet
+	if tileSetName == "" {
+		tileSetName = "standard"
+	}
+	tileSet, err := tilesets.ByName(tileSetName)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var ruleset rules.Ruleset
+	if req.CustomRuleset != nil {
+		ruleset = *req.CustomRuleset
+	} else {
+		rulesetName := req.Ruleset
+		if rulesetName == "" {
+			rulesetName = "standard"
+		}
+		ruleset, err = rules.ByName(rulesetName)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	var gameWithID engine.SerializedGameWithID
+	if req.Seed != nil {
+		gameWithID, err = server.engine.GenerateSeededGame(tileSet, *req.Seed, req.PlayerCount, ruleset)
+	} else {
+		gameWithID, err = server.engine.GenerateGame(tileSet, req.PlayerCount, ruleset)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, GameResponse{GameID: gameWithID.ID, Game: newSerializedGame(gameWithID.Game)})
+}
+
+func (server *Server) handleCloneGame(w http.ResponseWriter, r *http.Request) {
+	var req CloneGameRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Count < 0 {
+		writeError(w, fmt.Errorf("%w: negative count", ErrInvalidRequestBody))
+		return
+	}
+
+	var gameIDs []int
+	var err error
+	if req.Sub {
+		gameIDs, err = server.engine.SubCloneGame(req.GameID, req.Count)
+	} else {
+		gameIDs, err = server.engine.CloneGame(req.GameID, req.Count)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, CloneGameResponse{GameIDs: gameIDs})
+}
+
+func (server *Server) handleDeleteGames(w http.ResponseWriter, r *http.Request) {
+	var req DeleteGamesRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	server.engine.DeleteGames(req.GameIDs)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (server *Server) handlePlayTurnBatch(w http.ResponseWriter, r *http.Request) {
+	var batch BatchRequest[PlayTurnRequest]
+	if !decodeRequest(w, r, &batch) {
+		return
+	}
+
+	requests := make([]*engine.PlayTurnRequest, len(batch.Requests))
+	for i, req := range batch.Requests {
+		requests[i] = &engine.PlayTurnRequest{GameID: req.GameID, Move: req.Move}
+	}
+	responses := server.engine.SendPlayTurnBatch(requests)
+
+	result := BatchResponse[PlayTurnResponse]{Responses: make([]PlayTurnResponse, len(responses))}
+	for i, resp := range responses {
+		result.Responses[i] = PlayTurnResponse{
+			BaseResponse: newBaseResponse(resp),
+			Game:         newSerializedGame(resp.Game),
+			FinalScores:  resp.FinalScores,
+			ExtraTurn:    resp.ExtraTurn,
+		}
+	}
+	writeResponse(w, result)
+}
+
+func (server *Server) handleGetLegalMovesBatch(w http.ResponseWriter, r *http.Request) {
+	var batch BatchRequest[GetLegalMovesRequest]
+	if !decodeRequest(w, r, &batch) {
+		return
+	}
+
+	requests := make([]*engine.GetLegalMovesRequest, len(batch.Requests))
+	for i, req := range batch.Requests {
+		requests[i] = &engine.GetLegalMovesRequest{
+			BaseGameID:      req.GameID,
+			StateToCheck:    req.State,
+			TileToPlace:     req.Tile,
+			SkipStates:      req.SkipStates,
+			WithScoreDeltas: req.WithScoreDeltas,
+		}
+	}
+	responses := server.engine.SendGetLegalMovesBatch(requests)
+
+	result := BatchResponse[GetLegalMovesResponse]{Responses: make([]GetLegalMovesResponse, len(responses))}
+	for i, resp := range responses {
+		moves := make([]MoveWithState, len(resp.Moves))
+		for j, move := range resp.Moves {
+			moves[j] = MoveWithState{Move: move.Move, State: newGameState(move.State), ScoreDelta: move.ScoreDelta}
+		}
+		result.Responses[i] = GetLegalMovesResponse{BaseResponse: newBaseResponse(resp), Moves: moves}
+	}
+	writeResponse(w, result)
+}
+
+func (server *Server) handleGetRemainingTilesBatch(w http.ResponseWriter, r *http.Request) {
+	var batch BatchRequest[GetRemainingTilesRequest]
+	if !decodeRequest(w, r, &batch) {
+		return
+	}
+
+	requests := make([]*engine.GetRemainingTilesRequest, len(batch.Requests))
+	for i, req := range batch.Requests {
+		requests[i] = &engine.GetRemainingTilesRequest{BaseGameID: req.GameID, StateToCheck: req.State}
+	}
+	responses := server.engine.SendGetRemainingTilesBatch(requests)
+
+	result := BatchResponse[GetRemainingTilesResponse]{
+		Responses: make([]GetRemainingTilesResponse, len(responses)),
+	}
+	for i, resp := range responses {
+		probabilities := make([]TileProbability, len(resp.TileProbabilities))
+		for j, probability := range resp.TileProbabilities {
+			probabilities[j] = TileProbability{Tile: probability.Tile, Probability: probability.Probability}
+		}
+		result.Responses[i] = GetRemainingTilesResponse{
+			BaseResponse: newBaseResponse(resp), TileProbabilities: probabilities,
+		}
+	}
+	writeResponse(w, result)
+}
+
+func (server *Server) handleGetMidGameScoreBatch(w http.ResponseWriter, r *http.Request) {
+	var batch BatchRequest[GetMidGameScoreRequest]
+	if !decodeRequest(w, r, &batch) {
+		return
+	}
+
+	requests := make([]*engine.GetMidGameScoreRequest, len(batch.Requests))
+	for i, req := range batch.Requests {
+		requests[i] = &engine.GetMidGameScoreRequest{BaseGameID: req.GameID, StateToCheck: req.State}
+	}
+	responses := server.engine.SendGetMidGameScoreBatch(requests)
+
+	result := BatchResponse[GetMidGameScoreResponse]{Responses: make([]GetMidGameScoreResponse, len(responses))}
+	for i, resp := range responses {
+		result.Responses[i] = GetMidGameScoreResponse{BaseResponse: newBaseResponse(resp), Scores: resp.Scores}
+	}
+	writeResponse(w, result)
+}
+
+func (server *Server) handleGetSuccessorStateBatch(w http.ResponseWriter, r *http.Request) {
+	var batch BatchRequest[GetSuccessorStateRequest]
+	if !decodeRequest(w, r, &batch) {
+		return
+	}
+
+	requests := make([]*engine.GetSuccessorStateRequest, len(batch.Requests))
+	for i, req := range batch.Requests {
+		requests[i] = &engine.GetSuccessorStateRequest{
+			BaseGameID: req.GameID, StateToCheck: req.State, Move: req.Move,
+		}
+	}
+	responses := server.engine.SendGetSuccessorStateBatch(requests)
+
+	result := BatchResponse[GetSuccessorStateResponse]{
+		Responses: make([]GetSuccessorStateResponse, len(responses)),
+	}
+	for i, resp := range responses {
+		result.Responses[i] = GetSuccessorStateResponse{BaseResponse: newBaseResponse(resp), State: newGameState(resp.State)}
+	}
+	writeResponse(w, result)
+}
+
+func (server *Server) handleGetStateEncodingBatch(w http.ResponseWriter, r *http.Request) {
+	var batch BatchRequest[GetStateEncodingRequest]
+	if !decodeRequest(w, r, &batch) {
+		return
+	}
+
+	requests := make([]*engine.GetStateEncodingRequest, len(batch.Requests))
+	for i, req := range batch.Requests {
+		requests[i] = &engine.GetStateEncodingRequest{
+			BaseGameID:   req.GameID,
+			StateToCheck: req.State,
+			TileToPlace:  req.Tile,
+			Options:      encoding.Options{Width: req.Width, Height: req.Height},
+		}
+	}
+	responses := server.engine.SendGetStateEncodingBatch(requests)
+
+	result := BatchResponse[GetStateEncodingResponse]{
+		Responses: make([]GetStateEncodingResponse, len(responses)),
+	}
+	for i, resp := range responses {
+		result.Responses[i] = GetStateEncodingResponse{
+			BaseResponse:  newBaseResponse(resp),
+			Shape:         resp.Encoding.Shape,
+			Planes:        toInts(resp.Encoding.Planes),
+			Origin:        resp.Encoding.Origin,
+			TileHistogram: toInts(resp.Encoding.TileHistogram),
+		}
+	}
+	writeResponse(w, result)
+}
+
+func (server *Server) handleGetLegalActionMaskBatch(w http.ResponseWriter, r *http.Request) {
+	var batch BatchRequest[GetLegalActionMaskRequest]
+	if !decodeRequest(w, r, &batch) {
+		return
+	}
+
+	requests := make([]*engine.GetLegalActionMaskRequest, len(batch.Requests))
+	for i, req := range batch.Requests {
+		requests[i] = &engine.GetLegalActionMaskRequest{
+			BaseGameID:   req.GameID,
+			StateToCheck: req.State,
+			TileToPlace:  req.Tile,
+			Options:      encoding.Options{Width: req.Width, Height: req.Height},
+		}
+	}
+	responses := server.engine.SendGetLegalActionMaskBatch(requests)
+
+	result := BatchResponse[GetLegalActionMaskResponse]{
+		Responses: make([]GetLegalActionMaskResponse, len(responses)),
+	}
+	for i, resp := range responses {
+		result.Responses[i] = GetLegalActionMaskResponse{
+			BaseResponse: newBaseResponse(resp),
+			Mask:         toInts(resp.Mask),
+			Origin:       resp.ActionSpace.Origin,
+			Width:        resp.ActionSpace.Width,
+			Height:       resp.ActionSpace.Height,
+		}
+	}
+	writeResponse(w, result)
+}
+
+func (server *Server) handleGetChanceExpansionBatch(w http.ResponseWriter, r *http.Request) {
+	var batch BatchRequest[GetChanceExpansionRequest]
+	if !decodeRequest(w, r, &batch) {
+		return
+	}
+
+	requests := make([]*engine.GetChanceExpansionRequest, len(batch.Requests))
+	for i, req := range batch.Requests {
+		requests[i] = &engine.GetChanceExpansionRequest{BaseGameID: req.GameID, StateToCheck: req.State}
+	}
+	responses := server.engine.SendGetChanceExpansionBatch(requests)
+
+	result := BatchResponse[GetChanceExpansionResponse]{
+		Responses: make([]GetChanceExpansionResponse, len(responses)),
+	}
+	for i, resp := range responses {
+		outcomes := make([]ChanceOutcome, len(resp.Outcomes))
+		for j, outcome := range resp.Outcomes {
+			moves := make([]MoveWithState, len(outcome.Moves))
+			for k, move := range outcome.Moves {
+				moves[k] = MoveWithState{Move: move.Move, State: newGameState(move.State)}
+			}
+			outcomes[j] = ChanceOutcome{Tile: outcome.Tile, Probability: outcome.Probability, Moves: moves}
+		}
+		result.Responses[i] = GetChanceExpansionResponse{
+			BaseResponse: newBaseResponse(resp), Outcomes: outcomes,
+		}
+	}
+	writeResponse(w, result)
+}
+
+// Decodes the body of the request into the given value. Unknown fields are rejected
+// to catch typos early. Returns false, if the error response has already been written.
+func decodeRequest(w http.ResponseWriter, r *http.Request, value any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		writeError(w, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+		return false
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	// the status code is already sent at this point, nothing more can be done on failure
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, engine.ErrGameNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrInvalidRequestBody),
+		errors.Is(err, engine.ErrInvalidPlayerCount),
+		errors.Is(err, tilesets.ErrUnknownTileSet),
+		errors.Is(err, rules.ErrUnknownRuleset):
+		status = http.StatusBadRequest
+	case errors.Is(err, engine.ErrLockAlreadyAcquired):
+		status = http.StatusConflict
+	case errors.Is(err, engine.ErrCommunicatorClosed):
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
+}
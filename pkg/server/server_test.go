@@ -0,0 +1,335 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/encoding"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/engine"
+)
+
+func startTestServer(t *testing.T) *httptest.Server {
+	eng, err := engine.StartGameEngine(4, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	testServer := httptest.NewServer(New(eng))
+	t.Cleanup(func() {
+		testServer.Close()
+		eng.Close()
+	})
+	return testServer
+}
+
+// Sends the request with the given body and decodes the response into `result`,
+// returning the status code.
+func post(t *testing.T, testServer *httptest.Server, endpoint string, body any, result any) int {
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	resp, err := http.Post(testServer.URL+endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer resp.Body.Close()
+	if result != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	return resp.StatusCode
+}
+
+func generateGame(t *testing.T, testServer *httptest.Server) GameResponse {
+	seed := int64(5)
+	var game GameResponse
+	status := post(t, testServer, "/games/generate", GenerateGameRequest{PlayerCount: 2, Seed: &seed}, &game)
+	if status != http.StatusOK {
+		t.Fatalf("expected %#v, got %#v instead", http.StatusOK, status)
+	}
+	return game
+}
+
+// Passes the state from the response back as the state of a request,
+// the same way as a client sending back the JSON it received would.
+func toRequestState(t *testing.T, state *GameState) *engine.GameState {
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	var requestState engine.GameState
+	if err := json.Unmarshal(data, &requestState); err != nil {
+		t.Fatal(err.Error())
+	}
+	return &requestState
+}
+
+func TestServerEncodesPlayersCountsAsArraysOfNumbers(t *testing.T) {
+	testServer := startTestServer(t)
+	seed := int64(5)
+
+	var game map[string]any
+	post(t, testServer, "/games/generate", GenerateGameRequest{PlayerCount: 2, Seed: &seed}, &game)
+
+	player := game["game"].(map[string]any)["Players"].([]any)[0].(map[string]any)
+	for _, field := range []string{"MeepleCounts", "TradeGoods"} {
+		if _, ok := player[field].([]any); !ok {
+			t.Fatalf("expected %v to be an array, got %#v instead", field, player[field])
+		}
+	}
+}
+
+func TestServerPlaysTurnWithLegalMove(t *testing.T) {
+	testServer := startTestServer(t)
+	game := generateGame(t, testServer)
+
+	var legalMoves BatchResponse[GetLegalMovesResponse]
+	post(t, testServer, "/batch/legal-moves", BatchRequest[GetLegalMovesRequest]{
+		Requests: []GetLegalMovesRequest{{GameID: game.GameID, Tile: game.Game.CurrentTile}},
+	}, &legalMoves)
+	if legalMoves.Responses[0].Error != "" {
+		t.Fatal(legalMoves.Responses[0].Error)
+	}
+	if len(legalMoves.Responses[0].Moves) == 0 {
+		t.Fatalf("expected legal moves, got %#v instead", legalMoves.Responses[0])
+	}
+
+	move := legalMoves.Responses[0].Moves[0].Move
+	var playTurn BatchResponse[PlayTurnResponse]
+	post(t, testServer, "/batch/play-turn", BatchRequest[PlayTurnRequest]{
+		Requests: []PlayTurnRequest{{GameID: game.GameID, Move: move}},
+	}, &playTurn)
+	if playTurn.Responses[0].Error != "" {
+		t.Fatal(playTurn.Responses[0].Error)
+	}
+
+	expectedPlayerID := game.Game.CurrentPlayerID%2 + 1
+	actualPlayerID := playTurn.Responses[0].Game.CurrentPlayerID
+	if actualPlayerID != expectedPlayerID {
+		t.Fatalf("expected %#v, got %#v instead", expectedPlayerID, actualPlayerID)
+	}
+	placed := false
+	for _, tile := range playTurn.Responses[0].Game.Tiles {
+		if tile.Position == move.Position {
+			placed = true
+		}
+	}
+	if !placed {
+		t.Fatalf("expected tile at %#v, got %#v instead", move.Position, playTurn.Responses[0].Game.Tiles)
+	}
+}
+
+func TestServerAcceptsStatesReturnedWithLegalMoves(t *testing.T) {
+	testServer := startTestServer(t)
+	game := generateGame(t, testServer)
+
+	var legalMoves BatchResponse[GetLegalMovesResponse]
+	post(t, testServer, "/batch/legal-moves", BatchRequest[GetLegalMovesRequest]{
+		Requests: []GetLegalMovesRequest{{GameID: game.GameID, Tile: game.Game.CurrentTile}},
+	}, &legalMoves)
+	state := toRequestState(t, legalMoves.Responses[0].Moves[0].State)
+
+	var remainingTiles BatchResponse[GetRemainingTilesResponse]
+	post(t, testServer, "/batch/remaining-tiles", BatchRequest[GetRemainingTilesRequest]{
+		Requests: []GetRemainingTilesRequest{{GameID: game.GameID}, {GameID: game.GameID, State: state}},
+	}, &remainingTiles)
+	for _, resp := range remainingTiles.Responses {
+		if resp.Error != "" {
+			t.Fatal(resp.Error)
+		}
+	}
+	var totalProbability float32
+	for _, probability := range remainingTiles.Responses[1].TileProbabilities {
+		totalProbability += probability.Probability
+	}
+	if totalProbability < 0.999 || totalProbability > 1.001 {
+		t.Fatalf("expected %#v, got %#v instead", 1.0, totalProbability)
+	}
+
+	var scores BatchResponse[GetMidGameScoreResponse]
+	post(t, testServer, "/batch/mid-game-score", BatchRequest[GetMidGameScoreRequest]{
+		Requests: []GetMidGameScoreRequest{{GameID: game.GameID, State: state}},
+	}, &scores)
+	if scores.Responses[0].Error != "" {
+		t.Fatal(scores.Responses[0].Error)
+	}
+}
+
+func TestServerReturnsSuccessorStateOfMoveWithSkippedState(t *testing.T) {
+	testServer := startTestServer(t)
+	game := generateGame(t, testServer)
+
+	var legalMoves BatchResponse[GetLegalMovesResponse]
+	post(t, testServer, "/batch/legal-moves", BatchRequest[GetLegalMovesRequest]{
+		Requests: []GetLegalMovesRequest{{GameID: game.GameID, Tile: game.Game.CurrentTile, SkipStates: true}},
+	}, &legalMoves)
+	move := legalMoves.Responses[0].Moves[0]
+	if move.State != nil {
+		t.Fatalf("expected no state, got %#v instead", move.State)
+	}
+
+	var successors BatchResponse[GetSuccessorStateResponse]
+	post(t, testServer, "/batch/successor-state", BatchRequest[GetSuccessorStateRequest]{
+		Requests: []GetSuccessorStateRequest{{GameID: game.GameID, Move: move.Move}},
+	}, &successors)
+	if successors.Responses[0].Error != "" {
+		t.Fatal(successors.Responses[0].Error)
+	}
+
+	var scores BatchResponse[GetMidGameScoreResponse]
+	post(t, testServer, "/batch/mid-game-score", BatchRequest[GetMidGameScoreRequest]{
+		Requests: []GetMidGameScoreRequest{{GameID: game.GameID, State: toRequestState(t, successors.Responses[0].State)}},
+	}, &scores)
+	if scores.Responses[0].Error != "" {
+		t.Fatal(scores.Responses[0].Error)
+	}
+}
+
+func TestServerReturnsChanceExpansion(t *testing.T) {
+	testServer := startTestServer(t)
+	game := generateGame(t, testServer)
+
+	var expansion BatchResponse[GetChanceExpansionResponse]
+	post(t, testServer, "/batch/chance-expansion", BatchRequest[GetChanceExpansionRequest]{
+		Requests: []GetChanceExpansionRequest{{GameID: game.GameID}},
+	}, &expansion)
+	resp := expansion.Responses[0]
+	if resp.Error != "" {
+		t.Fatal(resp.Error)
+	}
+	if len(resp.Outcomes) == 0 {
+		t.Fatal("expected at least one outcome")
+	}
+	for _, outcome := range resp.Outcomes {
+		if outcome.Probability <= 0 {
+			t.Fatalf("expected positive probability, got %#v instead", outcome.Probability)
+		}
+	}
+}
+
+func TestServerReturnsStateEncoding(t *testing.T) {
+	testServer := startTestServer(t)
+	game := generateGame(t, testServer)
+
+	var encodings BatchResponse[GetStateEncodingResponse]
+	post(t, testServer, "/batch/state-encoding", BatchRequest[GetStateEncodingRequest]{
+		Requests: []GetStateEncodingRequest{{GameID: game.GameID, Tile: game.Game.CurrentTile, Width: 5, Height: 3}},
+	}, &encodings)
+	resp := encodings.Responses[0]
+	if resp.Error != "" {
+		t.Fatal(resp.Error)
+	}
+	if len(resp.Shape) != 3 || resp.Shape[1] != 9 || resp.Shape[2] != 15 {
+		t.Fatalf("expected planes of 9x15 cells, got %#v instead", resp.Shape)
+	}
+	if len(resp.Planes) != resp.Shape[0]*resp.Shape[1]*resp.Shape[2] {
+		t.Fatalf("expected %#v, got %#v instead", resp.Shape[0]*resp.Shape[1]*resp.Shape[2], len(resp.Planes))
+	}
+}
+
+func TestServerReturnsLegalActionMask(t *testing.T) {
+	testServer := startTestServer(t)
+	game := generateGame(t, testServer)
+
+	var masks BatchResponse[GetLegalActionMaskResponse]
+	post(t, testServer, "/batch/legal-action-mask", BatchRequest[GetLegalActionMaskRequest]{
+		Requests: []GetLegalActionMaskRequest{{GameID: game.GameID, Tile: game.Game.CurrentTile, Width: 5, Height: 3}},
+	}, &masks)
+	resp := masks.Responses[0]
+	if resp.Error != "" {
+		t.Fatal(resp.Error)
+	}
+	expectedSize := 5 * 3 * encoding.RotationCount * encoding.MeepleSlotCount
+	if len(resp.Mask) != expectedSize {
+		t.Fatalf("expected %#v, got %#v instead", expectedSize, len(resp.Mask))
+	}
+	if !slices.Contains(resp.Mask, 1) {
+		t.Fatal("expected at least one legal action")
+	}
+}
+
+func TestServerClonesAndDeletesGames(t *testing.T) {
+	testServer := startTestServer(t)
+	game := generateGame(t, testServer)
+
+	var clones CloneGameResponse
+	status := post(t, testServer, "/games/clone", CloneGameRequest{GameID: game.GameID, Count: 2}, &clones)
+	if status != http.StatusOK {
+		t.Fatalf("expected %#v, got %#v instead", http.StatusOK, status)
+	}
+	if len(clones.GameIDs) != 2 {
+		t.Fatalf("expected %#v, got %#v instead", 2, len(clones.GameIDs))
+	}
+
+	status = post(t, testServer, "/games/delete", DeleteGamesRequest{GameIDs: clones.GameIDs}, nil)
+	if status != http.StatusNoContent {
+		t.Fatalf("expected %#v, got %#v instead", http.StatusNoContent, status)
+	}
+
+	var scores BatchResponse[GetMidGameScoreResponse]
+	post(t, testServer, "/batch/mid-game-score", BatchRequest[GetMidGameScoreRequest]{
+		Requests: []GetMidGameScoreRequest{{GameID: clones.GameIDs[0]}, {GameID: game.GameID}},
+	}, &scores)
+	if scores.Responses[0].Error == "" {
+		t.Fatalf("expected error for deleted game, got %#v instead", scores.Responses[0])
+	}
+	if scores.Responses[1].Error != "" {
+		t.Fatal(scores.Responses[1].Error)
+	}
+}
+
+func TestServerReturnsNotFoundWhenCloningUnknownGame(t *testing.T) {
+	testServer := startTestServer(t)
+
+	var errResp ErrorResponse
+	status := post(t, testServer, "/games/clone", CloneGameRequest{GameID: 123, Count: 1}, &errResp)
+
+	if status != http.StatusNotFound {
+		t.Fatalf("expected %#v, got %#v instead", http.StatusNotFound, status)
+	}
+	if errResp.Error == "" {
+		t.Fatal("expected error message")
+	}
+}
+
+func TestServerReturnsBadRequestForUnknownTileSet(t *testing.T) {
+	testServer := startTestServer(t)
+
+	var errResp ErrorResponse
+	status := post(t, testServer, "/games/generate", GenerateGameRequest{TileSet: "unknown", PlayerCount: 2}, &errResp)
+
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected %#v, got %#v instead", http.StatusBadRequest, status)
+	}
+}
+
+func TestServerReturnsBadRequestForUnknownFields(t *testing.T) {
+	testServer := startTestServer(t)
+
+	var errResp ErrorResponse
+	status := post(t, testServer, "/games/generate", map[string]any{"playerCont": 2}, &errResp)
+
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected %#v, got %#v instead", http.StatusBadRequest, status)
+	}
+}
+
+func TestListenOnUnixSocket(t *testing.T) {
+	socketPath := path.Join(t.TempDir(), "engine.sock")
+
+	listener, err := Listen(UnixAddressPrefix + socketPath)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer listener.Close()
+
+	if listener.Addr().Network() != "unix" {
+		t.Fatalf("expected %#v, got %#v instead", "unix", listener.Addr().Network())
+	}
+}
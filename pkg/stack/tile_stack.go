@@ -21,6 +21,7 @@ type Stack[T Comparable[T]] struct {
 var (
 	ErrStackOutOfBounds = errors.New("stack: out of bounds")
 	ErrTileNotFound     = errors.New("could not find the given tile")
+	ErrInvalidSnapshot  = errors.New("stack: invalid snapshot")
 )
 
 // New creates new Stack and shuffles it using current time as seed.
@@ -32,12 +33,33 @@ func New[T Comparable[T]](tiles []T) Stack[T] {
 // NewSeeded creates new Stack and shuffles it using the provided seed.
 // NODE: Input slice is not copied.
 func NewSeeded[T Comparable[T]](tiles []T, seed int64) Stack[T] {
+	return NewSeededInGroups(tiles, nil, seed)
+}
+
+// NewInGroups creates new Stack and shuffles it using current time as seed,
+// see NewSeededInGroups() for details.
+// NODE: Input slice is not copied.
+func NewInGroups[T Comparable[T]](tiles []T, groupSizes []int) Stack[T] {
+	return NewSeededInGroups(tiles, groupSizes, time.Now().UnixNano())
+}
+
+// NewSeededInGroups creates new Stack and shuffles it using the provided seed
+// but only within the consecutive groups of tiles of the given sizes,
+// i.e. all tiles from the first group are drawn before the tiles from the second group.
+// Tiles that do not fit in any of the groups are shuffled together as the last group.
+// NODE: Input slice is not copied.
+func NewSeededInGroups[T Comparable[T]](tiles []T, groupSizes []int, seed int64) Stack[T] {
 	stack := NewOrdered(tiles)
 	stack.seed = seed
 	rng := rand.New(rand.NewSource(stack.seed)) //nolint:gosec// Weak number generator is sufficent in our case
-	rng.Shuffle(len(stack.order), func(i, j int) {
-		stack.order[i], stack.order[j] = stack.order[j], stack.order[i]
-	})
+	order := stack.order
+	for _, size := range append(slices.Clone(groupSizes), len(order)) {
+		group := order[:min(size, len(order))]
+		rng.Shuffle(len(group), func(i, j int) {
+			group[i], group[j] = group[j], group[i]
+		})
+		order = order[len(group):]
+	}
 	return stack
 }
 
@@ -98,6 +120,16 @@ func (s Stack[T]) Peek() (T, error) {
 	return s.Get(s.turnNo)
 }
 
+// Rewind moves the stack back by the given number of draws, effectively undoing
+// the given number of calls to Next().
+func (s *Stack[T]) Rewind(count int32) error {
+	if count < 0 || count > s.turnNo {
+		return ErrStackOutOfBounds
+	}
+	s.turnNo -= count
+	return nil
+}
+
 func (s *Stack[T]) MoveToTop(tile T) error {
 	if s.turnNo >= int32(len(s.tiles)) {
 		return ErrStackOutOfBounds
@@ -114,3 +146,48 @@ func (s *Stack[T]) MoveToTop(tile T) error {
 	}
 	return ErrTileNotFound
 }
+
+// Exported state of the stack, meant to be used for saving and restoring it.
+type Snapshot[T any] struct {
+	Seed   int64
+	TurnNo int32
+	Tiles  []T
+	Order  []int32
+}
+
+func (s Stack[T]) Snapshot() Snapshot[T] {
+	return Snapshot[T]{
+		Seed:   s.seed,
+		TurnNo: s.turnNo,
+		Tiles:  s.tiles,
+		Order:  slices.Clone(s.order),
+	}
+}
+
+// FromSnapshot restores the Stack from the state returned by Stack.Snapshot().
+// NODE: Tiles slice is not copied.
+func FromSnapshot[T Comparable[T]](snapshot Snapshot[T]) (Stack[T], error) {
+	tileCount := len(snapshot.Tiles)
+	if len(snapshot.Order) != tileCount {
+		return Stack[T]{}, ErrInvalidSnapshot
+	}
+	if snapshot.TurnNo < 0 || snapshot.TurnNo > int32(tileCount) {
+		return Stack[T]{}, ErrInvalidSnapshot
+	}
+
+	// the order has to be a permutation of tile indexes
+	seen := make([]bool, tileCount)
+	for _, i := range snapshot.Order {
+		if i < 0 || i >= int32(tileCount) || seen[i] {
+			return Stack[T]{}, ErrInvalidSnapshot
+		}
+		seen[i] = true
+	}
+
+	return Stack[T]{
+		seed:   snapshot.Seed,
+		turnNo: snapshot.TurnNo,
+		tiles:  snapshot.Tiles,
+		order:  slices.Clone(snapshot.Order),
+	}, nil
+}
@@ -227,3 +227,95 @@ func TestMoveToTopUpdatesOrderProperly(t *testing.T) {
 		t.Fatalf("expected %#v, got %#v instead", expectedRemaining, remaining)
 	}
 }
+
+func TestRewindRestoresPreviousTiles(t *testing.T) {
+	tiles := []Tile{{0}, {1}, {2}, {3}}
+	stack := NewOrdered(tiles)
+	for range 3 {
+		if _, err := stack.Next(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := stack.Rewind(2); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRemaining := []Tile{{1}, {2}, {3}}
+	remaining := stack.GetRemaining()
+	if !slices.Equal(remaining, expectedRemaining) {
+		t.Fatalf("expected %#v, got %#v instead", expectedRemaining, remaining)
+	}
+}
+
+func TestRewindReturnsErrorWhenRewindingPastStart(t *testing.T) {
+	tiles := []Tile{{0}, {1}}
+	stack := NewOrdered(tiles)
+	if _, err := stack.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := stack.Rewind(2)
+	if err == nil || !errors.Is(err, ErrStackOutOfBounds) {
+		t.Fatal(err)
+	}
+	if stack.GetRemainingTileCount() != 1 {
+		t.Fatalf("expected the stack to remain unchanged, got %#v remaining tiles", stack.GetRemainingTileCount())
+	}
+}
+
+func TestFromSnapshotRestoresStack(t *testing.T) {
+	tiles := []Tile{{0}, {1}, {2}, {3}}
+	original := NewSeeded(tiles, 42)
+	if _, err := original.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := FromSnapshot(original.Snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRemaining := original.GetRemaining()
+	remaining := restored.GetRemaining()
+	if !slices.Equal(remaining, expectedRemaining) {
+		t.Fatalf("expected %#v, got %#v instead", expectedRemaining, remaining)
+	}
+	if restored.seed != original.seed {
+		t.Fatalf("expected %#v, got %#v instead", original.seed, restored.seed)
+	}
+}
+
+func TestFromSnapshotReturnsErrorWhenOrderIsNotPermutation(t *testing.T) {
+	snapshot := Snapshot[Tile]{
+		TurnNo: 0,
+		Tiles:  []Tile{{0}, {1}, {2}},
+		Order:  []int32{0, 1, 1},
+	}
+
+	_, err := FromSnapshot(snapshot)
+	if err == nil || !errors.Is(err, ErrInvalidSnapshot) {
+		t.Fatal(err)
+	}
+}
+
+func TestSetSeedInGroupsKeepsGroupOrder(t *testing.T) {
+	tiles := []Tile{{0}, {1}, {2}, {3}, {4}, {5}, {6}}
+	stack := NewSeededInGroups(tiles, []int{3, 1}, 42)
+
+	expectedGroups := [][]int{{0, 1, 2}, {3}, {4, 5, 6}}
+	for _, group := range expectedGroups {
+		drawn := []int{}
+		for range group {
+			tile, err := stack.Next()
+			if err != nil {
+				t.Fatal(err)
+			}
+			drawn = append(drawn, tile.id)
+		}
+		slices.Sort(drawn)
+		if !slices.Equal(drawn, group) {
+			t.Fatalf("expected %#v, got %#v instead", group, drawn)
+		}
+	}
+}
@@ -14,12 +14,10 @@ import (
 type BinaryTile uint64
 
 // interpreting BinaryTile's bits:
-//      00000000_00000000_1_01_000000011_00_0011_0000010011_0001001100_1000001110
-//       X pos    Y pos   ^ ^   meeple   ^    ^     city       road      field
-//                       /  |            |    |
-//                      / owner playerID |    |
-//                     /                 |    |
-//             is placed                 |    |
+//      00000000_00000000_010_000000011_00_0011_0000010011_0001001100_1000001110
+//       X pos    Y pos    ^    meeple   ^    ^     city       road      field
+//                         |             |    |
+//                  owner playerID       |    |
 //                                       |    |
 //    monastery and unconnected field bits    city shield
 //
@@ -31,16 +29,27 @@ type BinaryTile uint64
 //  - first four bits of the meeple section are the sides (same as with shields)
 //  - next four meeple bits are the corners (same as with fields)
 //  - the last meeple bit is the center
-//  - the owner bits is just one-hot-encoded player ID. (ID(1) = 00...001, ID(2) = 00...010, etc.)
-//  - is placed bit is always 1 on all placed tiles, and 0 on the non-placed tiles
+//  - the owner bits are the binary representation of the player ID (ID(1) = 001, ID(2) = 010, etc.)
+//    which allows for games with up to 7 players
+//  - all placed tiles have at least one of the feature bits set, while the non-placed tiles are equal to 0
 //  - position bits are 8-bit reptesentations of tile position
+//
+// The layout is versioned by LayoutVersion, see its documentation for the earlier versions.
+
+// Version of the bit layout of BinaryTile, bumped on every incompatible change to it.
+//
+// Version 1 had a one-hot encoded 2-bit owner (bits 45-46) followed by an "is placed" bit
+// (bit 47, always set on the placed tiles). Version 2 replaced both of them with the 3-bit
+// owner ID (bits 45-47), the placed tiles can be told apart by being non-zero instead.
+const LayoutVersion = 2
 
 const (
 	featureBitSize  = 10
 	modifierBitSize = 4
 	meepleBitSize   = 9
 	positionBitSize = 8
-	maxPlayers      = 2
+	ownerBitSize    = 3
+	maxPlayers      = 1<<ownerBitSize - 1
 
 	connectionBitOffset  = 4
 	diagonalMeepleOffset = 4
@@ -67,12 +76,9 @@ const (
 	meepleEndBit   = meepleStartBit + meepleBitSize
 
 	playerStartBit = meepleEndBit
-	playerEndBit   = playerStartBit + maxPlayers
-
-	isPlacedBit    = playerEndBit
-	isPlacedEndBit = isPlacedBit + 1
+	playerEndBit   = playerStartBit + ownerBitSize
 
-	positionXStartBit = isPlacedEndBit
+	positionXStartBit = playerEndBit
 	positionXEndBit   = positionXStartBit + positionBitSize
 
 	// positionYStartBit = positionXEndBit
@@ -118,13 +124,19 @@ func fromPlacedFeatures(features []elements.PlacedFeature) BinaryTile {
 		case featureMod.Road:
 			bitOffset = roadStartBit
 
-		case featureMod.Monastery:
+		case featureMod.Monastery, featureMod.Garden:
+			// garden is scored the same way as the monastery so they share the bit
 			binaryTile.setBit(monasteryBit)
 			if feature.Meeple.Type != elements.NoneMeeple {
 				binaryTile.setOwner(feature.Meeple.PlayerID)
 				binaryTile.setBit(meepleEndBit - 1) // last meeple bit is meeple in the center
 			}
 
+		case featureMod.River:
+			// rivers cannot hold meeples and the fields on both sides of the river
+			// are already separate features so there is nothing to store
+			continue
+
 		default:
 			panic("unknown feature type")
 		}
@@ -159,11 +171,6 @@ func FromPlacedTile(tile elements.PlacedTile) BinaryTile {
 
 	binaryTile.addPosition(tile.Position)
 
-	if tile.Features != nil {
-		// turns out not all PlacedTiles are placed
-		binaryTile.setBit(isPlacedBit)
-	}
-
 	return binaryTile
 }
 
@@ -220,14 +227,13 @@ func (binaryTile *BinaryTile) addOrthogonalFeature(feature elements.PlacedFeatur
 	*binaryTile |= tmpBinaryTile
 }
 
-// Sets the appropriate owner bit in the binary tile, if the owner ID is not 0. Panics if ownerID is greater than maxPlayers
+// Sets the owner bits in the binary tile, if the owner ID is not 0. Panics if ownerID is greater than maxPlayers
 func (binaryTile *BinaryTile) setOwner(ownerID elements.ID) {
 	if ownerID != 0 {
 		if ownerID > maxPlayers {
 			panic(fmt.Sprintf("cannot use player ID = %#v in binary tile. Max number of players = %#v", ownerID, maxPlayers))
 		}
-		binaryTile.setBit(playerStartBit + int(ownerID) - 1)
-		// -1 because we don't want an empty bit (always zero) for the "NonePlayer" owner
+		*binaryTile |= BinaryTile(ownerID) << playerStartBit
 	}
 }
 
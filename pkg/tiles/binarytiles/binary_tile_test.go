@@ -20,7 +20,7 @@ func TestFromPlacedTileCityWithShield(t *testing.T) {
 	tile.GetPlacedFeatureAtSide(side.Top, feature.City).ModifierType = modifier.Shield
 	tile.Position = position.New(85, 42)
 
-	expected := BinaryTile(0b01010101_00101010_1_10_000000011_00_0011_0000010011_0001001100_1000001110)
+	expected := BinaryTile(0b01010101_00101010_010_000000011_00_0011_0000010011_0001001100_1000001110)
 	actual := FromPlacedTile(tile)
 
 	if expected != actual {
@@ -60,7 +60,7 @@ func TestFromPlacedTileUnconnectedField(t *testing.T) {
 		elements.Meeple{PlayerID: 1, Type: elements.NormalMeeple}
 	tile.Position = position.New(-21, -37)
 
-	expected := BinaryTile(0b11101011_11011011_1_01_100000000_10_1000_0000001111_0000000000_0000000000)
+	expected := BinaryTile(0b11101011_11011011_001_100000000_10_1000_0000001111_0000000000_0000000000)
 	actual := FromPlacedTile(tile)
 
 	if expected != actual {
@@ -74,7 +74,7 @@ func TestFromPlacedTileMonastery(t *testing.T) {
 	tile.Monastery().Meeple = elements.Meeple{PlayerID: 2, Type: elements.NormalMeeple}
 	tile.Position = position.New(-128, 127)
 
-	expected := BinaryTile(0b10000000_01111111_1_10_100000000_01_0000_0000000000_0000000100_1111111111)
+	expected := BinaryTile(0b10000000_01111111_010_100000000_01_0000_0000000000_0000000100_1111111111)
 	actual := FromPlacedTile(tile)
 
 	if expected != actual {
@@ -85,10 +85,54 @@ func TestFromPlacedTileMonastery(t *testing.T) {
 func TestFromPlacedTileEmptyTile(t *testing.T) {
 	var tile elements.PlacedTile
 
-	expected := BinaryTile(0b00000000_00000000_0_00_000000000_00_0000_0000000000_0000000000_0000000000)
+	expected := BinaryTile(0b00000000_00000000_000_000000000_00_0000_0000000000_0000000000_0000000000)
 	actual := FromPlacedTile(tile)
 
 	if expected != actual {
 		t.Fatalf("expected: %064b\ngot: %064b", expected, actual)
 	}
 }
+
+func TestFromPlacedTileSixthPlayerOwner(t *testing.T) {
+	// straight road with a meeple on the road belonging to player 6
+	tile := elements.ToPlacedTile(tiletemplates.StraightRoads())
+	tile.GetPlacedFeatureAtSide(side.Left, feature.Road).Meeple =
+		elements.Meeple{PlayerID: 6, Type: elements.NormalMeeple}
+	tile.Position = position.New(1, 0)
+
+	expected := BinaryTile(0b00000001_00000000_110_000001010_00_0000_0000000000_1000001010_0010101111)
+	actual := FromPlacedTile(tile)
+
+	if expected != actual {
+		t.Fatalf("expected: %064b\ngot: %064b", expected, actual)
+	}
+}
+
+func TestFromPlacedTilePanicsWhenOwnerIDExceedsMaxPlayers(t *testing.T) {
+	tile := elements.ToPlacedTile(tiletemplates.MonasteryWithoutRoads())
+	tile.Monastery().Meeple = elements.Meeple{PlayerID: maxPlayers + 1, Type: elements.NormalMeeple}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected FromPlacedTile() to panic")
+		}
+	}()
+	FromPlacedTile(tile)
+}
+
+func TestFromTileIgnoresRiver(t *testing.T) {
+	riverTile := tiletemplates.StraightRiverWithBridge()
+	tileWithoutRiver := tiles.Tile{}
+	for _, feat := range riverTile.Features {
+		if feat.FeatureType != feature.River {
+			tileWithoutRiver.Features = append(tileWithoutRiver.Features, feat)
+		}
+	}
+
+	expected := FromTile(tileWithoutRiver)
+	actual := FromTile(riverTile)
+
+	if expected != actual {
+		t.Fatalf("expected: %064b\ngot: %064b", expected, actual)
+	}
+}
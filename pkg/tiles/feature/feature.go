@@ -13,6 +13,9 @@ const (
 	City
 	Field
 	Monastery
+	River
+	// Garden from the 2nd edition of the game (scored like a monastery)
+	Garden
 )
 
 type Feature struct {
@@ -34,6 +37,12 @@ func (feature Feature) Rotate(rotations uint) Feature {
 	}
 }
 
+// Returns feature reflected across the vertical axis of the tile
+func (feature Feature) Reflect() Feature {
+	feature.Sides = feature.Sides.Reflect()
+	return feature
+}
+
 // structs exposed through the bindings do not implement `__eq__`
 // so temporarily implement this as a workaround
 // TODO: nuke this after bindings depend only on the binary tile representation
@@ -5,4 +5,12 @@ type Type int8
 const (
 	NoneType Type = iota
 	Shield
+	// Road modifier from the Inns & Cathedrals expansion
+	Inn
+	// City modifier from the Inns & Cathedrals expansion
+	Cathedral
+	// City modifiers (trade goods) from the Traders & Builders expansion
+	Wine
+	Grain
+	Cloth
 )
@@ -190,6 +190,22 @@ func (side Side) Mirror() Side {
 	return side.Rotate(2).FlipSides()
 }
 
+/*
+Reflects the side across the vertical axis of the tile:
+TopLeftEdge     <->  TopRightEdge
+RightTopEdge    <->  LeftTopEdge
+
+RightBottomEdge <->  LeftBottomEdge
+BottomRightEdge <->  BottomLeftEdge
+*/
+func (side Side) Reflect() Side {
+	// Mirror() is not a reflection of the whole tile - it maps the parts of the side
+	// to the matching parts of the neighbouring tile - but on the left and right edges
+	// it is the same as the reflection across the vertical axis.
+	// The top and bottom edges only have their parts swapped.
+	return (side & (Left | Right)).Mirror() | (side & (Top | Bottom)).FlipSides()
+}
+
 /*
 Flips each part of the side relative to the side's center:
 TopLeftEdge     <->  TopRightEdge
@@ -71,6 +71,55 @@ func TestSideRotateReturnsSideRotatedTwice(t *testing.T) {
 	}
 }
 
+func TestSideReflect(t *testing.T) {
+	testCases := []struct {
+		side     Side
+		expected Side
+	}{
+		{NoSide, NoSide},
+		{All, All},
+		{Top, Top},
+		{Right, Left},
+		{TopLeftEdge, TopRightEdge},
+		{RightTopEdge, LeftTopEdge},
+		{RightBottomEdge, LeftBottomEdge},
+		{BottomLeftEdge, BottomRightEdge},
+		{Top | RightTopEdge, Top | LeftTopEdge},
+	}
+	for _, testCase := range testCases {
+		if testCase.side.Reflect() != testCase.expected {
+			t.Fatalf("expected %#v, got %#v instead", testCase.expected, testCase.side.Reflect())
+		}
+		if testCase.side.Reflect().Reflect() != testCase.side {
+			t.Fatalf("expected %#v, got %#v instead", testCase.side, testCase.side.Reflect().Reflect())
+		}
+	}
+}
+
+func TestSideReflectAgreesWithMirrorForEverySide(t *testing.T) {
+	for value := range 256 {
+		side := Side(value)
+		horizontalEdges := side & (Left | Right)
+		verticalEdges := side & (Top | Bottom)
+		if side.Reflect()&(Left|Right) != horizontalEdges.Mirror() {
+			t.Fatalf("expected %#v, got %#v instead", horizontalEdges.Mirror(), side.Reflect()&(Left|Right))
+		}
+		if side.Reflect()&(Top|Bottom) != verticalEdges.FlipSides() {
+			t.Fatalf("expected %#v, got %#v instead", verticalEdges.FlipSides(), side.Reflect()&(Top|Bottom))
+		}
+		if side.Reflect().Reflect() != side {
+			t.Fatalf("expected %#v, got %#v instead", side, side.Reflect().Reflect())
+		}
+		// a reflection reverses the direction of the rotation
+		for rotations := range uint(4) {
+			expected := side.Reflect().Rotate(4 - rotations)
+			if side.Rotate(rotations).Reflect() != expected {
+				t.Fatalf("expected %#v, got %#v instead", expected, side.Rotate(rotations).Reflect())
+			}
+		}
+	}
+}
+
 func TestSideMirror(t *testing.T) {
 	if NoSide.Mirror() != NoSide {
 		t.Fatalf("expected %#v, got %#v instead", NoSide, NoSide.Mirror())
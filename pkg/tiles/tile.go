@@ -99,6 +99,19 @@ func (tile Tile) Rotate(rotations uint) Tile {
 	return tile
 }
 
+/*
+Reflect tile across its vertical axis
+*/
+func (tile Tile) Reflect() Tile {
+	newFeatures := make([]featureMod.Feature, len(tile.Features))
+	for i, feature := range tile.Features {
+		newFeatures[i] = feature.Reflect()
+	}
+
+	tile.Features = newFeatures
+	return tile
+}
+
 /*
 Return the feature of certain type on desired side
 */
@@ -0,0 +1,122 @@
+package tiletemplates
+
+import (
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
+)
+
+/*
+returns tiles.Tile having road from left to right and a garden
+*/
+func StraightRoadsGarden() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.Road,
+				Sides: side.Left |
+					side.Right,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftBottomEdge |
+					side.BottomLeftEdge |
+					side.BottomRightEdge |
+					side.RightBottomEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.TopLeftEdge |
+					side.TopRightEdge |
+					side.RightTopEdge,
+			},
+			{
+				FeatureType: feature.Garden,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having road from left to bottom and a garden
+*/
+func RoadsTurnGarden() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.Road,
+				Sides: side.Left |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.TopLeftEdge |
+					side.TopRightEdge |
+					side.RightTopEdge |
+					side.RightBottomEdge |
+					side.BottomRightEdge,
+			},
+			{
+				FeatureType: feature.Garden,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having single city edge on top and a garden
+*/
+func SingleCityEdgeNoRoadsGarden() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.City,
+				Sides:       side.Top,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.RightTopEdge |
+					side.RightBottomEdge |
+					side.BottomRightEdge |
+					side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Garden,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having city edges on top and right. Connected and a garden
+*/
+func TwoCityEdgesCornerConnectedGarden() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.City,
+				Sides: side.Top |
+					side.Right,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.LeftBottomEdge |
+					side.BottomLeftEdge |
+					side.BottomRightEdge,
+			},
+			{
+				FeatureType: feature.Garden,
+			},
+		},
+	}
+}
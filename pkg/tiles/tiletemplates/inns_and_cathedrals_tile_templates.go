@@ -0,0 +1,179 @@
+package tiletemplates
+
+import (
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature/modifier"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
+)
+
+/*
+returns tiles.Tile having 4 city edges. Connected, with a cathedral
+*/
+func FourCityEdgesConnectedCathedral() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.City,
+				ModifierType: modifier.Cathedral,
+				Sides: side.Top |
+					side.Right |
+					side.Left |
+					side.Bottom,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having road from left to right with an inn
+*/
+func StraightRoadsInn() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.Road,
+				ModifierType: modifier.Inn,
+				Sides: side.Left |
+					side.Right,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftBottomEdge |
+					side.BottomLeftEdge |
+					side.BottomRightEdge |
+					side.RightBottomEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.TopLeftEdge |
+					side.TopRightEdge |
+					side.RightTopEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having road from left to bottom with an inn
+*/
+func RoadsTurnInn() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.Road,
+				ModifierType: modifier.Inn,
+				Sides: side.Left |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.TopLeftEdge |
+					side.TopRightEdge |
+					side.RightTopEdge |
+					side.RightBottomEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having single city edge on top and road from left to right with an inn
+*/
+func SingleCityEdgeStraightRoadsInn() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.City,
+				Sides:       side.Top,
+			},
+			{
+				FeatureType:  feature.Road,
+				ModifierType: modifier.Inn,
+				Sides: side.Right |
+					side.Left,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.RightBottomEdge |
+					side.BottomRightEdge |
+					side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.RightTopEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having city edges on top, right and left. Connected and road at the bottom with an inn
+*/
+func ThreeCityEdgesConnectedRoadInn() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.City,
+				Sides: side.Top |
+					side.Right |
+					side.Left,
+			},
+			{
+				FeatureType:  feature.Road,
+				ModifierType: modifier.Inn,
+				Sides:        side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides:       side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides:       side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having monastery and road going bottom with an inn
+*/
+func MonasteryWithSingleRoadInn() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.Road,
+				ModifierType: modifier.Inn,
+				Sides:        side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopLeftEdge |
+					side.TopRightEdge |
+
+					side.RightTopEdge |
+					side.RightBottomEdge |
+
+					side.LeftTopEdge |
+					side.LeftBottomEdge |
+
+					side.BottomLeftEdge |
+					side.BottomRightEdge,
+			},
+			{
+				FeatureType: feature.Monastery,
+			},
+		},
+	}
+}
@@ -0,0 +1,328 @@
+package tiletemplates
+
+import (
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
+)
+
+/*
+returns tiles.Tile having the spring of the river going bottom
+*/
+func RiverSpring() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides:       side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopLeftEdge |
+					side.TopRightEdge |
+
+					side.RightTopEdge |
+					side.RightBottomEdge |
+
+					side.LeftTopEdge |
+					side.LeftBottomEdge |
+
+					side.BottomLeftEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having the lake that the river coming from top ends in
+*/
+func RiverLake() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides:       side.Top,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopLeftEdge |
+					side.TopRightEdge |
+
+					side.RightTopEdge |
+					side.RightBottomEdge |
+
+					side.LeftTopEdge |
+					side.LeftBottomEdge |
+
+					side.BottomLeftEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having river from top to bottom
+*/
+func StraightRiver() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides: side.Top |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopLeftEdge |
+					side.LeftTopEdge |
+					side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopRightEdge |
+					side.RightTopEdge |
+					side.RightBottomEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having river from left to bottom
+*/
+func RiverTurn() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides: side.Left |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.TopLeftEdge |
+					side.TopRightEdge |
+					side.RightTopEdge |
+					side.RightBottomEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having river from top to bottom and a bridge with road from left to right
+*/
+func StraightRiverWithBridge() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides: side.Top |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.Road,
+				Sides: side.Left |
+					side.Right,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopLeftEdge |
+					side.LeftTopEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopRightEdge |
+					side.RightTopEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.RightBottomEdge |
+					side.BottomRightEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.BottomLeftEdge |
+					side.LeftBottomEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having river from left to bottom and road from top to right
+*/
+func RiverTurnWithRoadTurn() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides: side.Left |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.Road,
+				Sides: side.Top |
+					side.Right,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.TopLeftEdge |
+					side.RightBottomEdge |
+					side.BottomRightEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopRightEdge |
+					side.RightTopEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having river from top to bottom and city edges on left and right. Not connected
+*/
+func StraightRiverWithTwoCityEdges() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides: side.Top |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.City,
+				Sides:       side.Left,
+			},
+			{
+				FeatureType: feature.City,
+				Sides:       side.Right,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopLeftEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopRightEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having river from top to bottom and city edge on left
+*/
+func StraightRiverWithCityEdge() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides: side.Top |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.City,
+				Sides:       side.Left,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopLeftEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.TopRightEdge |
+					side.RightTopEdge |
+					side.RightBottomEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having river from left to bottom and city edges on top and right. Connected
+*/
+func RiverTurnWithCityCorner() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides: side.Left |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.City,
+				Sides: side.Top |
+					side.Right,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having river from left to bottom, monastery and road going right
+*/
+func RiverTurnWithMonasteryAndRoad() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType: feature.River,
+				Sides: side.Left |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.Road,
+				Sides:       side.Right,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.TopLeftEdge |
+					side.TopRightEdge |
+					side.RightTopEdge |
+					side.RightBottomEdge |
+					side.BottomRightEdge,
+			},
+			{
+				FeatureType: feature.Monastery,
+			},
+		},
+	}
+}
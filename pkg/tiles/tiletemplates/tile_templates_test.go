@@ -40,9 +40,36 @@ func TestTileTemplateSidesOverlapInValidWays(t *testing.T) {
 		tiletemplates.ThreeCityEdgesConnectedRoadShield,
 		tiletemplates.FourCityEdgesConnectedShield,
 		tiletemplates.TestOnlyField,
+		tiletemplates.RiverSpring,
+		tiletemplates.RiverLake,
+		tiletemplates.StraightRiver,
+		tiletemplates.RiverTurn,
+		tiletemplates.StraightRiverWithBridge,
+		tiletemplates.RiverTurnWithRoadTurn,
+		tiletemplates.StraightRiverWithTwoCityEdges,
+		tiletemplates.StraightRiverWithCityEdge,
+		tiletemplates.RiverTurnWithCityCorner,
+		tiletemplates.RiverTurnWithMonasteryAndRoad,
+		tiletemplates.FourCityEdgesConnectedCathedral,
+		tiletemplates.StraightRoadsInn,
+		tiletemplates.RoadsTurnInn,
+		tiletemplates.SingleCityEdgeStraightRoadsInn,
+		tiletemplates.ThreeCityEdgesConnectedRoadInn,
+		tiletemplates.MonasteryWithSingleRoadInn,
+		tiletemplates.TwoCityEdgesCornerConnectedWine,
+		tiletemplates.SingleCityEdgeStraightRoadsWine,
+		tiletemplates.TwoCityEdgesUpAndDownConnectedGrain,
+		tiletemplates.TwoCityEdgesCornerConnectedRoadTurnGrain,
+		tiletemplates.ThreeCityEdgesConnectedCloth,
+		tiletemplates.ThreeCityEdgesConnectedRoadCloth,
+		tiletemplates.StraightRoadsGarden,
+		tiletemplates.RoadsTurnGarden,
+		tiletemplates.SingleCityEdgeNoRoadsGarden,
+		tiletemplates.TwoCityEdgesCornerConnectedGarden,
 	}
 	validFeatureTypeCombinations := [][]feature.Type{
 		{feature.Road, feature.Field},
+		{feature.River, feature.Field},
 	}
 	for _, tileTemplateFunc := range tiles {
 		funcNameParts := strings.Split(
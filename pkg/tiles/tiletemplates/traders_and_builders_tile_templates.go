@@ -0,0 +1,171 @@
+package tiletemplates
+
+import (
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature/modifier"
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/side"
+)
+
+/*
+returns tiles.Tile having city edges on top and right. Connected, with wine
+*/
+func TwoCityEdgesCornerConnectedWine() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.City,
+				ModifierType: modifier.Wine,
+				Sides: side.Top |
+					side.Right,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.LeftBottomEdge |
+					side.BottomLeftEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having single city edge on top with wine and road from left to right
+*/
+func SingleCityEdgeStraightRoadsWine() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.City,
+				ModifierType: modifier.Wine,
+				Sides:        side.Top,
+			},
+			{
+				FeatureType: feature.Road,
+				Sides: side.Right |
+					side.Left,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.RightBottomEdge |
+					side.BottomRightEdge |
+					side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.RightTopEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having city edges on top and down. Connected, with grain
+*/
+func TwoCityEdgesUpAndDownConnectedGrain() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.City,
+				ModifierType: modifier.Grain,
+				Sides: side.Top |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.LeftBottomEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.RightTopEdge |
+					side.RightBottomEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having city edges on top and right. Connected, with grain but also road from left to bottom
+*/
+func TwoCityEdgesCornerConnectedRoadTurnGrain() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.City,
+				ModifierType: modifier.Grain,
+				Sides: side.Top |
+					side.Right,
+			},
+			{
+				FeatureType: feature.Road,
+				Sides: side.Left |
+					side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftBottomEdge |
+					side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.LeftTopEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having city edges on top, right and left. Connected, with cloth
+*/
+func ThreeCityEdgesConnectedCloth() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.City,
+				ModifierType: modifier.Cloth,
+				Sides: side.Top |
+					side.Right |
+					side.Left,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides: side.BottomLeftEdge |
+					side.BottomRightEdge,
+			},
+		},
+	}
+}
+
+/*
+returns tiles.Tile having city edges on top, right and left. Connected, with cloth and road at the bottom
+*/
+func ThreeCityEdgesConnectedRoadCloth() tiles.Tile {
+	return tiles.Tile{
+		Features: []feature.Feature{
+			{
+				FeatureType:  feature.City,
+				ModifierType: modifier.Cloth,
+				Sides: side.Top |
+					side.Right |
+					side.Left,
+			},
+			{
+				FeatureType: feature.Road,
+				Sides:       side.Bottom,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides:       side.BottomLeftEdge,
+			},
+			{
+				FeatureType: feature.Field,
+				Sides:       side.BottomRightEdge,
+			},
+		},
+	}
+}
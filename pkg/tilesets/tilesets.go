@@ -1,13 +1,31 @@
 package tilesets
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles"
 	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/tiletemplates"
 )
 
+var ErrUnknownTileSet = errors.New("unknown tile set")
+
+var namedTileSets = map[string]func() TileSet{
+	"standard":             StandardTileSet,
+	"river":                RiverTileSet,
+	"inns-and-cathedrals":  InnsAndCathedralsTileSet,
+	"traders-and-builders": TradersAndBuildersTileSet,
+	"gardens":              GardensTileSet,
+}
+
 type TileSet struct {
 	StartingTile tiles.Tile
 	Tiles        []tiles.Tile
+	// Sizes of the consecutive groups of `Tiles` that are shuffled separately
+	// and dealt one after another, see stack.NewSeededInGroups().
+	// All tiles are shuffled together, if empty.
+	Groups []int
 }
 
 func StandardTileSet() TileSet { //nolint:gocyclo // shallow loops for adding tiles
@@ -141,3 +159,150 @@ func StandardTileSet() TileSet { //nolint:gocyclo // shallow loops for adding ti
 		Tiles:        tiles,
 	}
 }
+
+// River expansion tiles that are dealt before the tiles from the standard set.
+// The river starts with the spring (starting tile) and the lake is always its last tile.
+func RiverTileSet() TileSet {
+	var tiles []tiles.Tile
+
+	// straight river
+	for range 2 {
+		tiles = append(tiles, tiletemplates.StraightRiver())
+	}
+
+	// river turn
+	for range 2 {
+		tiles = append(tiles, tiletemplates.RiverTurn())
+	}
+
+	tiles = append(tiles,
+		tiletemplates.StraightRiverWithBridge(),
+		tiletemplates.RiverTurnWithRoadTurn(),
+		tiletemplates.StraightRiverWithTwoCityEdges(),
+		tiletemplates.StraightRiverWithCityEdge(),
+		tiletemplates.RiverTurnWithCityCorner(),
+		tiletemplates.RiverTurnWithMonasteryAndRoad(),
+	)
+	riverTileCount := len(tiles)
+
+	tiles = append(tiles, tiletemplates.RiverLake())
+
+	// the standard starting tile is shuffled together with the rest of the standard set
+	standardTileSet := StandardTileSet()
+	tiles = append(tiles, standardTileSet.StartingTile)
+	tiles = append(tiles, standardTileSet.Tiles...)
+
+	return TileSet{
+		StartingTile: tiletemplates.RiverSpring(),
+		Tiles:        tiles,
+		Groups:       []int{riverTileCount, 1},
+	}
+}
+
+// Standard tiles extended with the inn and cathedral tiles from the Inns & Cathedrals expansion.
+// Players of a game with these tiles also receive a big meeple.
+func InnsAndCathedralsTileSet() TileSet {
+	tileSet := StandardTileSet()
+
+	// cathedrals
+	for range 2 {
+		tileSet.Tiles = append(tileSet.Tiles, tiletemplates.FourCityEdgesConnectedCathedral())
+	}
+
+	// straight roads with inn
+	for range 2 {
+		tileSet.Tiles = append(tileSet.Tiles, tiletemplates.StraightRoadsInn())
+	}
+
+	// roads turns with inn
+	for range 2 {
+		tileSet.Tiles = append(tileSet.Tiles, tiletemplates.RoadsTurnInn())
+	}
+
+	tileSet.Tiles = append(tileSet.Tiles,
+		tiletemplates.SingleCityEdgeStraightRoadsInn(),
+		tiletemplates.ThreeCityEdgesConnectedRoadInn(),
+		tiletemplates.MonasteryWithSingleRoadInn(),
+	)
+
+	return tileSet
+}
+
+// Standard tiles extended with the trade goods tiles from the Traders & Builders expansion.
+// Players of a game with these tiles also receive a builder and a pig.
+func TradersAndBuildersTileSet() TileSet {
+	tileSet := StandardTileSet()
+
+	// wine
+	for range 5 {
+		tileSet.Tiles = append(tileSet.Tiles, tiletemplates.TwoCityEdgesCornerConnectedWine())
+	}
+	for range 4 {
+		tileSet.Tiles = append(tileSet.Tiles, tiletemplates.SingleCityEdgeStraightRoadsWine())
+	}
+
+	// grain
+	for range 3 {
+		tileSet.Tiles = append(tileSet.Tiles, tiletemplates.TwoCityEdgesUpAndDownConnectedGrain())
+	}
+	for range 3 {
+		tileSet.Tiles = append(tileSet.Tiles, tiletemplates.TwoCityEdgesCornerConnectedRoadTurnGrain())
+	}
+
+	// cloth
+	for range 3 {
+		tileSet.Tiles = append(tileSet.Tiles, tiletemplates.ThreeCityEdgesConnectedCloth())
+	}
+	for range 2 {
+		tileSet.Tiles = append(tileSet.Tiles, tiletemplates.ThreeCityEdgesConnectedRoadCloth())
+	}
+
+	return tileSet
+}
+
+// Standard tiles in which some of the tiles have a garden (2nd edition of the game).
+// Players of a game with these tiles also receive an abbot.
+func GardensTileSet() TileSet {
+	tileSet := StandardTileSet()
+
+	replacements := []struct {
+		tile   tiles.Tile
+		garden tiles.Tile
+		count  int
+	}{
+		{tiletemplates.StraightRoads(), tiletemplates.StraightRoadsGarden(), 2},
+		{tiletemplates.RoadsTurn(), tiletemplates.RoadsTurnGarden(), 2},
+		{tiletemplates.SingleCityEdgeNoRoads(), tiletemplates.SingleCityEdgeNoRoadsGarden(), 2},
+		{tiletemplates.TwoCityEdgesCornerConnected(), tiletemplates.TwoCityEdgesCornerConnectedGarden(), 2},
+	}
+	for _, replacement := range replacements {
+		replaced := 0
+		for i, tile := range tileSet.Tiles {
+			if replaced < replacement.count && tile.ExactEquals(replacement.tile) {
+				tileSet.Tiles[i] = replacement.garden
+				replaced++
+			}
+		}
+	}
+
+	return tileSet
+}
+
+// Returns the sorted names of the tile sets that can be passed to ByName()
+func Names() []string {
+	names := make([]string, 0, len(namedTileSets))
+	for name := range namedTileSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the tile set with the given name, e.g. "standard" or "inns-and-cathedrals"
+func ByName(name string) (TileSet, error) {
+	newTileSet, ok := namedTileSets[name]
+	if !ok {
+		return TileSet{}, fmt.Errorf("%w: %q (expected one of %v)", ErrUnknownTileSet, name, Names())
+	}
+	return newTileSet(), nil
+}
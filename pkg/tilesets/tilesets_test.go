@@ -1,7 +1,10 @@
 package tilesets
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/YetAnotherSpieskowcy/Carcassonne-Engine/pkg/tiles/feature"
 )
 
 // reference for sets tiles amount https://docs.google.com/spreadsheets/d/1TnPvB6oyisNGs7GZ0xpu-3LPp1V5-t0xH4vocCUPvsY/edit#gid=0
@@ -16,3 +19,85 @@ func TestStandardTileSet(t *testing.T) {
 		t.Fatalf("got %#v tiles, should be %#v", actual, expected)
 	}
 }
+
+func TestRiverTileSet(t *testing.T) {
+	var set = RiverTileSet()
+	expectedRiverTiles := 10
+	expected := expectedRiverTiles + 1 + 72
+
+	actual := len(set.Tiles)
+
+	if expected != actual {
+		t.Fatalf("got %#v tiles, should be %#v", actual, expected)
+	}
+	if set.Groups[0] != expectedRiverTiles {
+		t.Fatalf("got %#v river tiles, should be %#v", set.Groups[0], expectedRiverTiles)
+	}
+}
+
+func TestInnsAndCathedralsTileSet(t *testing.T) {
+	var set = InnsAndCathedralsTileSet()
+	expected := 71 + 9
+
+	actual := len(set.Tiles)
+
+	if expected != actual {
+		t.Fatalf("got %#v tiles, should be %#v", actual, expected)
+	}
+}
+
+func TestTradersAndBuildersTileSet(t *testing.T) {
+	var set = TradersAndBuildersTileSet()
+	expected := 71 + 20
+
+	actual := len(set.Tiles)
+
+	if expected != actual {
+		t.Fatalf("got %#v tiles, should be %#v", actual, expected)
+	}
+}
+
+func TestGardensTileSet(t *testing.T) {
+	var set = GardensTileSet()
+	expected := 71
+	expectedGardens := 8
+
+	actual := len(set.Tiles)
+	actualGardens := 0
+	for _, tile := range set.Tiles {
+		for _, feat := range tile.Features {
+			if feat.FeatureType == feature.Garden {
+				actualGardens++
+			}
+		}
+	}
+
+	if expected != actual {
+		t.Fatalf("got %#v tiles, should be %#v", actual, expected)
+	}
+	if expectedGardens != actualGardens {
+		t.Fatalf("got %#v gardens, should be %#v", actualGardens, expectedGardens)
+	}
+}
+
+func TestByNameReturnsNamedTileSet(t *testing.T) {
+	set, err := ByName("inns-and-cathedrals")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := len(InnsAndCathedralsTileSet().Tiles)
+
+	actual := len(set.Tiles)
+
+	if expected != actual {
+		t.Fatalf("got %#v tiles, should be %#v", actual, expected)
+	}
+}
+
+func TestByNameReturnsErrorForUnknownName(t *testing.T) {
+	_, err := ByName("unknown")
+
+	if !errors.Is(err, ErrUnknownTileSet) {
+		t.Fatalf("got %#v error, should be %#v", err, ErrUnknownTileSet)
+	}
+}
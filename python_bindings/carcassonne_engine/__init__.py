@@ -1,14 +1,26 @@
 import os
 import warnings
+from collections.abc import Callable, Sequence
 from types import TracebackType
 from typing import Self
 
 from . import requests
 from ._bindings import (  # type: ignore[attr-defined] # no stubs
+    agents as _go_agents,
+    encoding as _go_encoding,
     engine as _go_engine,
     go as _go,
 )
-from .models import SerializedGame, SerializedGameWithID
+from .models import (
+    GameState,
+    SearchEvaluation,
+    SearchLeaf,
+    SearchResult,
+    SerializedGame,
+    SerializedGameWithID,
+    StateCacheStats,
+)
+from .rules import Ruleset, standard_ruleset
 from .tilesets import TileSet
 
 __all__ = ("GameEngine",)
@@ -42,6 +54,27 @@ class GameEngine:
     def close(self) -> None:
         self._go_game_engine.Close()
 
+    def set_ordered_locking(self, enabled: bool) -> None:
+        """
+        Enable or disable the ordered locking mode (disabled by default).
+
+        In the ordered mode, the requests for the same game - both in one batch
+        and across concurrent batches - wait for each other and get executed
+        in the order of their submission instead of failing with a lock error.
+        """
+        self._go_game_engine.SetOrderedLocking(enabled)
+
+    def set_undo_enabled(self, enabled: bool) -> None:
+        """
+        Enable or disable recording the turns of the games added to the engine
+        afterwards (disabled by default), allowing them to be reverted
+        with `UndoTurnRequest`.
+
+        The recorded turns are kept for the whole lifetime of the game,
+        so it should only be enabled when the turns actually need to be reverted.
+        """
+        self._go_game_engine.SetUndoEnabled(enabled)
+
     def _check_closed(self) -> None:
         if self.closed:
             raise RuntimeError("The game engine has already been closed.")
@@ -58,11 +91,24 @@ class GameEngine:
     ) -> None:
         self.close()
 
-    def generate_game(self, tileset: TileSet) -> SerializedGameWithID:
-        """Generate a random game from the given tileset."""
+    def generate_game(
+        self,
+        tileset: TileSet,
+        player_count: int = 2,
+        ruleset: Ruleset | None = None,
+    ) -> SerializedGameWithID:
+        """
+        Generate a random game with the given player count from the given tileset.
+
+        The game is played with the standard rules, unless a ruleset is given.
+        """
         self._check_closed()
+        if ruleset is None:
+            ruleset = standard_ruleset()
         try:
-            go_obj = self._go_game_engine.GenerateGame(tileset._unwrap())
+            go_obj = self._go_game_engine.GenerateGame(
+                tileset._unwrap(), player_count, ruleset._unwrap()
+            )
         except RuntimeError as exc:
             # We want to raise IOError (or its subclasses) or engine-specific
             # exceptions depending on what error is returned here but since gopy
@@ -72,16 +118,28 @@ class GameEngine:
             raise Exception(str(exc)) from None
         return SerializedGameWithID(go_obj.ID, SerializedGame(go_obj.Game))
 
-    def generate_ordered_game(self, tileset: TileSet) -> SerializedGameWithID:
+    def generate_ordered_game(
+        self,
+        tileset: TileSet,
+        player_count: int = 2,
+        ruleset: Ruleset | None = None,
+    ) -> SerializedGameWithID:
         """
-        Generate a game from the given tileset using its defined tile order.
+        Generate a game for the given number of players from the given tileset
+        using its defined tile order.
+
+        The game is played with the standard rules, unless a ruleset is given.
 
         Usage for games played by an agent is ill-advised - the serialized game reveals
         the tileset and the order in it will be consistent with stack's order.
         """
         self._check_closed()
+        if ruleset is None:
+            ruleset = standard_ruleset()
         try:
-            go_obj = self._go_game_engine.GenerateOrderedGame(tileset._unwrap())
+            go_obj = self._go_game_engine.GenerateOrderedGame(
+                tileset._unwrap(), player_count, ruleset._unwrap()
+            )
         except RuntimeError as exc:
             # We want to raise IOError (or its subclasses) or engine-specific
             # exceptions depending on what error is returned here but since gopy
@@ -91,6 +149,51 @@ class GameEngine:
             raise Exception(str(exc)) from None
         return SerializedGameWithID(go_obj.ID, SerializedGame(go_obj.Game))
 
+    def load_game_from_log(self, log_path: str) -> SerializedGameWithID:
+        """
+        Load the game by replaying the log file (as created by the engine)
+        at the given path.
+
+        The scores recomputed during the replay are verified against the logged ones.
+        """
+        self._check_closed()
+        try:
+            go_obj = self._go_game_engine.LoadGameFromLog(log_path)
+        except RuntimeError as exc:
+            # See the comment in `generate_ordered_game()`.
+            # TODO: map exceptions once we migrate from gopy to manually-written bindings
+            raise Exception(str(exc)) from None
+        return SerializedGameWithID(go_obj.ID, SerializedGame(go_obj.Game))
+
+    def save_game(self, game_id: int, save_path: str) -> None:
+        """
+        Save the full state of the game with the given ID to a binary file
+        at the given path. The saved game can be restored with `load_game()`.
+        """
+        self._check_closed()
+        try:
+            self._go_game_engine.SaveGame(game_id, save_path)
+        except RuntimeError as exc:
+            # See the comment in `generate_ordered_game()`.
+            # TODO: map exceptions once we migrate from gopy to manually-written bindings
+            raise Exception(str(exc)) from None
+
+    def load_game(self, save_path: str) -> SerializedGameWithID:
+        """
+        Load the game saved with `save_game()` from the file at the given path.
+
+        The loaded game gets a new ID and its log does not contain the events
+        from before the game was saved.
+        """
+        self._check_closed()
+        try:
+            go_obj = self._go_game_engine.LoadGame(save_path)
+        except RuntimeError as exc:
+            # See the comment in `generate_ordered_game()`.
+            # TODO: map exceptions once we migrate from gopy to manually-written bindings
+            raise Exception(str(exc)) from None
+        return SerializedGameWithID(go_obj.ID, SerializedGame(go_obj.Game))
+
     def clone_game(self, game_id: int, count: int) -> list[int]:
         self._check_closed()
         try:
@@ -122,6 +225,130 @@ class GameEngine:
             return
         self._go_game_engine.DeleteGames(_go.Slice_int(game_ids))
 
+    def puct_search(
+        self,
+        game_ids: Sequence[int],
+        evaluator: Callable[[list[SearchLeaf]], Sequence[SearchEvaluation]],
+        *,
+        simulations: int | None = None,
+        exploration: float | None = None,
+        width: int | None = None,
+        height: int | None = None,
+        seed: int = 0,
+    ) -> list[SearchResult]:
+        """
+        Search the current turns of the games with the given IDs with PUCT
+        (Monte Carlo Tree Search guided by the evaluator, as in AlphaZero).
+
+        The searches of all of the games run at the same time and the evaluator
+        is called once per batch with the leaves of all of them (at most one leaf
+        of each game) - it has to return the evaluation of each of the leaves,
+        in the same order. The leaves are encoded with a `width` x `height` window
+        of the board. The engine's defaults are used for the options that are not given.
+
+        The results are returned in the order of the game IDs.
+        """
+        if (width is None) != (height is None):
+            raise ValueError("width and height have to be given together")
+        self._check_closed()
+        default_config = _go_agents.DefaultPUCTConfig()
+        go_config = _go_agents.PUCTConfig(
+            Simulations=(
+                default_config.Simulations if simulations is None else simulations
+            ),
+            Exploration=(
+                default_config.Exploration if exploration is None else exploration
+            ),
+            EncodingOptions=(
+                default_config.EncodingOptions
+                if width is None
+                else _go_encoding.Options(Width=width, Height=height)
+            ),
+        )
+        try:
+            driver = self._go_game_engine.StartPUCTSearch(
+                _go.Slice_int(game_ids), go_config, seed
+            )
+        except RuntimeError as exc:
+            # See the comment in `generate_ordered_game()`.
+            # TODO: map exceptions once we migrate from gopy to manually-written bindings
+            raise Exception(str(exc)) from None
+
+        while not driver.Done():
+            go_leaves = driver.NextLeaves()
+            if len(go_leaves) == 0:
+                continue
+            evaluations = evaluator([SearchLeaf(go_leaf) for go_leaf in go_leaves])
+            go_evaluations = _go_agents.Slice_agents_SearchEvaluation(
+                _go_agents.SearchEvaluation(
+                    Priors=_go.Slice_float32(evaluation.priors),
+                    Values=_go.Slice_float32(evaluation.values),
+                )
+                for evaluation in evaluations
+            )
+            try:
+                driver.Expand(go_evaluations)
+            except RuntimeError as exc:
+                raise ValueError(str(exc)) from None
+        return [SearchResult(go_result) for go_result in driver.Results()]
+
+    def set_state_cache_size(self, size: int) -> None:
+        """
+        Set the maximum number of the game states resolved by the requests
+        that are kept in the engine's cache, 0 disables the cache.
+        """
+        self._go_game_engine.SetStateCacheSize(size)
+
+    def state_cache_stats(self) -> StateCacheStats:
+        go_obj = self._go_game_engine.StateCacheStats()
+        return StateCacheStats(
+            go_obj.Hits, go_obj.Misses, go_obj.Evictions, go_obj.Size, go_obj.Capacity
+        )
+
+    def release_states(self, game_id: int, states: Sequence[GameState]) -> None:
+        """
+        Release the given states of the game with the given ID from the engine's cache.
+        The released states can still be used in the requests
+        but they have to be resolved again.
+        """
+        if self.closed:
+            return
+        self._go_game_engine.ReleaseStates(
+            game_id,
+            _go_engine.Slice_Ptr_engine_GameState(state._unwrap() for state in states),
+        )
+
+    def send_batch(
+        self, batch_requests: Sequence[requests.AnyRequest]
+    ) -> list[requests.AnyResponse]:
+        """
+        Send a batch of requests of any kind and wait for the responses.
+
+        The type and order of the returned responses correspond
+        to the types and order of the requests.
+        """
+        return self.submit_batch(batch_requests).responses()
+
+    def submit_batch(
+        self,
+        batch_requests: Sequence[requests.AnyRequest],
+        *,
+        timeout: float | None = None,
+    ) -> requests.PendingBatch:
+        """
+        Submit a batch of requests of any kind without waiting for the responses.
+
+        The requests that don't start executing within `timeout` seconds
+        are not executed and their responses have their `exception` set.
+        """
+        self._check_closed()
+        batch_requests = list(batch_requests)
+        go_requests = _go_engine.Slice_Ptr_engine_BatchRequest(
+            requests._wrap_batch_request(req, timeout) for req in batch_requests
+        )
+        go_obj = self._go_game_engine.SubmitBatch(go_requests)
+        return requests.PendingBatch(go_obj, batch_requests)
+
     def send_play_turn_batch(
         self, concrete_requests: list[requests.PlayTurnRequest]
     ) -> list[requests.PlayTurnResponse]:
@@ -132,6 +359,16 @@ class GameEngine:
         go_obj = self._go_game_engine.SendPlayTurnBatch(go_requests)
         return [requests.PlayTurnResponse(go_resp) for go_resp in go_obj]
 
+    def send_undo_turn_batch(
+        self, concrete_requests: list[requests.UndoTurnRequest]
+    ) -> list[requests.UndoTurnResponse]:
+        self._check_closed()
+        go_requests = _go_engine.Slice_Ptr_engine_UndoTurnRequest(
+            req._unwrap() for req in concrete_requests
+        )
+        go_obj = self._go_game_engine.SendUndoTurnBatch(go_requests)
+        return [requests.UndoTurnResponse(go_resp) for go_resp in go_obj]
+
     def send_get_remaining_tiles_batch(
         self, concrete_requests: list[requests.GetRemainingTilesRequest]
     ) -> list[requests.GetRemainingTilesResponse]:
@@ -152,6 +389,46 @@ class GameEngine:
         go_obj = self._go_game_engine.SendGetLegalMovesBatch(go_requests)
         return [requests.GetLegalMovesResponse(go_resp) for go_resp in go_obj]
 
+    def send_get_successor_state_batch(
+        self, concrete_requests: list[requests.GetSuccessorStateRequest]
+    ) -> list[requests.GetSuccessorStateResponse]:
+        self._check_closed()
+        go_requests = _go_engine.Slice_Ptr_engine_GetSuccessorStateRequest(
+            req._unwrap() for req in concrete_requests
+        )
+        go_obj = self._go_game_engine.SendGetSuccessorStateBatch(go_requests)
+        return [requests.GetSuccessorStateResponse(go_resp) for go_resp in go_obj]
+
+    def send_get_state_encoding_batch(
+        self, concrete_requests: list[requests.GetStateEncodingRequest]
+    ) -> list[requests.GetStateEncodingResponse]:
+        self._check_closed()
+        go_requests = _go_engine.Slice_Ptr_engine_GetStateEncodingRequest(
+            req._unwrap() for req in concrete_requests
+        )
+        go_obj = self._go_game_engine.SendGetStateEncodingBatch(go_requests)
+        return [requests.GetStateEncodingResponse(go_resp) for go_resp in go_obj]
+
+    def send_get_legal_action_mask_batch(
+        self, concrete_requests: list[requests.GetLegalActionMaskRequest]
+    ) -> list[requests.GetLegalActionMaskResponse]:
+        self._check_closed()
+        go_requests = _go_engine.Slice_Ptr_engine_GetLegalActionMaskRequest(
+            req._unwrap() for req in concrete_requests
+        )
+        go_obj = self._go_game_engine.SendGetLegalActionMaskBatch(go_requests)
+        return [requests.GetLegalActionMaskResponse(go_resp) for go_resp in go_obj]
+
+    def send_get_chance_expansion_batch(
+        self, concrete_requests: list[requests.GetChanceExpansionRequest]
+    ) -> list[requests.GetChanceExpansionResponse]:
+        self._check_closed()
+        go_requests = _go_engine.Slice_Ptr_engine_GetChanceExpansionRequest(
+            req._unwrap() for req in concrete_requests
+        )
+        go_obj = self._go_game_engine.SendGetChanceExpansionBatch(go_requests)
+        return [requests.GetChanceExpansionResponse(go_resp) for go_resp in go_obj]
+
     def send_get_mid_game_score_batch(
         self, concrete_requests: list[requests.GetMidGameScoreRequest]
     ) -> list[requests.GetMidGameScoreResponse]:
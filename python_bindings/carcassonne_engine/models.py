@@ -1,13 +1,16 @@
+from collections.abc import Sequence
 from typing import NamedTuple
 
 from ._bindings import (  # type: ignore[attr-defined] # no stubs
+    agents as _go_agents,
+    encoding as _go_encoding,
     engine as _go_engine,
     game as _go_game,
 )
 
 __all__ = ("GameState", "SerializedGame")
 
-from .placed_tile import PlacedTile, Tile
+from .placed_tile import PlacedTile, Position, Tile
 from .player import SerializedPlayer
 from .tilesets import TileSet
 
@@ -52,6 +55,8 @@ class SerializedGame:
         "_tiles",
         "_tile_set",
         "_binary_tiles",
+        "_binary_tiles_layout",
+        "_hash",
     )
 
     def __init__(self, go_obj: _go_game.SerializedGame) -> None:
@@ -67,6 +72,8 @@ class SerializedGame:
         self._tiles = go_obj.Tiles
         self._tile_set = go_obj.TileSet
         self._binary_tiles = go_obj.BinaryTiles
+        self._binary_tiles_layout = go_obj.BinaryTilesLayout
+        self._hash = go_obj.Hash
 
     @property
     def current_tile(self) -> Tile | None:
@@ -98,8 +105,39 @@ class SerializedGame:
 
     @property
     def binary_tiles(self) -> list[int]:
+        """
+        Binary representations of all placed tiles, the tiles that are not placed
+        are equal to 0.
+
+        Bit layout (from the least significant bit):
+
+        - 0-9: fields, 10-19: roads, 20-29: cities, 30-33: city shields
+        - 34: monastery, 35: unconnected field
+        - 36-44: meeple
+        - 45-47: ID of the meeple's owner (binary, not one-hot), 0 without a meeple
+        - 48-55: X position, 56-63: Y position
+
+        This is version 2 of the layout (see `binary_tiles_layout`). Version 1 had
+        a one-hot encoded 2-bit owner and an "is placed" bit in bit 47 instead.
+        """
         return self._binary_tiles
 
+    @property
+    def binary_tiles_layout(self) -> int:
+        """
+        Version of the bit layout of `binary_tiles`, decoders should refuse
+        the versions they were not written for.
+        """
+        return self._binary_tiles_layout
+
+    @property
+    def hash(self) -> int:
+        """
+        Hash of the game state that does not depend on the order of the moves
+        that led to it, usable as a key of a transposition table.
+        """
+        return self._hash
+
 
 class SerializedGameWithID(NamedTuple):
     """
@@ -110,3 +148,153 @@ class SerializedGameWithID(NamedTuple):
 
     id: int
     game: SerializedGame
+
+
+class StateCacheStats(NamedTuple):
+    """
+    Statistics of the game engine's cache of resolved game states.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    hits: int
+    misses: int
+    evictions: int
+    size: int
+    capacity: int
+
+    @property
+    def hit_rate(self) -> float:
+        lookups = self.hits + self.misses
+        if lookups == 0:
+            return 0.0
+        return self.hits / lookups
+
+
+class StateEncoding:
+    """
+    Game state rendered into planes for the neural networks.
+
+    `planes` is a flat buffer of `uint8` values in row-major order with the shape
+    of `shape` (plane count, height, width), which can be loaded into an array with
+    e.g. `numpy.frombuffer(encoding.planes, numpy.uint8).reshape(encoding.shape)`.
+    `origin` is the position of the tile in the top-left corner of the planes
+    and `tile_histogram` holds the number of the remaining tiles of each
+    of the distinct tiles of the game's tileset.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = ("shape", "planes", "origin", "tile_histogram")
+
+    def __init__(self, go_obj: _go_encoding.Encoding) -> None:
+        self.shape = tuple(go_obj.Shape)
+        self.planes = bytes(go_obj.Planes)
+        self.origin = Position._from_go_obj(go_obj.Origin)
+        self.tile_histogram = list(go_obj.TileHistogram)
+
+
+class ActionSpace:
+    """
+    Mapping of the moves to the integer actions, used by the legal action masks.
+
+    The actions index the positions of the `width` x `height` window of the board
+    (with the tile at `origin` in its top-left corner), the distinct rotations
+    of the tile to place and the meeple placed with it.
+    The symmetric rotations of the same move are mapped to the same action.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = ("_go_obj", "origin", "width", "height", "size")
+
+    def __init__(self, go_obj: _go_encoding.ActionSpace) -> None:
+        self._go_obj = go_obj
+        self.origin = Position._from_go_obj(go_obj.Origin)
+        self.width = go_obj.Width
+        self.height = go_obj.Height
+        self.size = go_obj.Size()
+
+    def encode_move(self, tile: Tile, move: PlacedTile) -> int:
+        """
+        Return the action of the move placing the given tile.
+
+        Raises an exception if the move does not place the tile
+        or is outside of the window.
+        """
+        return self._go_obj.EncodeMove(tile._unwrap(), move._unwrap())
+
+
+class SearchLeaf:
+    """
+    State at the leaf of one of the PUCT searches that has to be evaluated.
+
+    `search` is the index of the leaf's game in the game IDs given to
+    `GameEngine.puct_search()` and `player_id` is the player to move placing `tile`.
+    `legal_actions` holds the action of each of the `legal_moves` (see `ActionSpace`)
+    or -1, if the move places the tile outside of the window of `encoding`.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = (
+        "search",
+        "player_id",
+        "tile",
+        "encoding",
+        "legal_moves",
+        "legal_actions",
+    )
+
+    def __init__(self, go_obj: _go_agents.SearchLeaf) -> None:
+        self.search = go_obj.Search
+        self.player_id = go_obj.PlayerID
+        self.tile = Tile(go_obj.Tile)
+        self.encoding = StateEncoding(go_obj.Encoding)
+        self.legal_moves = [PlacedTile(move) for move in go_obj.LegalMoves]
+        self.legal_actions = list(go_obj.LegalActions)
+
+
+class SearchEvaluation(NamedTuple):
+    """
+    Evaluation of a leaf of the PUCT search.
+
+    `priors` holds the prior probability of each of the leaf's legal moves
+    (normalized by the search) and `values` holds the expected result of the game
+    for each of the players (indexed by player ID - 1) - 1 for the winner
+    (split in case of a tie) and 0 for the other players.
+    """
+
+    priors: Sequence[float]
+    values: Sequence[float]
+
+
+class SearchResult:
+    """
+    Outcome of the PUCT search of a single game.
+
+    `visits` holds the number of visits of each of the `legal_moves`
+    of the game's current player and `value` is the mean value of all
+    of the simulations for that player.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = ("legal_moves", "visits", "value")
+
+    def __init__(self, go_obj: _go_agents.SearchResult) -> None:
+        self.legal_moves = [PlacedTile(move) for move in go_obj.LegalMoves]
+        self.visits = list(go_obj.Visits)
+        self.value = go_obj.Value
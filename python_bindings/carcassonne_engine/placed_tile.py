@@ -81,6 +81,20 @@ class PlacedTile:
     def position(self) -> Position:
         return self._position
 
+    @property
+    def recalls_abbot(self) -> bool:
+        """
+        Whether the player recalls their abbot with this move
+        instead of placing a meeple.
+        """
+        return self._go_obj.RecallAbbot
+
+    @property
+    def recalled_abbot_position(self) -> Position | None:
+        if not self._go_obj.RecallAbbot:
+            return None
+        return Position._from_go_obj(self._go_obj.RecalledAbbotPosition)
+
     def to_tile(self) -> Tile:
         return Tile(_go_elements.ToTile(self._go_obj))
 
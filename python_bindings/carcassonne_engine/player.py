@@ -13,12 +13,13 @@ class SerializedPlayer:
     The instances of this class are provided by the `GameEngine` objects.
     """
 
-    __slots__ = ("_id", "_score", "_meeple_counts")
+    __slots__ = ("_id", "_score", "_meeple_counts", "_trade_goods")
 
     def __init__(self, go_obj: _go_elements.SerializedPlayer) -> None:
         self._id = go_obj.ID
         self._score = go_obj.Score
         self._meeple_counts = list(go_obj.MeepleCounts)
+        self._trade_goods = list(go_obj.TradeGoods)
 
     @property
     def id(self) -> int:
@@ -31,3 +32,7 @@ class SerializedPlayer:
     @property
     def meeple_counts(self) -> list[int]:
         return self._meeple_counts
+
+    @property
+    def trade_goods(self) -> list[int]:
+        return self._trade_goods
@@ -1,19 +1,36 @@
-from ._bindings import engine as _go_engine  # type: ignore[attr-defined] # no stubs
-from .models import GameState, SerializedGame, Tile
+from ._bindings import (  # type: ignore[attr-defined] # no stubs
+    encoding as _go_encoding,
+    engine as _go_engine,
+)
+from .models import ActionSpace, GameState, SerializedGame, StateEncoding, Tile
 from .placed_tile import PlacedTile
 
 __all__ = (
     "BaseResponse",
     "PlayTurnRequest",
     "PlayTurnResponse",
+    "UndoTurnRequest",
+    "UndoTurnResponse",
     "GetRemainingTilesRequest",
     "GetRemainingTilesResponse",
     "TileProbability",
     "GetLegalMovesRequest",
     "GetLegalMovesResponse",
     "MoveWithState",
+    "GetSuccessorStateRequest",
+    "GetSuccessorStateResponse",
+    "GetStateEncodingRequest",
+    "GetStateEncodingResponse",
+    "GetLegalActionMaskRequest",
+    "GetLegalActionMaskResponse",
+    "GetChanceExpansionRequest",
+    "GetChanceExpansionResponse",
+    "ChanceOutcome",
     "GetMidGameScoreRequest",
     "GetMidGameScoreResponse",
+    "AnyRequest",
+    "AnyResponse",
+    "PendingBatch",
 )
 
 
@@ -72,16 +89,55 @@ class PlayTurnResponse(BaseResponse):
     The instances of this class are provided by the `GameEngine` objects.
     """
 
-    __slots__ = ("game", "final_scores")
+    __slots__ = ("game", "final_scores", "extra_turn")
 
     def __init__(self, go_obj: _go_engine.PlayTurnResponse) -> None:
         super().__init__(go_obj)
         self.game = SerializedGame(go_obj.Game) if not self.exception else None
+        # whether the same player moves again (extra turn granted by their builder)
+        self.extra_turn: bool = go_obj.ExtraTurn
         self.final_scores: dict[int, int] | None = None
         if go_obj.FinalScores:
             self.final_scores = {k: v for k, v in go_obj.FinalScores.items()}
 
 
+class UndoTurnRequest:
+    """
+    Game engine request for reverting the last turn played
+    on the game with specified ID.
+    """
+
+    __slots__ = ("_go_obj", "_game_id")
+
+    def __init__(self, *, game_id: int) -> None:
+        self._go_obj = _go_engine.UndoTurnRequest(GameID=game_id)
+        self._game_id = game_id
+
+    def _unwrap(self) -> _go_engine.UndoTurnRequest:
+        return self._go_obj
+
+    @property
+    def game_id(self) -> int:
+        return self._game_id
+
+
+class UndoTurnResponse(BaseResponse):
+    """
+    Game engine response for `UndoTurnRequest` instances.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = ("game",)
+
+    def __init__(self, go_obj: _go_engine.UndoTurnResponse) -> None:
+        super().__init__(go_obj)
+        self.game = SerializedGame(go_obj.Game) if not self.exception else None
+
+
 class GetRemainingTilesRequest:
     """
     Game engine request for getting the remaining tiles
@@ -163,9 +219,21 @@ class GetLegalMovesRequest:
     """
     Game engine request for getting the legal moves for the placeable tile
     in the game with specified ID and state.
+
+    With `skip_states`, the states resulting from the moves are not created,
+    which is much cheaper - the state of the chosen move can be created later
+    with `GetSuccessorStateRequest`. With `with_score_deltas`, the points received
+    by the players when each of the moves is played are returned as well.
     """
 
-    __slots__ = ("_go_obj", "_base_game_id", "_state_to_check", "_tile_to_place")
+    __slots__ = (
+        "_go_obj",
+        "_base_game_id",
+        "_state_to_check",
+        "_tile_to_place",
+        "_skip_states",
+        "_with_score_deltas",
+    )
 
     def __init__(
         self,
@@ -173,22 +241,30 @@ class GetLegalMovesRequest:
         base_game_id: int,
         state_to_check: GameState | None = None,
         tile_to_place: Tile,
+        skip_states: bool = False,
+        with_score_deltas: bool = False,
     ) -> None:
         if state_to_check is not None:
             self._go_obj = _go_engine.GetLegalMovesRequest(
                 BaseGameID=base_game_id,
                 StateToCheck=state_to_check._unwrap(),
                 TileToPlace=tile_to_place._unwrap(),
+                SkipStates=skip_states,
+                WithScoreDeltas=with_score_deltas,
             )
         else:
             # gopy bindings don't consider None as Go's nil for pointers
             self._go_obj = _go_engine.GetLegalMovesRequest(
                 BaseGameID=base_game_id,
                 TileToPlace=tile_to_place._unwrap(),
+                SkipStates=skip_states,
+                WithScoreDeltas=with_score_deltas,
             )
         self._base_game_id = base_game_id
         self._state_to_check = state_to_check
         self._tile_to_place = tile_to_place
+        self._skip_states = skip_states
+        self._with_score_deltas = with_score_deltas
 
     def _unwrap(self) -> _go_engine.GetLegalMovesRequest:
         return self._go_obj
@@ -205,6 +281,14 @@ class GetLegalMovesRequest:
     def tile_to_place(self) -> Tile:
         return self._tile_to_place
 
+    @property
+    def skip_states(self) -> bool:
+        return self._skip_states
+
+    @property
+    def with_score_deltas(self) -> bool:
+        return self._with_score_deltas
+
 
 class GetLegalMovesResponse(BaseResponse):
     """
@@ -237,11 +321,335 @@ class MoveWithState:
     The instances of this class are provided by the `GameEngine` objects.
     """
 
-    __slots__ = ("move", "state")
+    __slots__ = ("move", "state", "score_delta")
 
     def __init__(self, go_obj: _go_engine.MoveWithState) -> None:
         self.move = PlacedTile(go_obj.Move)
-        self.state = GameState(go_obj.State)
+        self.state = GameState(go_obj.State) if go_obj.HasState() else None
+        # the delta holds all of the players, if it was requested
+        self.score_delta = {score[0]: score[1] for score in go_obj.ScoreDelta} or None
+
+
+class GetSuccessorStateRequest:
+    """
+    Game engine request for getting the state resulting from playing the given move
+    in the game with specified ID and state, e.g. a move returned
+    for `GetLegalMovesRequest` with `skip_states` set.
+    """
+
+    __slots__ = ("_go_obj", "_base_game_id", "_state_to_check", "_move")
+
+    def __init__(
+        self,
+        *,
+        base_game_id: int,
+        state_to_check: GameState | None = None,
+        move: PlacedTile,
+    ) -> None:
+        if state_to_check is not None:
+            self._go_obj = _go_engine.GetSuccessorStateRequest(
+                BaseGameID=base_game_id,
+                StateToCheck=state_to_check._unwrap(),
+                Move=move._unwrap(),
+            )
+        else:
+            # gopy bindings don't consider None as Go's nil for pointers
+            self._go_obj = _go_engine.GetSuccessorStateRequest(
+                BaseGameID=base_game_id,
+                Move=move._unwrap(),
+            )
+        self._base_game_id = base_game_id
+        self._state_to_check = state_to_check
+        self._move = move
+
+    def _unwrap(self) -> _go_engine.GetSuccessorStateRequest:
+        return self._go_obj
+
+    @property
+    def base_game_id(self) -> int:
+        return self._base_game_id
+
+    @property
+    def state_to_check(self) -> GameState | None:
+        return self._state_to_check
+
+    @property
+    def move(self) -> PlacedTile:
+        return self._move
+
+
+class GetSuccessorStateResponse(BaseResponse):
+    """
+    Game engine response for `GetSuccessorStateRequest` instances.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = ("state",)
+
+    def __init__(self, go_obj: _go_engine.GetSuccessorStateResponse) -> None:
+        super().__init__(go_obj)
+        self.state = GameState(go_obj.State) if not self.exception else None
+
+
+class GetStateEncodingRequest:
+    """
+    Game engine request for getting the game with specified ID and state
+    rendered into planes for the neural networks.
+
+    The placeable positions are only rendered for the given `tile_to_place`.
+    The rendered window of the board is `width` x `height` tiles,
+    the engine's default is used for the dimensions that are not given.
+    """
+
+    __slots__ = (
+        "_go_obj",
+        "_base_game_id",
+        "_state_to_check",
+        "_tile_to_place",
+        "_width",
+        "_height",
+    )
+
+    def __init__(
+        self,
+        *,
+        base_game_id: int,
+        state_to_check: GameState | None = None,
+        tile_to_place: Tile | None = None,
+        width: int | None = None,
+        height: int | None = None,
+    ) -> None:
+        if (width is None) != (height is None):
+            raise ValueError("width and height have to be given together")
+        kwargs = {
+            "BaseGameID": base_game_id,
+            "Options": _go_encoding.Options(Width=width or 0, Height=height or 0),
+        }
+        # gopy bindings don't consider None as Go's nil for pointers
+        if state_to_check is not None:
+            kwargs["StateToCheck"] = state_to_check._unwrap()
+        if tile_to_place is not None:
+            kwargs["TileToPlace"] = tile_to_place._unwrap()
+        self._go_obj = _go_engine.GetStateEncodingRequest(**kwargs)
+        self._base_game_id = base_game_id
+        self._state_to_check = state_to_check
+        self._tile_to_place = tile_to_place
+        self._width = width
+        self._height = height
+
+    def _unwrap(self) -> _go_engine.GetStateEncodingRequest:
+        return self._go_obj
+
+    @property
+    def base_game_id(self) -> int:
+        return self._base_game_id
+
+    @property
+    def state_to_check(self) -> GameState | None:
+        return self._state_to_check
+
+    @property
+    def tile_to_place(self) -> Tile | None:
+        return self._tile_to_place
+
+    @property
+    def width(self) -> int | None:
+        return self._width
+
+    @property
+    def height(self) -> int | None:
+        return self._height
+
+
+class GetStateEncodingResponse(BaseResponse):
+    """
+    Game engine response for `GetStateEncodingRequest` instances.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = ("encoding",)
+
+    def __init__(self, go_obj: _go_engine.GetStateEncodingResponse) -> None:
+        super().__init__(go_obj)
+        self.encoding = StateEncoding(go_obj.Encoding) if not self.exception else None
+
+
+class GetLegalActionMaskRequest:
+    """
+    Game engine request for getting the mask of the legal actions of the current
+    player placing `tile_to_place` in the game with specified ID and state.
+
+    The actions are indexed by the positions of the `width` x `height` window
+    of the board - the same window that `GetStateEncodingRequest` renders,
+    the engine's default is used for the dimensions that are not given.
+    """
+
+    __slots__ = (
+        "_go_obj",
+        "_base_game_id",
+        "_state_to_check",
+        "_tile_to_place",
+        "_width",
+        "_height",
+    )
+
+    def __init__(
+        self,
+        *,
+        base_game_id: int,
+        tile_to_place: Tile,
+        state_to_check: GameState | None = None,
+        width: int | None = None,
+        height: int | None = None,
+    ) -> None:
+        if (width is None) != (height is None):
+            raise ValueError("width and height have to be given together")
+        kwargs = {
+            "BaseGameID": base_game_id,
+            "TileToPlace": tile_to_place._unwrap(),
+            "Options": _go_encoding.Options(Width=width or 0, Height=height or 0),
+        }
+        # gopy bindings don't consider None as Go's nil for pointers
+        if state_to_check is not None:
+            kwargs["StateToCheck"] = state_to_check._unwrap()
+        self._go_obj = _go_engine.GetLegalActionMaskRequest(**kwargs)
+        self._base_game_id = base_game_id
+        self._state_to_check = state_to_check
+        self._tile_to_place = tile_to_place
+        self._width = width
+        self._height = height
+
+    def _unwrap(self) -> _go_engine.GetLegalActionMaskRequest:
+        return self._go_obj
+
+    @property
+    def base_game_id(self) -> int:
+        return self._base_game_id
+
+    @property
+    def state_to_check(self) -> GameState | None:
+        return self._state_to_check
+
+    @property
+    def tile_to_place(self) -> Tile:
+        return self._tile_to_place
+
+    @property
+    def width(self) -> int | None:
+        return self._width
+
+    @property
+    def height(self) -> int | None:
+        return self._height
+
+
+class GetLegalActionMaskResponse(BaseResponse):
+    """
+    Game engine response for `GetLegalActionMaskRequest` instances.
+
+    `mask` holds a byte for each of the actions of `action_space`,
+    1 for the legal actions and 0 for the others.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = ("mask", "action_space")
+
+    def __init__(self, go_obj: _go_engine.GetLegalActionMaskResponse) -> None:
+        super().__init__(go_obj)
+        if self.exception:
+            self.mask = None
+            self.action_space = None
+        else:
+            self.mask = bytes(go_obj.Mask)
+            self.action_space = ActionSpace(go_obj.ActionSpace)
+
+
+class GetChanceExpansionRequest:
+    """
+    Game engine request for getting each tile that can be drawn
+    in the game with specified ID and state, along with its probability
+    and the legal moves for it.
+    """
+
+    __slots__ = ("_go_obj", "_base_game_id", "_state_to_check")
+
+    def __init__(
+        self, *, base_game_id: int, state_to_check: GameState | None = None
+    ) -> None:
+        if state_to_check is not None:
+            self._go_obj = _go_engine.GetChanceExpansionRequest(
+                BaseGameID=base_game_id,
+                StateToCheck=state_to_check._unwrap(),
+            )
+        else:
+            # gopy bindings don't consider None as Go's nil for pointers
+            self._go_obj = _go_engine.GetChanceExpansionRequest(
+                BaseGameID=base_game_id,
+            )
+        self._base_game_id = base_game_id
+        self._state_to_check = state_to_check
+
+    def _unwrap(self) -> _go_engine.GetChanceExpansionRequest:
+        return self._go_obj
+
+    @property
+    def base_game_id(self) -> int:
+        return self._base_game_id
+
+    @property
+    def state_to_check(self) -> GameState | None:
+        return self._state_to_check
+
+
+class GetChanceExpansionResponse(BaseResponse):
+    """
+    Game engine response for `GetChanceExpansionRequest` instances.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = ("outcomes",)
+
+    def __init__(self, go_obj: _go_engine.GetChanceExpansionResponse) -> None:
+        super().__init__(go_obj)
+        self.outcomes = (
+            [ChanceOutcome(go_outcome) for go_outcome in go_obj.Outcomes]
+            if not self.exception
+            else None
+        )
+
+
+class ChanceOutcome:
+    """
+    A tile that can be drawn, its probability and the legal moves for it.
+
+    This class is not meant to be instantiated by users directly
+    and should be considered read-only.
+
+    The instances of this class are provided by the `GameEngine` objects.
+    """
+
+    __slots__ = ("tile", "probability", "moves")
+
+    def __init__(self, go_obj: _go_engine.ChanceOutcome) -> None:
+        self.tile = Tile(go_obj.Tile)
+        self.probability = go_obj.Probability
+        self.moves = [MoveWithState(go_move) for go_move in go_obj.Moves]
 
 
 class GetMidGameScoreRequest:
@@ -299,3 +707,117 @@ class GetMidGameScoreResponse(BaseResponse):
             if not self.exception
             else None
         )
+
+
+AnyRequest = (
+    PlayTurnRequest
+    | UndoTurnRequest
+    | GetRemainingTilesRequest
+    | GetLegalMovesRequest
+    | GetMidGameScoreRequest
+    | GetSuccessorStateRequest
+    | GetStateEncodingRequest
+    | GetLegalActionMaskRequest
+    | GetChanceExpansionRequest
+)
+AnyResponse = (
+    PlayTurnResponse
+    | UndoTurnResponse
+    | GetRemainingTilesResponse
+    | GetLegalMovesResponse
+    | GetMidGameScoreResponse
+    | GetSuccessorStateResponse
+    | GetStateEncodingResponse
+    | GetLegalActionMaskResponse
+    | GetChanceExpansionResponse
+)
+
+# maps request types to the field of Go's BatchRequest/BatchResponse envelopes
+# and the response type
+_BATCH_FIELDS: dict[type, tuple[str, type[BaseResponse]]] = {
+    PlayTurnRequest: ("PlayTurn", PlayTurnResponse),
+    UndoTurnRequest: ("UndoTurn", UndoTurnResponse),
+    GetRemainingTilesRequest: ("GetRemainingTiles", GetRemainingTilesResponse),
+    GetLegalMovesRequest: ("GetLegalMoves", GetLegalMovesResponse),
+    GetMidGameScoreRequest: ("GetMidGameScore", GetMidGameScoreResponse),
+    GetSuccessorStateRequest: ("GetSuccessorState", GetSuccessorStateResponse),
+    GetStateEncodingRequest: ("GetStateEncoding", GetStateEncodingResponse),
+    GetLegalActionMaskRequest: ("GetLegalActionMask", GetLegalActionMaskResponse),
+    GetChanceExpansionRequest: ("GetChanceExpansion", GetChanceExpansionResponse),
+}
+
+
+def _wrap_batch_request(
+    req: AnyRequest, timeout: float | None = None
+) -> _go_engine.BatchRequest:
+    field, _ = _BATCH_FIELDS[type(req)]
+    return _go_engine.BatchRequest(
+        **{field: req._unwrap()}, Timeout=_to_go_duration(timeout)
+    )
+
+
+def _to_go_duration(seconds: float | None) -> int:
+    # Go's time.Duration is in nanoseconds, 0 means no limit
+    if seconds is None:
+        return 0
+    return max(1, int(seconds * 1_000_000_000))
+
+
+def _unwrap_batch_response(
+    req: AnyRequest, go_obj: _go_engine.BatchResponse
+) -> AnyResponse:
+    field, response_type = _BATCH_FIELDS[type(req)]
+    return response_type(getattr(go_obj, field))  # type: ignore[return-value]
+
+
+class PendingBatch:
+    """
+    Handle to a batch of requests submitted with `GameEngine.submit_batch()`.
+
+    This class is not meant to be instantiated by users directly.
+
+    Every submitted batch should eventually be waited on
+    with `wait()` or `responses()`.
+    """
+
+    __slots__ = ("_go_obj", "_batch_requests")
+
+    def __init__(
+        self, go_obj: _go_engine.PendingBatch, batch_requests: list[AnyRequest]
+    ) -> None:
+        self._go_obj = go_obj
+        self._batch_requests = batch_requests
+
+    def done(self) -> bool:
+        """Return `True`, if all of the requests in the batch are done."""
+        return self._go_obj.Done()
+
+    def wait(self, timeout: float | None = None) -> bool:
+        """
+        Wait for all of the requests in the batch to be done,
+        for at most `timeout` seconds (without a time limit, if it's `None`).
+
+        Returns `True`, if all of the requests are done.
+        """
+        return self._go_obj.Wait(_to_go_duration(timeout))
+
+    def cancel(self) -> None:
+        """
+        Cancel the requests in the batch that did not start executing yet.
+
+        The responses to these requests have their `exception` set.
+        """
+        self._go_obj.Cancel()
+
+    def responses(self) -> list[AnyResponse]:
+        """
+        Wait for all of the requests in the batch to be done and return their responses.
+
+        The type and order of the responses correspond
+        to the types and order of the requests.
+        """
+        go_obj = self._go_obj.Responses()
+        return [
+            _unwrap_batch_response(req, go_resp)
+            for req, go_resp in zip(self._batch_requests, go_obj)
+        ]
@@ -0,0 +1,64 @@
+from ._bindings import rules as _go_rules  # type: ignore[attr-defined] # no stubs
+
+__all__ = ("Ruleset", "first_edition_ruleset", "standard_ruleset")
+
+
+class Ruleset:
+    """
+    Configuration of the rules that can differ between the editions of the game.
+
+    This class is not meant to be instantiated by users directly.
+
+    If you want to get an instance of it, call the appropriate function
+    for a predefined ruleset such as `standard_ruleset()`.
+    """
+
+    __slots__ = ("_go_obj",)
+
+    def __init__(self, go_obj: _go_rules.Ruleset) -> None:
+        self._go_obj = go_obj
+
+    @property
+    def meeple_count(self) -> int:
+        """Number of normal meeples that each player starts with."""
+        return self._go_obj.MeepleCount
+
+    @meeple_count.setter
+    def meeple_count(self, value: int) -> None:
+        self._go_obj.MeepleCount = value
+
+    @property
+    def farm_city_points(self) -> int:
+        return self._go_obj.FarmCityPoints
+
+    @farm_city_points.setter
+    def farm_city_points(self, value: int) -> None:
+        self._go_obj.FarmCityPoints = value
+
+    @property
+    def farm_scoring(self) -> int:
+        """
+        The way the farmers are scored, one of the `FarmScoring` constants
+        from the `carcassonne_engine._bindings.rules` module.
+        """
+        return self._go_obj.FarmScoring
+
+    @farm_scoring.setter
+    def farm_scoring(self, value: int) -> None:
+        self._go_obj.FarmScoring = value
+
+    def _unwrap(self) -> _go_rules.Ruleset:
+        return self._go_obj
+
+
+def standard_ruleset() -> Ruleset:
+    """Rules of the current (3rd) edition of the game."""
+    return Ruleset(_go_rules.Standard())
+
+
+def first_edition_ruleset() -> Ruleset:
+    """
+    Rules of the 1st edition of the game which only differ
+    from the standard rules in the way the farmers are scored.
+    """
+    return Ruleset(_go_rules.FirstEdition())
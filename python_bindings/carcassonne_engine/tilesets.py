@@ -7,7 +7,14 @@ from ._bindings import (  # type: ignore[attr-defined] # no stubs
 )
 from .models import Tile
 
-__all__ = ("TileSet", "standard_tile_set")
+__all__ = (
+    "TileSet",
+    "gardens_tile_set",
+    "inns_and_cathedrals_tile_set",
+    "river_tile_set",
+    "standard_tile_set",
+    "traders_and_builders_tile_set",
+)
 
 
 class TileSet:
@@ -49,3 +56,41 @@ class TileSet:
 
 def standard_tile_set() -> TileSet:
     return TileSet(_go_tilesets.StandardTileSet())
+
+
+def river_tile_set() -> TileSet:
+    """
+    River expansion tiles (starting with the spring and ending with the lake)
+    that are dealt before the tiles from the standard set.
+    """
+    return TileSet(_go_tilesets.RiverTileSet())
+
+
+def inns_and_cathedrals_tile_set() -> TileSet:
+    """
+    Standard tiles extended with the inn and cathedral tiles
+    from the Inns & Cathedrals expansion.
+
+    Players of a game with these tiles also receive a big meeple.
+    """
+    return TileSet(_go_tilesets.InnsAndCathedralsTileSet())
+
+
+def traders_and_builders_tile_set() -> TileSet:
+    """
+    Standard tiles extended with the trade goods tiles
+    from the Traders & Builders expansion.
+
+    Players of a game with these tiles also receive a builder and a pig.
+    """
+    return TileSet(_go_tilesets.TradersAndBuildersTileSet())
+
+
+def gardens_tile_set() -> TileSet:
+    """
+    Standard tiles in which some of the tiles have a garden
+    (2nd edition of the game).
+
+    Players of a game with these tiles also receive an abbot.
+    """
+    return TileSet(_go_tilesets.GardensTileSet())
@@ -29,6 +29,32 @@ __all__ = (
     "single_city_edge_right_road_turn",
     "three_city_edges_connected",
     "two_city_edges_corner_connected_road_turn",
+    "river_spring",
+    "river_lake",
+    "straight_river",
+    "river_turn",
+    "straight_river_with_bridge",
+    "river_turn_with_road_turn",
+    "straight_river_with_two_city_edges",
+    "straight_river_with_city_edge",
+    "river_turn_with_city_corner",
+    "river_turn_with_monastery_and_road",
+    "four_city_edges_connected_cathedral",
+    "straight_roads_inn",
+    "roads_turn_inn",
+    "single_city_edge_straight_roads_inn",
+    "three_city_edges_connected_road_inn",
+    "monastery_with_single_road_inn",
+    "two_city_edges_corner_connected_wine",
+    "single_city_edge_straight_roads_wine",
+    "two_city_edges_up_and_down_connected_grain",
+    "two_city_edges_corner_connected_road_turn_grain",
+    "three_city_edges_connected_cloth",
+    "three_city_edges_connected_road_cloth",
+    "straight_roads_garden",
+    "roads_turn_garden",
+    "single_city_edge_no_roads_garden",
+    "two_city_edges_corner_connected_garden",
 )
 
 
@@ -130,3 +156,107 @@ def three_city_edges_connected() -> Tile:
 
 def two_city_edges_corner_connected_road_turn() -> Tile:
     return Tile(_go_tiletemplates.TwoCityEdgesCornerConnectedRoadTurn())
+
+
+def river_spring() -> Tile:
+    return Tile(_go_tiletemplates.RiverSpring())
+
+
+def river_lake() -> Tile:
+    return Tile(_go_tiletemplates.RiverLake())
+
+
+def straight_river() -> Tile:
+    return Tile(_go_tiletemplates.StraightRiver())
+
+
+def river_turn() -> Tile:
+    return Tile(_go_tiletemplates.RiverTurn())
+
+
+def straight_river_with_bridge() -> Tile:
+    return Tile(_go_tiletemplates.StraightRiverWithBridge())
+
+
+def river_turn_with_road_turn() -> Tile:
+    return Tile(_go_tiletemplates.RiverTurnWithRoadTurn())
+
+
+def straight_river_with_two_city_edges() -> Tile:
+    return Tile(_go_tiletemplates.StraightRiverWithTwoCityEdges())
+
+
+def straight_river_with_city_edge() -> Tile:
+    return Tile(_go_tiletemplates.StraightRiverWithCityEdge())
+
+
+def river_turn_with_city_corner() -> Tile:
+    return Tile(_go_tiletemplates.RiverTurnWithCityCorner())
+
+
+def river_turn_with_monastery_and_road() -> Tile:
+    return Tile(_go_tiletemplates.RiverTurnWithMonasteryAndRoad())
+
+
+def four_city_edges_connected_cathedral() -> Tile:
+    return Tile(_go_tiletemplates.FourCityEdgesConnectedCathedral())
+
+
+def straight_roads_inn() -> Tile:
+    return Tile(_go_tiletemplates.StraightRoadsInn())
+
+
+def roads_turn_inn() -> Tile:
+    return Tile(_go_tiletemplates.RoadsTurnInn())
+
+
+def single_city_edge_straight_roads_inn() -> Tile:
+    return Tile(_go_tiletemplates.SingleCityEdgeStraightRoadsInn())
+
+
+def three_city_edges_connected_road_inn() -> Tile:
+    return Tile(_go_tiletemplates.ThreeCityEdgesConnectedRoadInn())
+
+
+def monastery_with_single_road_inn() -> Tile:
+    return Tile(_go_tiletemplates.MonasteryWithSingleRoadInn())
+
+
+def two_city_edges_corner_connected_wine() -> Tile:
+    return Tile(_go_tiletemplates.TwoCityEdgesCornerConnectedWine())
+
+
+def single_city_edge_straight_roads_wine() -> Tile:
+    return Tile(_go_tiletemplates.SingleCityEdgeStraightRoadsWine())
+
+
+def two_city_edges_up_and_down_connected_grain() -> Tile:
+    return Tile(_go_tiletemplates.TwoCityEdgesUpAndDownConnectedGrain())
+
+
+def two_city_edges_corner_connected_road_turn_grain() -> Tile:
+    return Tile(_go_tiletemplates.TwoCityEdgesCornerConnectedRoadTurnGrain())
+
+
+def three_city_edges_connected_cloth() -> Tile:
+    return Tile(_go_tiletemplates.ThreeCityEdgesConnectedCloth())
+
+
+def three_city_edges_connected_road_cloth() -> Tile:
+    return Tile(_go_tiletemplates.ThreeCityEdgesConnectedRoadCloth())
+
+
+def straight_roads_garden() -> Tile:
+    return Tile(_go_tiletemplates.StraightRoadsGarden())
+
+
+def roads_turn_garden() -> Tile:
+    return Tile(_go_tiletemplates.RoadsTurnGarden())
+
+
+def single_city_edge_no_roads_garden() -> Tile:
+    return Tile(_go_tiletemplates.SingleCityEdgeNoRoadsGarden())
+
+
+def two_city_edges_corner_connected_garden() -> Tile:
+    return Tile(_go_tiletemplates.TwoCityEdgesCornerConnectedGarden())
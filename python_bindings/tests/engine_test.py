@@ -11,11 +11,21 @@ from carcassonne_engine._bindings.feature import Type as FeatureType
 from carcassonne_engine._bindings.side import Side
 from carcassonne_engine.placed_tile import Position
 from carcassonne_engine.requests import (
+    GetChanceExpansionRequest,
+    GetChanceExpansionResponse,
+    GetLegalActionMaskRequest,
     GetLegalMovesRequest,
     GetMidGameScoreRequest,
+    GetMidGameScoreResponse,
     GetRemainingTilesRequest,
+    GetRemainingTilesResponse,
+    GetStateEncodingRequest,
+    GetSuccessorStateRequest,
     PlayTurnRequest,
+    UndoTurnRequest,
+    UndoTurnResponse,
 )
+from carcassonne_engine.rules import first_edition_ruleset
 from carcassonne_engine.tilesets import TileSet, standard_tile_set
 from carcassonne_engine.utils import format_binary_tile_bits
 
@@ -201,6 +211,198 @@ def test_game_engine_send_get_remaining_tiles_batch_returns_remaining_tiles(
             ), f"could not find a tile matching the move probability {tile_prob}"
 
 
+def test_game_engine_send_get_chance_expansion_batch_returns_moves_for_each_tile(
+    tmp_path: Path,
+) -> None:
+    t1 = tiletemplates.monastery_with_single_road()
+    t2 = tiletemplates.roads_turn()
+    tiles = [t1, t2, t1]
+    tile_set = TileSet.from_tiles(
+        tiles,
+        starting_tile=tiletemplates.single_city_edge_straight_roads(),
+    )
+
+    with GameEngine(2, tmp_path) as engine:
+        game_id, game = engine.generate_game(tile_set)
+
+        request = GetChanceExpansionRequest(base_game_id=game_id)
+        (resp,) = engine.send_get_chance_expansion_batch([request])
+        assert resp.exception is None
+        assert resp.outcomes is not None
+
+    assert len(resp.outcomes) == 2
+    assert sum(outcome.probability for outcome in resp.outcomes) == approx(1)
+    for outcome in resp.outcomes:
+        assert outcome.moves
+        for move in outcome.moves:
+            assert move.move.to_tile() == outcome.tile
+
+
+def test_game_engine_send_batch_returns_responses_for_mixed_requests(
+    tmp_path: Path,
+) -> None:
+    with GameEngine(4, tmp_path) as engine:
+        game_ids = [engine.generate_game(standard_tile_set())[0] for _ in range(3)]
+        responses = engine.send_batch(
+            [
+                GetRemainingTilesRequest(base_game_id=game_ids[0]),
+                GetMidGameScoreRequest(base_game_id=game_ids[1]),
+                UndoTurnRequest(game_id=game_ids[2]),
+                GetChanceExpansionRequest(base_game_id=game_ids[0]),
+            ]
+        )
+
+    remaining_tiles_resp, mid_game_score_resp, undo_turn_resp, chance_resp = responses
+    assert isinstance(remaining_tiles_resp, GetRemainingTilesResponse)
+    assert remaining_tiles_resp.exception is None
+    assert isinstance(mid_game_score_resp, GetMidGameScoreResponse)
+    assert mid_game_score_resp.player_scores == {1: 0, 2: 0}
+    assert isinstance(undo_turn_resp, UndoTurnResponse)
+    # there's no turn to undo yet
+    assert undo_turn_resp.exception is not None
+    assert isinstance(chance_resp, GetChanceExpansionResponse)
+    assert chance_resp.outcomes
+
+
+def test_game_engine_submit_batch_returns_pending_batch(tmp_path: Path) -> None:
+    with GameEngine(4, tmp_path) as engine:
+        game_id, _ = engine.generate_game(standard_tile_set())
+        pending = engine.submit_batch(
+            [GetMidGameScoreRequest(base_game_id=game_id)], timeout=60
+        )
+
+        assert pending.wait(60)
+        assert pending.done()
+        (resp,) = pending.responses()
+
+    assert isinstance(resp, GetMidGameScoreResponse)
+    assert resp.player_scores == {1: 0, 2: 0}
+
+
+def test_game_engine_caches_states_of_legal_moves(tmp_path: Path) -> None:
+    with GameEngine(4, tmp_path) as engine:
+        engine.set_state_cache_size(1000)
+        game_id, game = engine.generate_game(standard_tile_set())
+        assert game.current_tile is not None
+        legal_moves_req = GetLegalMovesRequest(
+            base_game_id=game_id, tile_to_place=game.current_tile
+        )
+        (legal_moves_resp,) = engine.send_get_legal_moves_batch([legal_moves_req])
+        assert legal_moves_resp.moves is not None
+        states = [
+            move.state for move in legal_moves_resp.moves if move.state is not None
+        ]
+
+        (resp,) = engine.send_get_mid_game_score_batch(
+            [GetMidGameScoreRequest(base_game_id=game_id, state_to_check=states[0])]
+        )
+        assert resp.exception is None
+        stats = engine.state_cache_stats()
+        engine.release_states(game_id, states)
+        released_stats = engine.state_cache_stats()
+
+    assert stats.hit_rate == 1.0
+    assert stats.size == len(states)
+    assert released_stats.size == 0
+
+
+def test_game_engine_send_get_legal_moves_batch_can_skip_states(
+    tmp_path: Path,
+) -> None:
+    with GameEngine(2, tmp_path) as engine:
+        game_id, game = engine.generate_game(standard_tile_set())
+        assert game.current_tile is not None
+        legal_moves_req = GetLegalMovesRequest(
+            base_game_id=game_id,
+            tile_to_place=game.current_tile,
+            skip_states=True,
+            with_score_deltas=True,
+        )
+        (legal_moves_resp,) = engine.send_get_legal_moves_batch([legal_moves_req])
+        assert legal_moves_resp.moves is not None
+        move = legal_moves_resp.moves[0]
+
+        successor_req = GetSuccessorStateRequest(base_game_id=game_id, move=move.move)
+        (successor_resp,) = engine.send_get_successor_state_batch([successor_req])
+
+    assert move.state is None
+    assert move.score_delta == {1: 0, 2: 0}
+    assert successor_resp.exception is None
+    assert successor_resp.state is not None
+    assert successor_resp.state.serialized.Hash != game.hash
+
+
+def test_game_engine_send_get_state_encoding_batch_returns_planes(
+    tmp_path: Path,
+) -> None:
+    with GameEngine(1, tmp_path) as engine:
+        game_id, game = engine.generate_game(standard_tile_set())
+        request = GetStateEncodingRequest(
+            base_game_id=game_id, tile_to_place=game.current_tile, width=5, height=3
+        )
+        (resp,) = engine.send_get_state_encoding_batch([request])
+
+    assert resp.exception is None
+    assert resp.encoding is not None
+    plane_count, height, width = resp.encoding.shape
+    assert (height, width) == (9, 15)
+    assert len(resp.encoding.planes) == plane_count * height * width
+    assert sum(resp.encoding.tile_histogram) == 71
+
+
+def test_game_engine_send_get_legal_action_mask_batch_marks_legal_moves(
+    tmp_path: Path,
+) -> None:
+    with GameEngine(1, tmp_path) as engine:
+        game_id, game = engine.generate_game(standard_tile_set())
+        moves_request = GetLegalMovesRequest(
+            base_game_id=game_id, tile_to_place=game.current_tile, skip_states=True
+        )
+        (moves_resp,) = engine.send_get_legal_moves_batch([moves_request])
+        mask_request = GetLegalActionMaskRequest(
+            base_game_id=game_id, tile_to_place=game.current_tile
+        )
+        (resp,) = engine.send_get_legal_action_mask_batch([mask_request])
+
+    assert moves_resp.moves is not None
+    assert resp.exception is None
+    assert resp.mask is not None and resp.action_space is not None
+    assert len(resp.mask) == resp.action_space.size
+    assert sum(resp.mask) == len(moves_resp.moves)
+    for move in moves_resp.moves:
+        action = resp.action_space.encode_move(game.current_tile, move.move)
+        assert resp.mask[action] == 1
+
+
+def test_game_engine_puct_search_batches_leaves_of_all_games(
+    tmp_path: Path,
+) -> None:
+    batch_sizes = []
+
+    def evaluator(
+        leaves: list[models.SearchLeaf],
+    ) -> list[models.SearchEvaluation]:
+        batch_sizes.append(len(leaves))
+        return [
+            models.SearchEvaluation([1.0] * len(leaf.legal_moves), [0.5, 0.5])
+            for leaf in leaves
+        ]
+
+    with GameEngine(1, tmp_path) as engine:
+        game_ids = [engine.generate_game(standard_tile_set()).id for _ in range(2)]
+        results = engine.puct_search(
+            game_ids, evaluator, simulations=10, width=7, height=7, seed=1
+        )
+
+    assert batch_sizes[0] == len(game_ids)
+    assert all(batch_size <= len(game_ids) for batch_size in batch_sizes)
+    assert len(results) == len(game_ids)
+    for result in results:
+        assert len(result.visits) == len(result.legal_moves)
+        # the first simulation only evaluates the root
+        assert sum(result.visits) == 9
+
+
 def test_game_engine_send_get_legal_moves_batch_returns_no_duplicates(
     tmp_path: Path,
 ) -> None:
@@ -339,3 +541,98 @@ def test_game_engine_send_get_mid_game_score_batch_at_mid_game_returns_expected_
     )
 
     assert mid_game_score_response.player_scores == {1: 3, 2: 2}
+
+
+def test_game_engine_send_undo_turn_batch_restores_previous_state(
+    tmp_path: Path,
+) -> None:
+    engine = GameEngine(4, tmp_path)
+    engine.set_undo_enabled(True)
+    tile_set = standard_tile_set()
+
+    game_id, game = engine.generate_game(tile_set)
+
+    play_turn_req = PlayTurnRequest(
+        game_id=game_id, move=game.valid_tile_placements[0]
+    )
+    (play_turn_resp,) = engine.send_play_turn_batch([play_turn_req])
+    assert play_turn_resp.exception is None
+    assert play_turn_resp.game is not None
+    assert play_turn_resp.game.current_player_id == 2
+
+    undo_turn_req = UndoTurnRequest(game_id=game_id)
+    (undo_turn_resp,) = engine.send_undo_turn_batch([undo_turn_req])
+    assert undo_turn_resp.exception is None
+    assert undo_turn_resp.game is not None
+    assert undo_turn_resp.game.current_player_id == 1
+    assert undo_turn_resp.game.current_tile == game.current_tile
+    assert undo_turn_resp.game.binary_tiles == game.binary_tiles
+
+    (undo_turn_resp,) = engine.send_undo_turn_batch([undo_turn_req])
+    assert undo_turn_resp.exception is not None
+    engine.close()
+
+
+def test_game_engine_load_game_from_log_restores_played_game(tmp_path: Path) -> None:
+    engine = GameEngine(4, tmp_path)
+    tile_set = standard_tile_set()
+
+    game_id, game = engine.generate_game(tile_set, player_count=3)
+
+    for _ in range(3):
+        play_turn_req = PlayTurnRequest(
+            game_id=game_id, move=game.valid_tile_placements[0]
+        )
+        (play_turn_resp,) = engine.send_play_turn_batch([play_turn_req])
+        assert play_turn_resp.exception is None
+        assert play_turn_resp.game is not None
+        game = play_turn_resp.game
+
+    loaded_game_id, loaded_game = engine.load_game_from_log(
+        str(tmp_path / f"{game_id}.jsonl")
+    )
+    assert loaded_game_id != game_id
+    assert loaded_game.player_count == 3
+    assert loaded_game.current_player_id == game.current_player_id
+    assert loaded_game.current_tile == game.current_tile
+
+    with pytest.raises(Exception):
+        engine.load_game_from_log(str(tmp_path / "missing.jsonl"))
+    engine.close()
+
+
+def test_game_engine_load_game_restores_saved_game(tmp_path: Path) -> None:
+    engine = GameEngine(4, tmp_path)
+    tile_set = standard_tile_set()
+
+    game_id, game = engine.generate_game(tile_set)
+
+    play_turn_req = PlayTurnRequest(
+        game_id=game_id, move=game.valid_tile_placements[0]
+    )
+    (play_turn_resp,) = engine.send_play_turn_batch([play_turn_req])
+    assert play_turn_resp.exception is None
+    assert play_turn_resp.game is not None
+    game = play_turn_resp.game
+
+    save_path = str(tmp_path / "game.bin")
+    engine.save_game(game_id, save_path)
+
+    loaded_game_id, loaded_game = engine.load_game(save_path)
+    assert loaded_game_id != game_id
+    assert loaded_game.current_player_id == game.current_player_id
+    assert loaded_game.current_tile == game.current_tile
+    assert loaded_game.binary_tiles == game.binary_tiles
+    engine.close()
+
+
+def test_game_engine_generate_game_uses_given_ruleset(tmp_path: Path) -> None:
+    engine = GameEngine(4, tmp_path)
+    ruleset = first_edition_ruleset()
+    ruleset.meeple_count = 5
+
+    _, game = engine.generate_game(standard_tile_set(), ruleset=ruleset)
+
+    for player in game.players:
+        assert player.meeple_counts[MeepleType.NormalMeeple] == 5
+    engine.close()
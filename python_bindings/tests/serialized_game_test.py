@@ -2,6 +2,7 @@ import logging
 from pathlib import Path
 
 from carcassonne_engine import GameEngine
+from carcassonne_engine.requests import GetLegalMovesRequest, PlayTurnRequest
 from carcassonne_engine.tilesets import standard_tile_set
 
 log = logging.getLogger(__name__)
@@ -19,6 +20,26 @@ def test_serialized_properties(tmp_path: Path) -> None:
     assert serialized_game.tile_set is not None
     assert len(serialized_game.binary_tiles) == 72
     assert serialized_game.binary_tiles[1] == 0  # not placed tile is 0
+    assert serialized_game.binary_tiles_layout == 2
+
+
+def test_serialized_game_hash_changes_after_turn(tmp_path: Path) -> None:
+    with GameEngine(4, tmp_path) as engine:
+        game_id, game = engine.generate_game(standard_tile_set())
+        assert game.current_tile is not None
+        legal_moves_req = GetLegalMovesRequest(
+            base_game_id=game_id, tile_to_place=game.current_tile
+        )
+        (legal_moves_resp,) = engine.send_get_legal_moves_batch([legal_moves_req])
+        assert legal_moves_resp.moves is not None
+        move = legal_moves_resp.moves[0]
+        (play_turn_resp,) = engine.send_play_turn_batch(
+            [PlayTurnRequest(game_id=game_id, move=move.move)]
+        )
+
+    assert play_turn_resp.game is not None
+    assert isinstance(play_turn_resp.game.hash, int)
+    assert game.hash != play_turn_resp.game.hash
 
 
 def test_serialized_player_properties(tmp_path: Path) -> None:
@@ -39,5 +60,17 @@ def test_serialized_player_properties(tmp_path: Path) -> None:
     assert serialized_game.players[0].score == 0
     assert serialized_game.players[1].score == 0
 
-    assert serialized_game.players[0].meeple_counts == [0, 7]
-    assert serialized_game.players[1].meeple_counts == [0, 7]
+    assert serialized_game.players[0].meeple_counts == [0, 7, 0, 0, 0, 0]
+    assert serialized_game.players[1].meeple_counts == [0, 7, 0, 0, 0, 0]
+
+
+def test_serialized_player_properties_with_custom_player_count(tmp_path: Path) -> None:
+    engine = GameEngine(4, tmp_path)
+    tile_set = standard_tile_set()
+
+    serialized_game_with_id = engine.generate_game(tile_set, player_count=5)
+    serialized_game = serialized_game_with_id.game
+
+    assert len(serialized_game.players) == 5
+    assert serialized_game.player_count == 5
+    assert [player.id for player in serialized_game.players] == [1, 2, 3, 4, 5]
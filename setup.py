@@ -19,6 +19,8 @@ GO_EXCLUDED_PACKAGES = (
     # nothing depends on performance tests
     f"game{os.sep}performancetests",
     f"engine{os.sep}request_performance_tests",
+    # the server is only used through its command, not from Python
+    "server",
     "end_tests",
     f"end_tests{os.sep}four_player_game_test",
     f"end_tests{os.sep}two_player_game_test",